@@ -20,8 +20,17 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ealebed/spini/cmd"
+	"github.com/ealebed/spini/pkg/output"
 )
 
+// accountColumns represents columns of the table output for spinnaker accounts
+var accountColumns = []output.Column{
+	{Header: "name", Field: "name"},
+	{Header: "type", Field: "type"},
+	{Header: "environment", Field: "environment"},
+	{Header: "account type", Field: "accountType"},
+}
+
 type accountOptions struct {
 	*cmd.GlobalOptions
 }
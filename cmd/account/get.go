@@ -58,7 +58,7 @@ func NewGetCmd(accountOptions *accountOptions) *cobra.Command {
 }
 
 // getAccount returns actual attributes for specified account
-func getAccount(_ *cobra.Command, options *getOptions) error {
+func getAccount(cmd *cobra.Command, options *getOptions) error {
 	account, resp, err := options.GateClient.CredentialsControllerApi.GetAccountUsingGET(
 		options.GateClient.Context,
 		options.accountName,
@@ -77,7 +77,5 @@ func getAccount(_ *cobra.Command, options *getOptions) error {
 		return err
 	}
 
-	output.JsonOutput(account)
-
-	return nil
+	return output.Print(cmd.OutOrStdout(), options.OutputFormat, account, accountColumns)
 }
@@ -54,7 +54,7 @@ func NewListCmd(accountOptions *accountOptions) *cobra.Command {
 }
 
 // listAccount returns account list from spinnaker
-func listAccount(_ *cobra.Command, options *listOptions) error {
+func listAccount(cmd *cobra.Command, options *listOptions) error {
 	accountList, resp, err := options.GateClient.CredentialsControllerApi.GetAccountsUsingGET(
 		options.GateClient.Context,
 		&gate.CredentialsControllerApiGetAccountsUsingGETOpts{Expand: optional.NewBool(options.expand)})
@@ -71,7 +71,5 @@ func listAccount(_ *cobra.Command, options *listOptions) error {
 		return fmt.Errorf("encountered an error listing accounts, status code: %d", resp.StatusCode)
 	}
 
-	output.JsonOutput(accountList)
-
-	return nil
+	return output.Print(cmd.OutOrStdout(), options.OutputFormat, accountList, accountColumns)
 }
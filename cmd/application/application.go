@@ -20,11 +20,28 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ealebed/spini/cmd"
+	"github.com/ealebed/spini/pkg/output"
 )
 
 // spinnakerApplicationKey is the Gate / Orca JSON field and cobra primary command name.
 const spinnakerApplicationKey = "application"
 
+// applicationColumns represents columns of the table output for spinnaker applications
+var applicationColumns = []output.Column{
+	{Header: "name", Field: "name"},
+	{Header: "email", Field: "email"},
+	{Header: "accounts", Field: "accounts"},
+	{Header: "cloud providers", Field: "cloudProviders"},
+}
+
+// expandedApplicationColumns represents columns of the table output for expanded spinnaker application payload
+var expandedApplicationColumns = []output.Column{
+	{Header: "name", Field: "name"},
+	{Header: "email", Field: "attributes.email"},
+	{Header: "accounts", Field: "attributes.accounts"},
+	{Header: "clusters", Field: "clusters"},
+}
+
 type applicationOptions struct {
 	*cmd.GlobalOptions
 }
@@ -63,7 +63,7 @@ func NewGetCmd(applicationOptions *applicationOptions) *cobra.Command {
 }
 
 // getApplication returns actual attributes for specified application
-func getApplication(_ *cobra.Command, options *getOptions) error {
+func getApplication(cmd *cobra.Command, options *getOptions) error {
 	app, resp, err := options.GateClient.ApplicationControllerApi.GetApplicationUsingGET(
 		options.GateClient.Context,
 		options.applicationName,
@@ -88,11 +88,9 @@ func getApplication(_ *cobra.Command, options *getOptions) error {
 	if options.expand {
 		// NOTE: expand returns the actual attributes as well as the app's cluster details, nested in
 		// their own fields. This means that the expanded output can't be submitted as input to `save`.
-		output.JsonOutput(app)
-	} else {
-		// NOTE: app GET wraps the actual app attributes in an 'attributes' field.
-		output.JsonOutput(app["attributes"])
+		return output.Print(cmd.OutOrStdout(), options.OutputFormat, app, expandedApplicationColumns)
 	}
 
-	return nil
+	// NOTE: app GET wraps the actual app attributes in an 'attributes' field.
+	return output.Print(cmd.OutOrStdout(), options.OutputFormat, app["attributes"], applicationColumns)
 }
@@ -56,7 +56,7 @@ func NewListCmd(applicationOptions *applicationOptions) *cobra.Command {
 }
 
 // listApplication returns application list from spinnaker
-func listApplication(_ *cobra.Command, options listOptions) error {
+func listApplication(cmd *cobra.Command, options listOptions) error {
 	appList, resp, err := options.GateClient.ApplicationControllerApi.GetAllApplicationsUsingGET(
 		options.GateClient.Context,
 		&gate.ApplicationControllerApiGetAllApplicationsUsingGETOpts{Account: optional.NewString(options.accountName)})
@@ -73,7 +73,5 @@ func listApplication(_ *cobra.Command, options listOptions) error {
 		return fmt.Errorf("encountered an error listing application, status code: %d", resp.StatusCode)
 	}
 
-	output.JsonOutput(appList)
-
-	return nil
+	return output.Print(cmd.OutOrStdout(), options.OutputFormat, appList, applicationColumns)
 }
@@ -69,7 +69,15 @@ func NewSaveCmd(applicationOptions *applicationOptions) *cobra.Command {
 // saveApplication creates application on spinnaker from json-formatted file
 func saveApplication(_ *cobra.Command, options *saveOptions) error {
 	var a *types.Application
-	configResponse := utils.LoadConfiguration(options.localConfig, options.Organization, options.repositoryName, options.branch)
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
 
 	for _, app := range configResponse {
 		if app.Application == options.applicationName {
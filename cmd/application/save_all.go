@@ -62,7 +62,15 @@ func NewSaveAllCmd(applicationOptions *applicationOptions) *cobra.Command {
 // saveAllApplication creates spinnaker application from json-formatted files
 func saveAllApplication(_ *cobra.Command, options *saveAllOptions) error {
 	var appList []*types.Application
-	configResponse := utils.LoadConfiguration(options.localConfig, options.Organization, options.repositoryName, options.branch)
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
 
 	for _, app := range configResponse {
 		if app.SkipAutogeneration {
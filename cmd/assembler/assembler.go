@@ -22,8 +22,12 @@ import (
 	"github.com/ealebed/spini/cmd"
 	"github.com/ealebed/spini/cmd/account"
 	"github.com/ealebed/spini/cmd/application"
+	"github.com/ealebed/spini/cmd/config"
+	"github.com/ealebed/spini/cmd/diff"
 	"github.com/ealebed/spini/cmd/manifest"
 	"github.com/ealebed/spini/cmd/pipeline"
+	"github.com/ealebed/spini/cmd/plan"
+	"github.com/ealebed/spini/cmd/report"
 )
 
 // AddSubCommands adds all the subcommands to the rootCmd.
@@ -31,6 +35,11 @@ import (
 func AddSubCommands(rootCmd *cobra.Command, globalOptions *cmd.GlobalOptions) {
 	rootCmd.AddCommand(account.NewAccountCmd(globalOptions))
 	rootCmd.AddCommand(application.NewApplicationCmd(globalOptions))
+	rootCmd.AddCommand(config.NewConfigCmd(globalOptions))
+	rootCmd.AddCommand(diff.NewDiffCmd(globalOptions))
 	rootCmd.AddCommand(pipeline.NewPipelineCmd(globalOptions))
 	rootCmd.AddCommand(manifest.NewManifestCmd(globalOptions))
+	rootCmd.AddCommand(plan.NewPlanCmd(globalOptions))
+	rootCmd.AddCommand(plan.NewApplyCmd(globalOptions))
+	rootCmd.AddCommand(report.NewReportCmd(globalOptions))
 }
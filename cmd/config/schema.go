@@ -0,0 +1,41 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ealebed/spini/pkg/validation"
+)
+
+// NewSchemaCmd returns new schema command
+func NewSchemaCmd(_ *configOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "schema",
+		Short:   "print JSON Schema of configuration.json",
+		Long:    "print JSON Schema of configuration.json, suitable for editor integration and external validators",
+		Example: "spini config schema > configuration.schema.json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := fmt.Fprint(cmd.OutOrStdout(), string(validation.Schema))
+			return err
+		},
+	}
+
+	return cmd
+}
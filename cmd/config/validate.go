@@ -0,0 +1,137 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ealebed/spini/pkg/validation"
+	"github.com/ealebed/spini/utils"
+)
+
+// validateOptions represents options for validate command
+type validateOptions struct {
+	*configOptions
+	localConfig    bool
+	repositoryName string
+	branch         string
+	// podSecurityLevel turns warnings of the Pod Security Admission level into problems
+	podSecurityLevel string
+}
+
+// NewValidateCmd returns new validate command
+func NewValidateCmd(configOptions *configOptions) *cobra.Command {
+	options := &validateOptions{
+		configOptions: configOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Aliases: []string{"lint"},
+		Short:   "validate applications configuration against JSON Schema and semantic rules",
+		Long: "validate applications configuration file(s) against JSON Schema and semantic rules " +
+			"(probes, resource quantities, deployment strategy, ports, profile names) and report all problems. " +
+			"Security settings rejected by Pod Security Admission levels are reported as warnings, " +
+			"or as problems for the level set with --pod-security-level",
+		Example: "spini config validate [--repo=...] [--branch=...] [--pod-security-level=restricted]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validateConfig(cmd, options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.localConfig, "local", true, "read local configuration")
+	cmd.Flags().StringVarP(&options.repositoryName, "repo", "r", "", "GitHub repository name to read configuration from")
+	cmd.Flags().StringVarP(&options.branch, "branch", "b", "master", "branch to read configuration from")
+	cmd.Flags().StringVar(&options.podSecurityLevel, "pod-security-level", "",
+		"Pod Security Admission level (baseline, restricted) enforced in namespaces of applications")
+
+	return cmd
+}
+
+// validateConfig reports all problems found in configuration.json
+func validateConfig(cmd *cobra.Command, options *validateOptions) error {
+	if options.podSecurityLevel != "" && !validation.ValidPodSecurityLevel(options.podSecurityLevel) {
+		return fmt.Errorf("unknown pod security level %q, expected one of: %s",
+			options.podSecurityLevel, strings.Join(validation.PodSecurityLevels, ", "))
+	}
+
+	files, err := utils.LoadConfigurationFiles(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	policy, err := utils.LoadResourcePolicy(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch)
+	if err != nil {
+		return fmt.Errorf("failed to read resource policy: %w", err)
+	}
+
+	problems, err := validation.Validate(files, policy)
+	if err != nil {
+		return err
+	}
+
+	violations := validation.PodSecurity(files)
+	for _, violation := range violations {
+		if options.podSecurityLevel != "" && violation.RejectedBy(options.podSecurityLevel) {
+			problems = append(problems, violation.Problem)
+			continue
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "⚠ "+violation.String()) //nolint:errcheck // output to terminal
+	}
+	printPodSecurityLevels(cmd, violations)
+
+	if len(problems) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "✔ Configuration is valid") //nolint:errcheck // output to terminal
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintln(cmd.OutOrStdout(), "✘ "+problem.String()) //nolint:errcheck // output to terminal
+	}
+
+	return fmt.Errorf("configuration has %d problem(s)", len(problems))
+}
+
+// printPodSecurityLevels prints applications rejected under each Pod Security Admission level
+func printPodSecurityLevels(cmd *cobra.Command, violations []validation.PodSecurityViolation) {
+	for _, level := range validation.PodSecurityLevels {
+		applications := []string{}
+		for _, violation := range violations {
+			if violation.RejectedBy(level) && !slices.Contains(applications, violation.Application) {
+				applications = append(applications, violation.Application)
+			}
+		}
+
+		if len(applications) > 0 {
+			//nolint:errcheck // output to terminal
+			fmt.Fprintf(cmd.OutOrStdout(), "⚠ Pod Security %q level rejects: %s\n", level, strings.Join(applications, ", "))
+		}
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ealebed/spini/cmd"
+	"github.com/ealebed/spini/pkg/diff"
+	"github.com/ealebed/spini/pkg/output"
+	"github.com/ealebed/spini/types"
+	"github.com/ealebed/spini/utils"
+	spin "github.com/ealebed/spini/utils/spinnaker"
+)
+
+// DriftExitCode is the exit code of diff command when live state differs from generated one
+const DriftExitCode = 2
+
+var resultColumns = []output.Column{
+	{Header: "kind", Field: "kind"},
+	{Header: "name", Field: "name"},
+	{Header: "status", Field: "status"},
+}
+
+// diffOptions represents options for diff command
+type diffOptions struct {
+	*cmd.GlobalOptions
+	applicationName string
+	localConfig     bool
+	repositoryName  string
+	branch          string
+	structured      bool
+}
+
+// NewDiffCmd returns new diff command
+func NewDiffCmd(globalOptions *cmd.GlobalOptions) *cobra.Command {
+	options := &diffOptions{
+		GlobalOptions: globalOptions,
+	}
+
+	cmd := &cobra.Command{ //nolint:gocritic // shadowing cmd is common pattern in cobra
+		Use:   "diff",
+		Short: "show difference between generated and live spinnaker applications and pipelines",
+		Long: "show difference between generated and live spinnaker applications and pipelines, " +
+			"exits with code 2 when drift is detected",
+		Example: "spini diff [--name=...] [--repo=...] [--structured]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffState(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.applicationName, "name", "n", "", "spinnaker application to compare (default all applications)")
+	cmd.Flags().BoolVar(&options.localConfig, "local", true, "read local configuration")
+	cmd.Flags().StringVarP(&options.repositoryName, "repo", "r", "", "GitHub repository name to read configuration from")
+	cmd.Flags().StringVarP(&options.branch, "branch", "b", "master", "branch to read configuration from")
+	cmd.Flags().BoolVar(&options.structured, "structured", false, "print changed fields as JSON pointers instead of unified diff")
+
+	return cmd
+}
+
+// diffState compares generated applications and pipelines with their live state in spinnaker
+func diffState(command *cobra.Command, options *diffOptions) error {
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	results := []*diff.Result{}
+	found := false
+	for _, app := range configResponse {
+		if options.applicationName != "" && app.Application != options.applicationName {
+			continue
+		}
+		found = true
+
+		if app.SkipAutogeneration {
+			fmt.Fprintln(command.ErrOrStderr(), "Skip "+app.Application+" due to skip flag")
+			continue
+		}
+
+		appResults, err := diffApplication(app, options)
+		if err != nil {
+			return err
+		}
+		results = append(results, appResults...)
+	}
+
+	if !found && options.applicationName != "" {
+		return fmt.Errorf("application '%s' not found in configuration", options.applicationName)
+	}
+
+	// print machine-readable results only when output format is requested explicitly
+	if command.Flags().Changed("output") {
+		if err := output.Print(command.OutOrStdout(), options.OutputFormat, results, resultColumns); err != nil {
+			return err
+		}
+	} else if err := printResults(command.OutOrStdout(), results, options.structured); err != nil {
+		return err
+	}
+
+	drifted := 0
+	for _, result := range results {
+		if result.Status != diff.StatusInSync {
+			drifted++
+		}
+	}
+
+	if drifted > 0 {
+		return &cmd.ExitError{
+			Code: DriftExitCode,
+			Err:  fmt.Errorf("%d of %d object(s) differ from generated configuration", drifted, len(results)),
+		}
+	}
+
+	return nil
+}
+
+// diffApplication compares generated application and its pipelines with live state
+func diffApplication(app *types.Configuration, options *diffOptions) ([]*diff.Result, error) {
+	results := []*diff.Result{}
+
+	result, err := spin.DiffApplication(types.NewApplication(app), options.GateClient)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, result)
+
+	liveIDs, err := spin.LivePipelineIDs(app.Application, options.GateClient)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines, err := utils.GeneratePipelines(app, options.Organization, options.GitHubRepositoryName, liveIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate pipelines of %s: %w", app.Application, err)
+	}
+
+	for _, pipeline := range pipelines {
+		result, err = spin.DiffPipeline(pipeline, options.GateClient)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// printResults prints human-readable difference for every object
+func printResults(w io.Writer, results []*diff.Result, structured bool) error {
+	for _, result := range results {
+		switch result.Status {
+		case diff.StatusInSync:
+			fmt.Fprintf(w, "✔ %s %s is in sync\n", result.Kind, result.Name)
+			continue
+		case diff.StatusMissing:
+			fmt.Fprintf(w, "✘ %s %s doesn't exist\n", result.Kind, result.Name)
+		default:
+			fmt.Fprintf(w, "✘ %s %s differs\n", result.Kind, result.Name)
+		}
+
+		if structured {
+			for _, change := range result.Changes {
+				fmt.Fprintln(w, "  "+change.String())
+			}
+			continue
+		}
+
+		unified, err := result.Unified()
+		if err != nil {
+			return fmt.Errorf("failed to print difference for %s %s: %w", result.Kind, result.Name, err)
+		}
+		fmt.Fprint(w, unified)
+	}
+
+	return nil
+}
@@ -73,7 +73,15 @@ func deleteManifest(_ *cobra.Command, options *deleteOptions) error {
 	var filename string
 	var str []string
 
-	configResponse := utils.LoadConfiguration(options.localConfig, options.Organization, options.repositoryName, options.branch)
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
 
 	for _, app := range configResponse {
 		if app.Application == options.applicationName {
@@ -19,6 +19,7 @@ package manifest
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -73,16 +74,36 @@ func NewSaveAllCmd(manifestOptions *manifestOptions) *cobra.Command {
 
 // saveAllManifest creates manifests (or updates if already exists) for all applications in github repository
 func saveAllManifest(_ *cobra.Command, options *saveAllOptions) error {
-	configResponse := utils.LoadConfiguration(options.localConfig, options.Organization, options.repositoryName, options.branch)
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
 
 	for _, app := range configResponse {
 		if !app.SkipAutogeneration {
 			for _, profile := range *app.Profiles {
 				for _, tier := range *profile.Datacenters {
-					filePath := utils.GenerateManifests(app, tier, profile.ProfileName, options.Organization)
+					filePath, err := utils.GenerateManifests(app, tier, profile.ProfileName, options.Organization)
+					if err != nil {
+						return err
+					}
 
 					str = append(str, filePath+":"+filePath)
 					rmStr = append(rmStr, filePath)
+
+					namespacePath, err := utils.GenerateNamespaceManifests(configResponse, tier.TierName, app.Namespace, options.Organization)
+					if err != nil {
+						return err
+					}
+					if namespacePath != "" && !slices.Contains(rmStr, namespacePath) {
+						str = append(str, namespacePath+":"+namespacePath)
+						rmStr = append(rmStr, namespacePath)
+					}
 				}
 			}
 		} else {
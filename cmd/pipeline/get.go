@@ -62,7 +62,7 @@ func NewGetCmd(pipelineOptions *pipelineOptions) *cobra.Command { //nolint:dupl
 }
 
 // getPipeline returns the pipeline with the provided name from the provided application
-func getPipeline(_ *cobra.Command, options *getOptions) error {
+func getPipeline(cmd *cobra.Command, options *getOptions) error {
 	successPayload, resp, err := options.GateClient.ApplicationControllerApi.GetPipelineConfigUsingGET(
 		options.GateClient.Context,
 		options.applicationName,
@@ -83,7 +83,5 @@ func getPipeline(_ *cobra.Command, options *getOptions) error {
 			resp.StatusCode)
 	}
 
-	output.JsonOutput(successPayload)
-
-	return nil
+	return output.Print(cmd.OutOrStdout(), options.OutputFormat, successPayload, pipelineColumns)
 }
@@ -57,7 +57,7 @@ func NewListCmd(pipelineOptions *pipelineOptions) *cobra.Command { //nolint:dupl
 }
 
 // listPipeline returns the pipelines for the provided application
-func listPipeline(_ *cobra.Command, options *listOptions) error {
+func listPipeline(cmd *cobra.Command, options *listOptions) error {
 	successPayload, resp, err := options.GateClient.ApplicationControllerApi.GetPipelineConfigsForApplicationUsingGET(
 		options.GateClient.Context,
 		options.applicationName)
@@ -75,7 +75,6 @@ func listPipeline(_ *cobra.Command, options *listOptions) error {
 			options.applicationName,
 			resp.StatusCode)
 	}
-	output.JsonOutput(successPayload)
 
-	return nil
+	return output.Print(cmd.OutOrStdout(), options.OutputFormat, successPayload, pipelineColumns)
 }
@@ -20,8 +20,18 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ealebed/spini/cmd"
+	"github.com/ealebed/spini/pkg/output"
 )
 
+// pipelineColumns represents columns of the table output for spinnaker pipelines
+var pipelineColumns = []output.Column{
+	{Header: "name", Field: "name"},
+	{Header: "application", Field: "application"},
+	{Header: "id", Field: "id"},
+	{Header: "disabled", Field: "disabled"},
+	{Header: "triggers", Field: "triggers.type"},
+}
+
 type pipelineOptions struct {
 	*cmd.GlobalOptions
 }
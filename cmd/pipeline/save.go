@@ -69,12 +69,30 @@ func NewSaveCmd(pipelineOptions *pipelineOptions) *cobra.Command {
 // savePipeline creates pipeline on spinnaker application from json-formatted file
 func savePipeline(_ *cobra.Command, options *saveOptions) error {
 	var pipeList []*types.Pipeline
-	configResponse := utils.LoadConfiguration(options.localConfig, options.Organization, options.repositoryName, options.branch)
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
 
 	for _, app := range configResponse {
 		if app.Application == options.applicationName {
 			if !app.SkipAutogeneration {
-				pipeList = utils.GeneratePipelines(app, options.Organization, options.GitHubRepositoryName)
+				// dry-run output doesn't depend on live state, pipelines get deterministic IDs
+				var liveIDs map[string]string
+				if !options.DryRun {
+					if liveIDs, err = spin.LivePipelineIDs(app.Application, options.GateClient); err != nil {
+						return err
+					}
+				}
+				pipeList, err = utils.GeneratePipelines(app, options.Organization, options.GitHubRepositoryName, liveIDs)
+				if err != nil {
+					return fmt.Errorf("failed to generate pipelines of %s: %w", app.Application, err)
+				}
 			} else {
 				fmt.Println("Skip " + app.Application + " due to skip flag")
 				os.Exit(0)
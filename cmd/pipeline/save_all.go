@@ -62,11 +62,29 @@ func NewSaveAllCmd(pipelineOptions *pipelineOptions) *cobra.Command {
 // saveAllPipeline creates pipelines for all spinnaker's applications from json-formatted file
 func saveAllPipeline(_ *cobra.Command, options *saveAllOptions) error {
 	var pipeList []*types.Pipeline
-	configResponse := utils.LoadConfiguration(options.localConfig, options.Organization, options.repositoryName, options.branch)
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
 
 	for _, app := range configResponse {
 		if !app.SkipAutogeneration {
-			pipeList = utils.GeneratePipelines(app, options.Organization, options.GitHubRepositoryName)
+			// dry-run output doesn't depend on live state, pipelines get deterministic IDs
+			var liveIDs map[string]string
+			if !options.DryRun {
+				if liveIDs, err = spin.LivePipelineIDs(app.Application, options.GateClient); err != nil {
+					return err
+				}
+			}
+			pipeList, err = utils.GeneratePipelines(app, options.Organization, options.GitHubRepositoryName, liveIDs)
+			if err != nil {
+				return fmt.Errorf("failed to generate pipelines of %s: %w", app.Application, err)
+			}
 		} else {
 			fmt.Println("Skip " + app.Application + " due to skip flag")
 		}
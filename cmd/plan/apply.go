@@ -0,0 +1,192 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/spf13/cobra"
+
+	"github.com/ealebed/spini/cmd"
+	"github.com/ealebed/spini/pkg/plan"
+	"github.com/ealebed/spini/types"
+	git "github.com/ealebed/spini/utils/github"
+	spin "github.com/ealebed/spini/utils/spinnaker"
+)
+
+// applyOptions represents options for apply command
+type applyOptions struct {
+	*cmd.GlobalOptions
+	commitMessage string
+}
+
+// NewApplyCmd returns new apply command
+func NewApplyCmd(globalOptions *cmd.GlobalOptions) *cobra.Command {
+	options := &applyOptions{
+		GlobalOptions: globalOptions,
+	}
+
+	cmd := &cobra.Command{ //nolint:gocritic // shadowing cmd is common pattern in cobra
+		Use:   "apply <planfile>",
+		Short: "execute changeset saved by 'spini plan'",
+		Long: "execute exactly the changeset saved by 'spini plan', " +
+			"refusing to apply it when live state of any planned object changed since planning",
+		Example: "spini apply spini.plan.json --dry-run=false",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return applyPlan(cmd, args[0], options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.commitMessage, "commit-message", "Apply spini plan",
+		"content of the commit message with planned manifests changes")
+
+	return cmd
+}
+
+// applyPlan executes changes from the plan file
+func applyPlan(command *cobra.Command, planFile string, options *applyOptions) error {
+	p, err := plan.Read(planFile)
+	if err != nil {
+		return err
+	}
+
+	if p.Organization != options.Organization {
+		return fmt.Errorf("plan was computed for organization '%s', not '%s'", p.Organization, options.Organization)
+	}
+
+	gc := git.NewClient()
+
+	// refuse to apply stale plan: every planned object must still be in the state seen by plan command
+	stale := []string{}
+	for _, change := range p.Changes {
+		current, err := liveHash(change, p, options.GateClient, gc)
+		if err != nil {
+			return err
+		}
+		if current != change.LiveHash {
+			stale = append(stale, change.Kind+" "+change.Name)
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("live state changed since planning, run 'spini plan' again:\n  %s", strings.Join(stale, "\n  "))
+	}
+
+	printPlan(command.OutOrStdout(), p)
+
+	if !p.HasChanges() {
+		return nil
+	}
+
+	if options.DryRun {
+		fmt.Println("[DRY_RUN] Plan " + planFile + " is up to date with live state, nothing applied")
+		return nil
+	}
+
+	manifestEntries := []*github.TreeEntry{}
+	for _, change := range p.Changes {
+		if change.Action == plan.ActionNoOp {
+			continue
+		}
+
+		if change.Kind == plan.KindManifest {
+			entry, err := manifestEntry(change)
+			if err != nil {
+				return err
+			}
+			manifestEntries = append(manifestEntries, entry)
+			continue
+		}
+
+		if err := applyObjectChange(change, options); err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Kind, change.Name, err)
+		}
+	}
+
+	if len(manifestEntries) == 0 {
+		return nil
+	}
+
+	PROptions := &types.PullRequestOptions{
+		Organization:   p.Organization,
+		RepositoryName: p.ManifestsRepository,
+		AuthorName:     options.GitHubUser,
+		AuthorEmail:    options.GitHubEmail,
+		PRSubject:      "Apply spini plan",
+		PRDescription:  "Apply *.yaml manifests changes planned at " + p.CreatedAt.Format(time.RFC3339),
+		CommitMessage:  options.commitMessage,
+		CommitBranch:   "update_" + time.Now().Format("2006-01-02-1504"),
+		Entries:        manifestEntries,
+	}
+
+	if err := gc.NewPullRequest(PROptions); err != nil {
+		return fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	fmt.Println("\nManifest(s) changes succeeded")
+
+	return nil
+}
+
+// applyObjectChange saves or deletes spinnaker application or pipeline
+func applyObjectChange(change *plan.Change, options *applyOptions) error {
+	switch {
+	case change.Kind == plan.KindApplication && change.Action != plan.ActionDelete:
+		application := &types.Application{}
+		if err := json.Unmarshal(change.Desired, application); err != nil {
+			return err
+		}
+		return spin.CreateApplication(application, options.GateClient)
+	case change.Kind == plan.KindPipeline && change.Action == plan.ActionDelete:
+		application, pipelineName, _ := strings.Cut(change.Name, "/")
+		return spin.DeletePipeline(application, pipelineName, options.GateClient)
+	case change.Kind == plan.KindPipeline:
+		pipeline := &types.Pipeline{}
+		if err := json.Unmarshal(change.Desired, pipeline); err != nil {
+			return err
+		}
+		return spin.SavePipeline(pipeline, options.GateClient)
+	default:
+		return fmt.Errorf("unsupported action")
+	}
+}
+
+// manifestEntry returns git tree entry creating, updating or deleting manifest file
+func manifestEntry(change *plan.Change) (*github.TreeEntry, error) {
+	entry := &github.TreeEntry{
+		Path: github.String(change.Name),
+		Type: github.String("blob"),
+		Mode: github.String("100644"),
+	}
+
+	// entry without content and SHA deletes the file
+	if change.Action == plan.ActionDelete {
+		return entry, nil
+	}
+
+	var content string
+	if err := json.Unmarshal(change.Desired, &content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest %s: %w", change.Name, err)
+	}
+	entry.Content = github.String(content)
+
+	return entry, nil
+}
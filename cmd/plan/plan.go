@@ -0,0 +1,113 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ealebed/spini/cmd"
+	"github.com/ealebed/spini/pkg/plan"
+)
+
+// defaultPlanFile is the plan file written by plan command when --out isn't provided
+const defaultPlanFile = "spini.plan.json"
+
+// actionSymbols are prefixes of planned actions in human-readable output
+var actionSymbols = map[string]string{
+	plan.ActionCreate: "+",
+	plan.ActionUpdate: "~",
+	plan.ActionDelete: "-",
+}
+
+// planOptions represents options for plan command
+type planOptions struct {
+	*cmd.GlobalOptions
+	applicationName string
+	localConfig     bool
+	repositoryName  string
+	branch          string
+	planFile        string
+	// prune deletes all live pipelines which aren't generated, including ones not created by spini
+	prune bool
+}
+
+// NewPlanCmd returns new plan command
+func NewPlanCmd(globalOptions *cmd.GlobalOptions) *cobra.Command {
+	options := &planOptions{
+		GlobalOptions: globalOptions,
+	}
+
+	cmd := &cobra.Command{ //nolint:gocritic // shadowing cmd is common pattern in cobra
+		Use:   "plan",
+		Short: "compute changeset of spinnaker applications, pipelines and kubernetes manifests and save it to plan file",
+		Long: "compute changeset (create/update/delete/no-op per object) of spinnaker applications, pipelines " +
+			"and kubernetes manifests and save it to plan file, which can be executed with 'spini apply'",
+		Example: "spini plan [--name=...] [--repo=...] [--out=spini.plan.json]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return planChanges(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.applicationName, "name", "n", "", "spinnaker application to plan (default all applications)")
+	cmd.Flags().BoolVar(&options.localConfig, "local", true, "read local configuration")
+	cmd.Flags().StringVarP(&options.repositoryName, "repo", "r", "", "GitHub repository name to read configuration from")
+	cmd.Flags().StringVarP(&options.branch, "branch", "b", "master", "branch to read configuration from")
+	cmd.Flags().StringVar(&options.planFile, "out", defaultPlanFile, "path to save plan file")
+	cmd.Flags().BoolVar(&options.prune, "prune", false,
+		"delete all live pipelines which aren't generated, by default only pipelines created by spini are deleted")
+
+	return cmd
+}
+
+// planChanges computes changeset and writes it to plan file
+func planChanges(command *cobra.Command, options *planOptions) error {
+	p, err := buildPlan(options)
+	if err != nil {
+		return err
+	}
+
+	printPlan(command.OutOrStdout(), p)
+
+	if err := plan.Write(options.planFile, p); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(command.OutOrStdout(), "\nPlan saved to %s, execute it with: spini apply %s --dry-run=false\n",
+		options.planFile, options.planFile)
+
+	return nil
+}
+
+// printPlan prints planned actions except no-op ones and summary
+func printPlan(w io.Writer, p *plan.Plan) {
+	for _, change := range p.Changes {
+		symbol, ok := actionSymbols[change.Action]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(w, "%s %s %s %s\n", symbol, change.Action, change.Kind, change.Name)
+		for _, fieldChange := range change.Diff {
+			fmt.Fprintln(w, "    "+fieldChange.String())
+		}
+	}
+
+	fmt.Fprintln(w, p.String())
+}
@@ -0,0 +1,363 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spinnaker/spin/cmd/gateclient"
+
+	"github.com/ealebed/spini/pkg/diff"
+	"github.com/ealebed/spini/pkg/plan"
+	"github.com/ealebed/spini/types"
+	"github.com/ealebed/spini/utils"
+	git "github.com/ealebed/spini/utils/github"
+	spin "github.com/ealebed/spini/utils/spinnaker"
+)
+
+// manifestsBranch is the branch of manifests repository pull requests are based on
+const manifestsBranch = "master"
+
+// buildPlan computes changeset for all applications from configuration
+func buildPlan(options *planOptions) (*plan.Plan, error) {
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	p := plan.New(options.Organization, options.GitHubRepositoryName)
+	gc := git.NewClient()
+	found := false
+	planned := []*types.Configuration{}
+
+	for _, app := range configResponse {
+		if options.applicationName != "" && app.Application != options.applicationName {
+			continue
+		}
+		found = true
+
+		if app.SkipAutogeneration {
+			fmt.Println("Skip " + app.Application + " due to skip flag")
+			continue
+		}
+
+		changes, err := planApplication(app, options.GateClient)
+		if err != nil {
+			return nil, err
+		}
+		p.Changes = append(p.Changes, changes...)
+
+		changes, err = planManifests(app, options.Organization, options.GitHubRepositoryName, gc)
+		if err != nil {
+			return nil, err
+		}
+		p.Changes = append(p.Changes, changes...)
+
+		changes, err = planPipelines(app, options, options.GateClient)
+		if err != nil {
+			return nil, err
+		}
+		p.Changes = append(p.Changes, changes...)
+		planned = append(planned, app)
+	}
+
+	if !found && options.applicationName != "" {
+		return nil, fmt.Errorf("application '%s' not found in configuration", options.applicationName)
+	}
+
+	changes, err := planNamespaces(configResponse, planned, options.Organization, func(filePath string) ([]byte, error) {
+		return readManifest(gc, options.Organization, options.GitHubRepositoryName, filePath)
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.Changes = append(p.Changes, changes...)
+
+	return p, nil
+}
+
+// planApplication returns change of spinnaker application
+func planApplication(app *types.Configuration, gateClient *gateclient.GatewayClient) ([]*plan.Change, error) {
+	application := types.NewApplication(app)
+
+	result, err := spin.DiffApplication(application, gateClient)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := newObjectChange(result, application)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*plan.Change{change}, nil
+}
+
+// planPipelines returns changes of generated pipelines and deletion of live pipelines which aren't generated anymore.
+// Only pipelines created by spini are deleted, other live pipelines are deleted with --prune.
+func planPipelines(app *types.Configuration, options *planOptions, gateClient *gateclient.GatewayClient) ([]*plan.Change, error) {
+	changes := []*plan.Change{}
+	generated := map[string]bool{}
+
+	liveConfigs, err := spin.ListPipelineConfigs(app.Application, gateClient)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines, err := utils.GeneratePipelines(app, options.Organization, options.GitHubRepositoryName, spin.PipelineIDs(liveConfigs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate pipelines of %s: %w", app.Application, err)
+	}
+
+	for _, pipeline := range pipelines {
+		generated[pipeline.Name] = true
+
+		// DiffPipeline adopts live pipeline index and trigger settings, so saved pipeline replaces the live one
+		result, err := spin.DiffPipeline(pipeline, gateClient)
+		if err != nil {
+			return nil, err
+		}
+
+		change, err := newObjectChange(result, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	for _, live := range liveConfigs {
+		name, _ := live["name"].(string)
+		id, _ := live["id"].(string)
+		if generated[name] || (!options.prune && !utils.GeneratedPipeline(app.Application, name, id)) {
+			continue
+		}
+
+		liveHash, err := plan.HashObject(live)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &plan.Change{
+			Kind:     plan.KindPipeline,
+			Name:     app.Application + "/" + name,
+			Action:   plan.ActionDelete,
+			LiveHash: liveHash,
+		})
+	}
+
+	return changes, nil
+}
+
+// planManifests returns changes of generated manifests and deletion of manifests of the application
+// which aren't generated anymore, only directories with generated manifests are checked for deletion
+func planManifests(app *types.Configuration, organization, repositoryName string, gc *git.Client) ([]*plan.Change, error) {
+	changes := []*plan.Change{}
+	generated := map[string]bool{}
+	directories := []string{}
+
+	for _, profile := range *app.Profiles {
+		for _, tier := range *profile.Datacenters {
+			filePath := utils.ManifestPath(app, tier, profile.ProfileName)
+			generated[filePath] = true
+			if !slices.Contains(directories, path.Dir(filePath)) {
+				directories = append(directories, path.Dir(filePath))
+			}
+
+			content, err := utils.RenderManifests(app, tier, profile.ProfileName, organization)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate manifest %s: %w", filePath, err)
+			}
+
+			live, err := readManifest(gc, organization, repositoryName, filePath)
+			if err != nil {
+				return nil, err
+			}
+
+			change, err := newManifestChange(filePath, live, content)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Strings(directories)
+	for _, directory := range directories {
+		files, _, err := gc.ListDirectoryFromRepo(organization, repositoryName, manifestsBranch, directory)
+		if err != nil && !errors.Is(err, git.ErrNotFound) {
+			return nil, err
+		}
+
+		for _, filePath := range files {
+			if generated[filePath] || !strings.HasPrefix(path.Base(filePath), app.Application) {
+				continue
+			}
+
+			live, err := readManifest(gc, organization, repositoryName, filePath)
+			if err != nil {
+				return nil, err
+			}
+			if utils.ManifestApplication(live, organization) != app.Application {
+				continue
+			}
+
+			changes = append(changes, &plan.Change{
+				Kind:     plan.KindManifest,
+				Name:     filePath,
+				Action:   plan.ActionDelete,
+				LiveHash: plan.Hash(live),
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// planNamespaces returns changes of namespace manifests in clusters of planned applications, settings of the namespace
+// are merged from all applications. Namespace manifests aren't deleted, other applications may still use the namespace.
+func planNamespaces(apps, planned []*types.Configuration, organization string, readLive func(filePath string) ([]byte, error)) ([]*plan.Change, error) {
+	changes := []*plan.Change{}
+	generated := map[string]bool{}
+
+	for _, app := range planned {
+		if app.Namespace == "" || app.Namespace == "default" {
+			continue
+		}
+
+		for _, profile := range *app.Profiles {
+			for _, tier := range *profile.Datacenters {
+				filePath := utils.NamespaceManifestPath(tier.TierName, app.Namespace)
+				if generated[filePath] {
+					continue
+				}
+				generated[filePath] = true
+
+				content, err := utils.RenderNamespaceManifests(apps, app.Namespace, organization)
+				if err != nil {
+					return nil, fmt.Errorf("failed to generate manifest %s: %w", filePath, err)
+				}
+
+				live, err := readLive(filePath)
+				if err != nil {
+					return nil, err
+				}
+
+				change, err := newManifestChange(filePath, live, content)
+				if err != nil {
+					return nil, err
+				}
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// newManifestChange returns change of the manifest file from its live and generated content,
+// nil live content means that the file doesn't exist
+func newManifestChange(filePath string, live, content []byte) (*plan.Change, error) {
+	desired, err := json.Marshal(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest %s: %w", filePath, err)
+	}
+
+	change := &plan.Change{
+		Kind:     plan.KindManifest,
+		Name:     filePath,
+		Action:   plan.ActionNoOp,
+		LiveHash: plan.Hash(live),
+		Desired:  desired,
+	}
+	switch {
+	case live == nil:
+		change.Action = plan.ActionCreate
+	case string(live) != string(content):
+		change.Action = plan.ActionUpdate
+	}
+
+	return change, nil
+}
+
+// newObjectChange returns change of spinnaker application or pipeline from its diff result
+func newObjectChange(result *diff.Result, desired interface{}) (*plan.Change, error) {
+	liveHash, err := plan.HashObject(result.Live)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(desired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", result.Kind, result.Name, err)
+	}
+
+	return &plan.Change{
+		Kind:     result.Kind,
+		Name:     result.Name,
+		Action:   plan.Action(result.Status),
+		LiveHash: liveHash,
+		Desired:  raw,
+		Diff:     result.Changes,
+	}, nil
+}
+
+// liveHash returns hash of current live state of the object
+func liveHash(change *plan.Change, p *plan.Plan, gateClient *gateclient.GatewayClient, gc *git.Client) (string, error) {
+	switch change.Kind {
+	case plan.KindApplication:
+		live, err := spin.GetApplicationConfig(change.Name, gateClient)
+		if err != nil {
+			return "", err
+		}
+		return plan.HashObject(live)
+	case plan.KindPipeline:
+		application, pipelineName, _ := strings.Cut(change.Name, "/")
+		live, err := spin.GetPipelineConfig(application, pipelineName, gateClient)
+		if err != nil {
+			return "", err
+		}
+		return plan.HashObject(live)
+	case plan.KindManifest:
+		live, err := readManifest(gc, p.Organization, p.ManifestsRepository, change.Name)
+		if err != nil {
+			return "", err
+		}
+		return plan.Hash(live), nil
+	default:
+		return "", fmt.Errorf("unknown kind '%s' of %s", change.Kind, change.Name)
+	}
+}
+
+// readManifest returns content of the manifest from manifests repository, nil is returned when file doesn't exist
+func readManifest(gc *git.Client, organization, repositoryName, filePath string) ([]byte, error) {
+	content, err := gc.ReadFileFromRepo(organization, repositoryName, manifestsBranch, filePath)
+	if errors.Is(err, git.ErrNotFound) {
+		return nil, nil
+	}
+
+	return content, err
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ealebed/spini/pkg/plan"
+	"github.com/ealebed/spini/types"
+	"github.com/ealebed/spini/utils"
+)
+
+func TestPlanNamespaces(t *testing.T) {
+	newApp := func(name, namespace string, tiers ...string) *types.Configuration {
+		datacenters := []*types.Datacenter{}
+		for _, tier := range tiers {
+			datacenters = append(datacenters, &types.Datacenter{TierName: tier})
+		}
+		return &types.Configuration{
+			Application: name,
+			Namespace:   namespace,
+			Profiles:    &[]*types.Profile{{ProfileName: "production", Datacenters: &datacenters}},
+		}
+	}
+	apps := []*types.Configuration{
+		newApp("api", "team", "gke1", "gke2"),
+		newApp("worker", "team", "gke1"),
+		newApp("legacy", "default", "gke1"),
+	}
+
+	existing, err := utils.RenderNamespaceManifests(apps, "team", "ealebed")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	live := map[string][]byte{utils.NamespaceManifestPath("gke2", "team"): existing}
+
+	changes, err := planNamespaces(apps, apps, "ealebed", func(filePath string) ([]byte, error) {
+		return live[filePath], nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"datacenters/gke1/team/_namespace.yaml": plan.ActionCreate,
+		"datacenters/gke2/team/_namespace.yaml": plan.ActionNoOp,
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for _, change := range changes {
+		if change.Kind != plan.KindManifest || expected[change.Name] != change.Action {
+			t.Errorf("Unexpected %s change %s of %s", change.Action, change.Kind, change.Name)
+		}
+
+		var content string
+		if err := json.Unmarshal(change.Desired, &content); err != nil || !strings.Contains(content, "kind: Namespace") {
+			t.Errorf("Expected Namespace in desired manifest of %s, got %s, %v", change.Name, change.Desired, err)
+		}
+	}
+}
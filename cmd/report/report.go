@@ -0,0 +1,50 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ealebed/spini/cmd"
+)
+
+type reportOptions struct {
+	*cmd.GlobalOptions
+}
+
+// NewReportCmd create new report command
+func NewReportCmd(globalOptions *cmd.GlobalOptions) *cobra.Command {
+	options := &reportOptions{
+		GlobalOptions: globalOptions,
+	}
+
+	cmd := &cobra.Command{ //nolint:gocritic // shadowing cmd is common pattern in cobra
+		Use:     "report",
+		Short:   "Summarize applications configuration (configuration.json)",
+		Long:    "Summarize applications configuration (configuration.json)",
+		Example: "",
+		// report commands work offline, so skip Gate client initialization from the root command
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewResourcesCmd(options))
+
+	return cmd
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ealebed/spini/pkg/output"
+	"github.com/ealebed/spini/pkg/report"
+	"github.com/ealebed/spini/utils"
+)
+
+var resourceColumns = map[string][]output.Column{
+	report.GroupByTier: {
+		{Header: "application", Field: "application"},
+		{Header: "profile", Field: "profile"},
+		{Header: "tier", Field: "tier"},
+		{Header: "node pool", Field: "nodePool"},
+		{Header: "replicas", Field: "replicas"},
+		{Header: "pod cpu", Field: "podCpu"},
+		{Header: "pod memory", Field: "podMemory"},
+		{Header: "cpu", Field: "cpu"},
+		{Header: "memory", Field: "memory"},
+	},
+	report.GroupByApplication: {
+		{Header: "application", Field: "application"},
+		{Header: "node pool", Field: "nodePool"},
+		{Header: "replicas", Field: "replicas"},
+		{Header: "cpu", Field: "cpu"},
+		{Header: "memory", Field: "memory"},
+	},
+	report.GroupByNodePool: {
+		{Header: "node pool", Field: "nodePool"},
+		{Header: "replicas", Field: "replicas"},
+		{Header: "cpu", Field: "cpu"},
+		{Header: "memory", Field: "memory"},
+	},
+}
+
+// resourcesOptions represents options for resources command
+type resourcesOptions struct {
+	*reportOptions
+	localConfig    bool
+	repositoryName string
+	branch         string
+	groupBy        string
+}
+
+// NewResourcesCmd returns new resources command
+func NewResourcesCmd(reportOptions *reportOptions) *cobra.Command {
+	options := &resourcesOptions{
+		reportOptions: reportOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "resources",
+		Short: "summarize requested CPU and memory of applications",
+		Long: "summarize CPU and memory requested by minimum replicas of applications per datacenter, application " +
+			"or node pool, requests of pod include sidecars and init containers",
+		Example: "spini report resources [--group-by=nodePool] [--repo=...] [--branch=...] [-o json]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reportResources(cmd, options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.localConfig, "local", true, "read local configuration")
+	cmd.Flags().StringVarP(&options.repositoryName, "repo", "r", "", "GitHub repository name to read configuration from")
+	cmd.Flags().StringVarP(&options.branch, "branch", "b", "master", "branch to read configuration from")
+	cmd.Flags().StringVar(&options.groupBy, "group-by", report.GroupByTier,
+		"group requested resources by "+strings.Join(report.GroupBy, ", "))
+
+	return cmd
+}
+
+// reportResources prints requested resources of all applications, table is printed unless output format is requested
+func reportResources(cmd *cobra.Command, options *resourcesOptions) error {
+	if !slices.Contains(report.GroupBy, options.groupBy) {
+		return fmt.Errorf("unknown grouping %q, expected one of: %s", options.groupBy, strings.Join(report.GroupBy, ", "))
+	}
+
+	configResponse, err := utils.LoadConfiguration(
+		options.localConfig,
+		options.Organization,
+		options.repositoryName,
+		options.branch,
+		options.ConfigurationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	format := output.FormatTable
+	if cmd.Flags().Changed("output") {
+		format = options.OutputFormat
+	}
+
+	usages, err := report.Resources(configResponse, options.groupBy)
+	if err != nil {
+		return fmt.Errorf("failed to report resources: %w", err)
+	}
+
+	return output.Print(cmd.OutOrStdout(), format, usages, resourceColumns[options.groupBy])
+}
@@ -29,6 +29,7 @@ import (
 
 type GlobalOptions struct {
 	configPath           string
+	ConfigurationPath    string
 	gateEndpoint         string
 	Organization         string
 	GitHubUser           string
@@ -56,12 +57,15 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *GlobalOptions)
 	cmd.PersistentFlags().StringVar(&options.configPath, "config", "", "path to config file (default $HOME/.spin/config)")
 	cmd.PersistentFlags().StringVar(&options.gateEndpoint, "gate-endpoint", "", "Gate (API server) endpoint (default http://localhost:8084)")
 
-	// TODO: configure colored/formatted output
-	// cmd.PersistentFlags().StringVar(&options.OutputFormat, "output", "", "configure output formatting")
+	// Output flags
+	cmd.PersistentFlags().StringVarP(&options.OutputFormat, "output", "o", "json",
+		"output format: json, yaml, table, jsonpath=<expression> or go-template=<template>")
 
 	// Other flags
 	cmd.PersistentFlags().BoolVar(&options.DryRun, "dry-run", true, "print output / save generated files without real changing system configuration")
 	cmd.PersistentFlags().StringVar(&options.Organization, "org", "ealebed", "source owner organization")
+	cmd.PersistentFlags().StringVar(&options.ConfigurationPath, "config-path", "configuration.json",
+		"path to applications configuration: JSON/YAML file, directory or glob pattern (e.g. apps/*.yaml), local or inside GitHub repository")
 
 	// Initialize GateClient
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
@@ -89,3 +93,17 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *GlobalOptions)
 
 	return cmd, options
 }
+
+// ExitError is returned by commands which report result with specific exit code, e.g. detected drift
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
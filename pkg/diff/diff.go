@@ -0,0 +1,299 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ChangeAdded marks value present only in generated object
+	ChangeAdded = "added"
+	// ChangeRemoved marks value present only in live object
+	ChangeRemoved = "removed"
+	// ChangeModified marks value which differs between generated and live objects
+	ChangeModified = "modified"
+)
+
+// ServerManagedFields lists top level fields maintained by Spinnaker itself, they are ignored by comparison
+var ServerManagedFields = []string{"updateTs", "createTs", "lastModifiedBy", "index"}
+
+// ServerManagedFieldsByKind lists top level fields which Spinnaker maintains for objects of the kind,
+// e.g. application accounts are collected from its clusters and user is the author of the last change
+var ServerManagedFieldsByKind = map[string][]string{
+	"application": {"accounts", "user"},
+}
+
+// Change represents single difference between generated and live objects
+type Change struct {
+	// Path is a JSON pointer (RFC 6901) to the changed value
+	Path string `json:"path"`
+	// Kind is one of ChangeAdded, ChangeRemoved or ChangeModified
+	Kind string `json:"kind"`
+	// Live is the value in live object
+	Live interface{} `json:"live,omitempty"`
+	// Generated is the value in generated object
+	Generated interface{} `json:"generated,omitempty"`
+}
+
+// String returns human-readable representation of the change
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return "+ " + c.Path + ": " + formatValue(c.Generated)
+	case ChangeRemoved:
+		return "- " + c.Path + ": " + formatValue(c.Live)
+	default:
+		return "~ " + c.Path + ": " + formatValue(c.Live) + " -> " + formatValue(c.Generated)
+	}
+}
+
+// Normalize converts object into generic JSON value and removes server-managed fields
+func Normalize(obj interface{}) (interface{}, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object: %v", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal object: %v", err)
+	}
+
+	if m, ok := generic.(map[string]interface{}); ok {
+		for _, field := range ServerManagedFields {
+			delete(m, field)
+		}
+	}
+
+	return generic, nil
+}
+
+// PruneLive removes fields of normalized live object which can't be emitted for the generated Go type:
+// fields unknown to the type, e.g. added by Spinnaker itself, and empty values of fields omitted when empty.
+// Values of maps and interfaces of the type aren't pruned.
+func PruneLive(live interface{}, generatedType reflect.Type) interface{} {
+	for generatedType != nil && generatedType.Kind() == reflect.Ptr {
+		generatedType = generatedType.Elem()
+	}
+	if generatedType == nil {
+		return live
+	}
+
+	switch generatedType.Kind() {
+	case reflect.Struct:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		fields := jsonFields(generatedType)
+		for key, value := range liveMap {
+			field, ok := fields[key]
+			switch {
+			case !ok, field.omitEmpty && omitted(value, field.Type):
+				delete(liveMap, key)
+			default:
+				liveMap[key] = PruneLive(value, field.Type)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if liveList, ok := live.([]interface{}); ok {
+			for i := range liveList {
+				liveList[i] = PruneLive(liveList[i], generatedType.Elem())
+			}
+		}
+	}
+
+	return live
+}
+
+// jsonField is a field of Go struct in JSON representation
+type jsonField struct {
+	reflect.StructField
+	omitEmpty bool
+}
+
+// jsonFields returns fields of Go struct by their JSON names, fields of embedded structs are included
+func jsonFields(structType reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		embeddedType := field.Type
+		if embeddedType.Kind() == reflect.Ptr {
+			embeddedType = embeddedType.Elem()
+		}
+		if field.Anonymous && name == "" && embeddedType.Kind() == reflect.Struct {
+			for embeddedName, embedded := range jsonFields(embeddedType) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embedded
+				}
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = jsonField{StructField: field, omitEmpty: strings.Contains(options, "omitempty")}
+	}
+
+	return fields
+}
+
+// omitted checks if live value of the Go type is never emitted with omitempty option,
+// e.g. false of bool field or null of pointer field, structs are always emitted
+func omitted(value interface{}, fieldType reflect.Type) bool {
+	switch fieldType.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value == nil
+	case reflect.Slice, reflect.Map, reflect.Array:
+		switch v := value.(type) {
+		case nil:
+			return true
+		case []interface{}:
+			return len(v) == 0
+		case map[string]interface{}:
+			return len(v) == 0
+		}
+	case reflect.Bool:
+		return value == false
+	case reflect.String:
+		return value == ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return value == float64(0)
+	}
+
+	return false
+}
+
+// Compare returns sorted list of changes between normalized live and generated objects
+func Compare(live, generated interface{}) []Change {
+	changes := compare("", live, generated)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes
+}
+
+func compare(path string, live, generated interface{}) []Change {
+	liveMap, liveIsMap := live.(map[string]interface{})
+	generatedMap, generatedIsMap := generated.(map[string]interface{})
+	if liveIsMap && generatedIsMap {
+		changes := []Change{}
+		for key, liveValue := range liveMap {
+			generatedValue, ok := generatedMap[key]
+			if !ok {
+				changes = append(changes, Change{Path: path + "/" + escape(key), Kind: ChangeRemoved, Live: liveValue})
+				continue
+			}
+			changes = append(changes, compare(path+"/"+escape(key), liveValue, generatedValue)...)
+		}
+		for key, generatedValue := range generatedMap {
+			if _, ok := liveMap[key]; !ok {
+				changes = append(changes, Change{Path: path + "/" + escape(key), Kind: ChangeAdded, Generated: generatedValue})
+			}
+		}
+
+		return changes
+	}
+
+	liveList, liveIsList := live.([]interface{})
+	generatedList, generatedIsList := generated.([]interface{})
+	if liveIsList && generatedIsList {
+		changes := []Change{}
+		for i := 0; i < len(liveList) || i < len(generatedList); i++ {
+			itemPath := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(generatedList):
+				changes = append(changes, Change{Path: itemPath, Kind: ChangeRemoved, Live: liveList[i]})
+			case i >= len(liveList):
+				changes = append(changes, Change{Path: itemPath, Kind: ChangeAdded, Generated: generatedList[i]})
+			default:
+				changes = append(changes, compare(itemPath, liveList[i], generatedList[i])...)
+			}
+		}
+
+		return changes
+	}
+
+	if reflect.DeepEqual(live, generated) {
+		return nil
+	}
+
+	return []Change{{Path: path, Kind: ChangeModified, Live: live, Generated: generated}}
+}
+
+// Unified returns unified diff of pretty-printed normalized live and generated objects,
+// empty string is returned when objects are equal
+func Unified(name string, live, generated interface{}) (string, error) {
+	liveLines, err := prettyLines(live)
+	if err != nil {
+		return "", err
+	}
+	generatedLines, err := prettyLines(generated)
+	if err != nil {
+		return "", err
+	}
+
+	return unifiedLines("live/"+name, "generated/"+name, liveLines, generatedLines, 3), nil
+}
+
+// prettyLines returns lines of indented JSON representation, nil value has no lines
+func prettyLines(obj interface{}) ([]string, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	pretty, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object: %v", err)
+	}
+
+	return strings.Split(string(pretty), "\n"), nil
+}
+
+// formatValue returns compact JSON representation of the value
+func formatValue(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(raw)
+}
+
+// escape escapes JSON pointer reference token
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
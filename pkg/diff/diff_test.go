@@ -0,0 +1,244 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ealebed/spini/types"
+)
+
+func TestNormalize(t *testing.T) {
+	normalized, err := Normalize(struct {
+		Name     string `json:"name"`
+		Index    int    `json:"index"`
+		UpdateTs string `json:"updateTs"`
+	}{Name: "deploy", Index: 3, UpdateTs: "1650000000000"})
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+
+	m, ok := normalized.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected map, got %T", normalized)
+	}
+	if len(m) != 1 || m["name"] != "deploy" {
+		t.Errorf("Expected only name field, got %v", m)
+	}
+
+	if normalized, err := Normalize(map[string]interface{}(nil)); err != nil || normalized != nil {
+		t.Errorf("Expected nil for nil map, got %v, %v", normalized, err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	live := map[string]interface{}{
+		"name":     "deploy",
+		"disabled": true,
+		"roles":    []interface{}{"devops", "team-1"},
+		"triggers": []interface{}{map[string]interface{}{"type": "docker", "tag": "^.*$"}},
+		"a/b":      "x",
+	}
+	generated := map[string]interface{}{
+		"name":     "deploy",
+		"disabled": false,
+		"roles":    []interface{}{"devops"},
+		"triggers": []interface{}{map[string]interface{}{"type": "docker"}},
+		"stages":   []interface{}{},
+	}
+
+	expected := []string{
+		`- /a~1b: "x"`,
+		"~ /disabled: true -> false",
+		`- /roles/1: "team-1"`,
+		"+ /stages: []",
+		`- /triggers/0/tag: "^.*$"`,
+	}
+
+	changes := Compare(live, generated)
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for i := range expected {
+		if changes[i].String() != expected[i] {
+			t.Errorf("Expected change %q, got %q", expected[i], changes[i].String())
+		}
+	}
+
+	if changes := Compare(generated, generated); len(changes) != 0 {
+		t.Errorf("Expected no changes for equal objects, got %v", changes)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name      string
+		live      interface{}
+		generated interface{}
+		expected  string
+	}{
+		{
+			name:      "equal objects",
+			live:      map[string]interface{}{"name": "deploy"},
+			generated: map[string]interface{}{"name": "deploy"},
+			expected:  "",
+		},
+		{
+			name:      "changed field",
+			live:      map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6},
+			generated: map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 7},
+			expected: "--- live/pipeline/app/deploy\n+++ generated/pipeline/app/deploy\n" +
+				"@@ -4,5 +4,5 @@\n" +
+				`   "c": 3,` + "\n" +
+				`   "d": 4,` + "\n" +
+				`   "e": 5,` + "\n" +
+				`-  "f": 6` + "\n" +
+				`+  "f": 7` + "\n" +
+				" }\n",
+		},
+		{
+			name:      "missing live object",
+			live:      nil,
+			generated: map[string]interface{}{"name": "deploy"},
+			expected: "--- live/pipeline/app/deploy\n+++ generated/pipeline/app/deploy\n" +
+				"@@ -0,0 +1,3 @@\n" +
+				"+{\n" +
+				`+  "name": "deploy"` + "\n" +
+				"+}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Unified("pipeline/app/deploy", tt.live, tt.generated)
+			if err != nil {
+				t.Fatalf("Unified returned error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnifiedSeparateHunks(t *testing.T) {
+	a := strings.Split("1 2 3 4 5 6 7 8 9 10 11 12 13 14 15", " ")
+	b := strings.Split("0 2 3 4 5 6 7 8 9 10 11 12 13 14 16", " ")
+
+	result := unifiedLines("a", "b", a, b, 3)
+	if strings.Count(result, "@@ -") != 2 {
+		t.Errorf("Expected 2 hunks, got:\n%s", result)
+	}
+	if !strings.Contains(result, "@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n") {
+		t.Errorf("Unexpected first hunk:\n%s", result)
+	}
+	if !strings.Contains(result, "@@ -12,4 +12,4 @@\n 12\n 13\n 14\n-15\n+16\n") {
+		t.Errorf("Unexpected second hunk:\n%s", result)
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	generated := map[string]interface{}{"name": "deploy", "disabled": false}
+
+	tests := []struct {
+		name     string
+		live     interface{}
+		expected string
+	}{
+		{name: "missing", live: map[string]interface{}(nil), expected: StatusMissing},
+		{name: "in sync ignoring server-managed fields", live: map[string]interface{}{
+			"name": "deploy", "disabled": false, "updateTs": "1650000000000", "lastModifiedBy": "anonymous", "index": 2,
+		}, expected: StatusInSync},
+		{name: "drifted", live: map[string]interface{}{"name": "deploy", "disabled": true}, expected: StatusDrifted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewResult("pipeline", "app/deploy", tt.live, generated)
+			if err != nil {
+				t.Fatalf("NewResult returned error: %v", err)
+			}
+			if result.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s (%v)", tt.expected, result.Status, result.Changes)
+			}
+		})
+	}
+}
+
+func TestNewResultGatePayload(t *testing.T) {
+	app := &types.Configuration{Application: "myapp", Type: "service", OwnerEmail: "owner@example.com", Owners: "team-1"}
+	pipeline := types.NewBuildPipeline(app)
+	pipeline.ID = "6a3b2c1d-0000-5000-8000-000000000001"
+
+	tests := []struct {
+		name      string
+		kind      string
+		generated interface{}
+		extra     string
+	}{
+		{
+			name:      "application",
+			kind:      "application",
+			generated: types.NewApplication(app),
+			extra: `{"accounts": "gke1,gke2", "user": "[anonymous]", "createTs": "1650000000000",
+				"updateTs": "1650000000001", "lastModifiedBy": "deployer@example.com", "instancePort": 80,
+				"enableRestartRunningExecutions": false, "platformHealthOnly": false, "platformHealthOnlyShowOverride": false}`,
+		},
+		{
+			name:      "pipeline",
+			kind:      "pipeline",
+			generated: pipeline,
+			extra: `{"schema": "1", "index": 3, "updateTs": "1650000000001", "lastModifiedBy": "deployer@example.com",
+				"disabled": false, "keepWaitingPipelines": false, "spelEvaluator": "v4", "expectedArtifacts": [], "notifications": []}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.generated)
+			if err != nil {
+				t.Fatalf("failed to marshal generated object: %v", err)
+			}
+			live := map[string]interface{}{}
+			if err := json.Unmarshal(raw, &live); err != nil {
+				t.Fatalf("failed to unmarshal generated object: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.extra), &live); err != nil {
+				t.Fatalf("failed to unmarshal server-side fields: %v", err)
+			}
+
+			result, err := NewResult(tt.kind, "myapp", live, tt.generated)
+			if err != nil {
+				t.Fatalf("NewResult returned error: %v", err)
+			}
+			if result.Status != StatusInSync {
+				t.Errorf("Expected status %s, got %s (%v)", StatusInSync, result.Status, result.Changes)
+			}
+
+			live["name"] = "renamed"
+			result, err = NewResult(tt.kind, "myapp", live, tt.generated)
+			if err != nil {
+				t.Fatalf("NewResult returned error: %v", err)
+			}
+			if result.Status != StatusDrifted || len(result.Changes) != 1 || result.Changes[0].Path != "/name" {
+				t.Errorf("Expected only /name change, got %s (%v)", result.Status, result.Changes)
+			}
+		})
+	}
+}
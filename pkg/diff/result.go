@@ -0,0 +1,91 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import "reflect"
+
+const (
+	// StatusInSync means live object is equal to generated one
+	StatusInSync = "in-sync"
+	// StatusDrifted means live object differs from generated one
+	StatusDrifted = "drifted"
+	// StatusMissing means generated object doesn't exist live
+	StatusMissing = "missing"
+)
+
+// Result represents comparison of single generated object with its live state
+type Result struct {
+	// Kind of the object, e.g. application or pipeline
+	Kind string `json:"kind"`
+	// Name of the object
+	Name string `json:"name"`
+	// Status is one of StatusInSync, StatusDrifted or StatusMissing
+	Status string `json:"status"`
+	// Changes between live and generated objects
+	Changes []Change `json:"changes,omitempty"`
+	// Live is normalized live object, nil when object doesn't exist
+	Live interface{} `json:"-"`
+	// Generated is normalized generated object
+	Generated interface{} `json:"-"`
+}
+
+// NewResult normalizes and compares live and generated objects, live is nil when object doesn't exist.
+// Fields of live object which are maintained by Spinnaker or can't be emitted for generated object are ignored.
+func NewResult(kind, name string, live, generated interface{}) (*Result, error) {
+	normalizedLive, err := Normalize(live)
+	if err != nil {
+		return nil, err
+	}
+	normalizedGenerated, err := Normalize(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedLive = PruneLive(normalizedLive, reflect.TypeOf(generated))
+	for _, normalized := range []interface{}{normalizedLive, normalizedGenerated} {
+		if m, ok := normalized.(map[string]interface{}); ok {
+			for _, field := range ServerManagedFieldsByKind[kind] {
+				delete(m, field)
+			}
+		}
+	}
+
+	result := &Result{
+		Kind:      kind,
+		Name:      name,
+		Status:    StatusInSync,
+		Live:      normalizedLive,
+		Generated: normalizedGenerated,
+	}
+
+	if normalizedLive == nil {
+		result.Status = StatusMissing
+		return result, nil
+	}
+
+	result.Changes = Compare(normalizedLive, normalizedGenerated)
+	if len(result.Changes) > 0 {
+		result.Status = StatusDrifted
+	}
+
+	return result, nil
+}
+
+// Unified returns unified diff between live and generated objects
+func (r *Result) Unified() (string, error) {
+	return Unified(r.Kind+"/"+r.Name, r.Live, r.Generated)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// edit represents single line of the edit script: ' ' kept, '-' removed or '+' added line
+type edit struct {
+	op   byte
+	line string
+	// a and b are line indexes in old and new text before this edit is applied
+	a, b int
+}
+
+// editScript returns shortest edit script transforming a into b based on longest common subsequence
+func editScript(a, b []string) []edit {
+	// lcs[i][j] is the length of longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{op: ' ', line: a[i], a: i, b: j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{op: '-', line: a[i], a: i, b: j})
+			i++
+		default:
+			edits = append(edits, edit{op: '+', line: b[j], a: i, b: j})
+			j++
+		}
+	}
+
+	return edits
+}
+
+// unifiedLines returns unified diff of two texts with the given number of context lines
+func unifiedLines(fromName, toName string, a, b []string, context int) string {
+	edits := editScript(a, b)
+
+	var sb strings.Builder
+	for start := 0; start < len(edits); {
+		// find next changed line
+		for start < len(edits) && edits[start].op == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+
+		// extend hunk while changes are separated by no more than 2*context kept lines
+		end := start
+		for last := start; last < len(edits); last++ {
+			if edits[last].op != ' ' {
+				end = last
+			} else if last-end > 2*context {
+				break
+			}
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context+1, len(edits))
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&sb, edits[from:to])
+
+		start = to
+	}
+
+	return sb.String()
+}
+
+// writeHunk writes hunk header and lines
+func writeHunk(sb *strings.Builder, hunk []edit) {
+	aCount, bCount := 0, 0
+	for _, e := range hunk {
+		if e.op != '+' {
+			aCount++
+		}
+		if e.op != '-' {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(hunk[0].a, aCount), hunkRange(hunk[0].b, bCount))
+	for _, e := range hunk {
+		sb.WriteByte(e.op)
+		sb.WriteString(e.line)
+		sb.WriteByte('\n')
+	}
+}
+
+// hunkRange returns hunk range in unified diff format, lines are numbered from 1
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,287 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	FormatJSON       = "json"
+	FormatYAML       = "yaml"
+	FormatTable      = "table"
+	FormatJSONPath   = "jsonpath"
+	FormatGoTemplate = "go-template"
+)
+
+// Formats lists supported values of the --output flag
+var Formats = []string{FormatJSON, FormatYAML, FormatTable, FormatJSONPath + "=...", FormatGoTemplate + "=..."}
+
+// Column describes single column of the table output
+type Column struct {
+	// Header is the column title printed in the first row
+	Header string
+	// Field is a dot-separated path to the value inside each row object, e.g. "attributes.email"
+	Field string
+}
+
+// Printer renders objects returned by Gate in the selected format
+type Printer interface {
+	Print(w io.Writer, input interface{}) error
+}
+
+type jsonPrinter struct{}
+
+type yamlPrinter struct{}
+
+type tablePrinter struct {
+	columns []Column
+}
+
+type jsonPathPrinter struct {
+	parser *jsonpath.JSONPath
+}
+
+type templatePrinter struct {
+	template *template.Template
+}
+
+// NewPrinter returns printer for the provided output format. Columns are used only by the table format.
+func NewPrinter(format string, columns []Column) (Printer, error) {
+	name, arg, _ := strings.Cut(format, "=")
+
+	switch name {
+	case "", FormatJSON:
+		return &jsonPrinter{}, nil
+	case FormatYAML:
+		return &yamlPrinter{}, nil
+	case FormatTable:
+		if len(columns) == 0 {
+			return nil, fmt.Errorf("table output is not supported for this command")
+		}
+		return &tablePrinter{columns: columns}, nil
+	case FormatJSONPath:
+		if arg == "" {
+			return nil, fmt.Errorf("jsonpath output requires an expression, e.g. jsonpath='{.name}'")
+		}
+		parser := jsonpath.New("output").AllowMissingKeys(true)
+		if err := parser.Parse(relaxedJSONPath(arg)); err != nil {
+			return nil, fmt.Errorf("failed to parse jsonpath expression %q: %v", arg, err)
+		}
+		return &jsonPathPrinter{parser: parser}, nil
+	case FormatGoTemplate:
+		if arg == "" {
+			return nil, fmt.Errorf("go-template output requires a template, e.g. go-template='{{.name}}'")
+		}
+		tmpl, err := template.New("output").Option("missingkey=zero").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse go-template %q: %v", arg, err)
+		}
+		return &templatePrinter{template: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, supported formats: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Print renders input to w in the provided output format
+func Print(w io.Writer, format string, input interface{}, columns []Column) error {
+	printer, err := NewPrinter(format, columns)
+	if err != nil {
+		return err
+	}
+
+	return printer.Print(w, input)
+}
+
+func (p *jsonPrinter) Print(w io.Writer, input interface{}) error {
+	res, err := MarshalToJson(input)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(res))
+	return err
+}
+
+func (p *yamlPrinter) Print(w io.Writer, input interface{}) error {
+	// yaml.v2 ignores json tags, so go through generic representation to keep field names consistent
+	generic, err := toGeneric(input)
+	if err != nil {
+		return err
+	}
+
+	res, err := MarshalToYaml(generic)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(w, string(res))
+	return err
+}
+
+func (p *tablePrinter) Print(w io.Writer, input interface{}) error {
+	generic, err := toGeneric(input)
+	if err != nil {
+		return err
+	}
+
+	var rows []interface{}
+	if list, ok := generic.([]interface{}); ok {
+		rows = list
+	} else if generic != nil {
+		rows = []interface{}{generic}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+
+	headers := make([]string, 0, len(p.columns))
+	for _, column := range p.columns {
+		headers = append(headers, strings.ToUpper(column.Header))
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t")) //nolint:errcheck // errors are reported by Flush
+
+	for _, row := range rows {
+		cells := make([]string, 0, len(p.columns))
+		for _, column := range p.columns {
+			cells = append(cells, formatCell(lookupField(row, column.Field)))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t")) //nolint:errcheck // errors are reported by Flush
+	}
+
+	return tw.Flush()
+}
+
+func (p *jsonPathPrinter) Print(w io.Writer, input interface{}) error {
+	generic, err := toGeneric(input)
+	if err != nil {
+		return err
+	}
+
+	if err := p.parser.Execute(w, generic); err != nil {
+		return fmt.Errorf("failed to execute jsonpath expression: %v", err)
+	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+func (p *templatePrinter) Print(w io.Writer, input interface{}) error {
+	generic, err := toGeneric(input)
+	if err != nil {
+		return err
+	}
+
+	if err := p.template.Execute(w, generic); err != nil {
+		return fmt.Errorf("failed to execute go-template: %v", err)
+	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+// toGeneric converts input to the maps/slices representation using its json field names
+func toGeneric(input interface{}) (interface{}, error) {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to json: %v", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal from json: %v", err)
+	}
+
+	return generic, nil
+}
+
+// relaxedJSONPath wraps expression into curly braces like kubectl does, so both '.name' and '{.name}' are accepted
+func relaxedJSONPath(expr string) string {
+	if strings.HasPrefix(expr, "{") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") {
+		expr = "." + expr
+	}
+
+	return "{" + expr + "}"
+}
+
+// lookupField returns value by dot-separated path from the generic object.
+// When the path crosses a list, the rest of the path is resolved for every list item.
+func lookupField(obj interface{}, field string) interface{} {
+	if field == "" {
+		return obj
+	}
+
+	key, rest, _ := strings.Cut(field, ".")
+
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		return lookupField(v[key], rest)
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if value := lookupField(item, field); value != nil {
+				values = append(values, value)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// formatCell returns string representation of the table cell value
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, formatCell(item))
+		}
+		if len(items) == 0 {
+			return "-"
+		}
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return strings.Join(keys, ",")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
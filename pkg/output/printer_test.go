@@ -0,0 +1,209 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+var testApplications = []interface{}{
+	map[string]interface{}{
+		"name":           "spini-test-application",
+		"email":          "ealebed@gmail.com",
+		"accounts":       "gke1",
+		"cloudProviders": "kubernetes",
+	},
+	map[string]interface{}{
+		"name":  "spini-test-bot",
+		"email": "",
+	},
+}
+
+var testColumns = []Column{
+	{Header: "name", Field: "name"},
+	{Header: "email", Field: "email"},
+	{Header: "accounts", Field: "accounts"},
+}
+
+func TestNewPrinter(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		columns []Column
+		wantErr bool
+	}{
+		{name: "default format", format: ""},
+		{name: "json", format: FormatJSON},
+		{name: "yaml", format: FormatYAML},
+		{name: "table with columns", format: FormatTable, columns: testColumns},
+		{name: "table without columns", format: FormatTable, wantErr: true},
+		{name: "jsonpath", format: "jsonpath={.name}"},
+		{name: "jsonpath without expression", format: FormatJSONPath, wantErr: true},
+		{name: "invalid jsonpath", format: "jsonpath={.name", wantErr: true},
+		{name: "go-template", format: "go-template={{.name}}"},
+		{name: "go-template without template", format: FormatGoTemplate, wantErr: true},
+		{name: "invalid go-template", format: "go-template={{.name", wantErr: true},
+		{name: "unknown format", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printer, err := NewPrinter(tt.format, tt.columns)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NewPrinter(%q) expected error, got nil", tt.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewPrinter(%q) unexpected error: %v", tt.format, err)
+			}
+			if printer == nil {
+				t.Fatalf("NewPrinter(%q) returned nil printer", tt.format)
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		input    interface{}
+		columns  []Column
+		expected string
+	}{
+		{
+			name:     "json",
+			format:   FormatJSON,
+			input:    map[string]interface{}{"name": "spini"},
+			expected: "{\n \"name\": \"spini\"\n}\n",
+		},
+		{
+			name:   "yaml uses json field names",
+			format: FormatYAML,
+			input: struct {
+				Name string `json:"name"`
+			}{Name: "spini"},
+			expected: "name: spini\n",
+		},
+		{
+			name:    "table for list",
+			format:  FormatTable,
+			input:   testApplications,
+			columns: testColumns,
+			expected: "NAME                     EMAIL               ACCOUNTS\n" +
+				"spini-test-application   ealebed@gmail.com   gke1\n" +
+				"spini-test-bot           -                   -\n",
+		},
+		{
+			name:     "table for single object",
+			format:   FormatTable,
+			input:    testApplications[0],
+			columns:  testColumns[:1],
+			expected: "NAME\nspini-test-application\n",
+		},
+		{
+			name:     "relaxed jsonpath",
+			format:   "jsonpath=.name",
+			input:    map[string]interface{}{"name": "spini"},
+			expected: "spini\n",
+		},
+		{
+			name:     "jsonpath range over list",
+			format:   `jsonpath={range [*]}{.name}{" "}{end}`,
+			input:    testApplications,
+			expected: "spini-test-application spini-test-bot \n",
+		},
+		{
+			name:     "go-template",
+			format:   "go-template={{range .}}{{.name}};{{end}}",
+			input:    testApplications,
+			expected: "spini-test-application;spini-test-bot;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Print(&buf, tt.format, tt.input, tt.columns); err != nil {
+				t.Fatalf("Print(%q) unexpected error: %v", tt.format, err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Print(%q) = %q, want %q", tt.format, buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestLookupField(t *testing.T) {
+	obj := map[string]interface{}{
+		"name": "spini",
+		"attributes": map[string]interface{}{
+			"email": "ealebed@gmail.com",
+		},
+		"triggers": []interface{}{
+			map[string]interface{}{"type": "docker"},
+			map[string]interface{}{"type": "git"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		field    string
+		expected string
+	}{
+		{name: "top level field", field: "name", expected: "spini"},
+		{name: "nested field", field: "attributes.email", expected: "ealebed@gmail.com"},
+		{name: "field across list", field: "triggers.type", expected: "docker,git"},
+		{name: "missing field", field: "attributes.missing", expected: "-"},
+		{name: "path through scalar", field: "name.missing", expected: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatCell(lookupField(obj, tt.field))
+			if result != tt.expected {
+				t.Errorf("lookupField(%q) = %q, want %q", tt.field, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{name: "nil", value: nil, expected: "-"},
+		{name: "empty string", value: "", expected: "-"},
+		{name: "integer number", value: float64(25), expected: "25"},
+		{name: "bool", value: true, expected: "true"},
+		{name: "empty list", value: []interface{}{}, expected: "-"},
+		{name: "map keys", value: map[string]interface{}{"b": 1, "a": 2}, expected: "a,b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatCell(tt.value); result != tt.expected {
+				t.Errorf("formatCell(%v) = %q, want %q", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
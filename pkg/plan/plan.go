@@ -0,0 +1,186 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ealebed/spini/pkg/diff"
+)
+
+// Version is the version of plan file format
+const Version = 1
+
+const (
+	// ActionCreate creates object which doesn't exist
+	ActionCreate = "create"
+	// ActionUpdate updates existing object
+	ActionUpdate = "update"
+	// ActionDelete deletes object which isn't generated anymore
+	ActionDelete = "delete"
+	// ActionNoOp leaves object unchanged
+	ActionNoOp = "no-op"
+)
+
+const (
+	// KindApplication is spinnaker application
+	KindApplication = "application"
+	// KindPipeline is spinnaker pipeline, named as <application>/<pipeline>
+	KindPipeline = "pipeline"
+	// KindManifest is kubernetes manifest file in manifests repository, named by its path
+	KindManifest = "manifest"
+)
+
+// Plan represents changeset computed by plan command and executed by apply command
+type Plan struct {
+	// Version of plan file format
+	Version int `json:"version"`
+	// CreatedAt is the time plan was computed
+	CreatedAt time.Time `json:"createdAt"`
+	// Organization is GitHub organization and docker registry namespace used for generation
+	Organization string `json:"organization"`
+	// ManifestsRepository is GitHub repository with kubernetes manifests
+	ManifestsRepository string `json:"manifestsRepository"`
+	// Changes to apply, including no-op ones which are used to detect live state changes
+	Changes []*Change `json:"changes"`
+}
+
+// Change represents planned action for single object
+type Change struct {
+	// Kind is one of KindApplication, KindPipeline or KindManifest
+	Kind string `json:"kind"`
+	// Name of the object
+	Name string `json:"name"`
+	// Action is one of ActionCreate, ActionUpdate, ActionDelete or ActionNoOp
+	Action string `json:"action"`
+	// LiveHash is hash of the live state at planning time, empty when object doesn't exist
+	LiveHash string `json:"liveHash,omitempty"`
+	// Desired is the object to save: JSON for applications and pipelines, YAML string for manifests
+	Desired json.RawMessage `json:"desired,omitempty"`
+	// Diff lists changed fields of updated applications and pipelines for review
+	Diff []diff.Change `json:"diff,omitempty"`
+}
+
+// New returns empty plan
+func New(organization, manifestsRepository string) *Plan {
+	return &Plan{
+		Version:             Version,
+		CreatedAt:           time.Now().UTC(),
+		Organization:        organization,
+		ManifestsRepository: manifestsRepository,
+		Changes:             []*Change{},
+	}
+}
+
+// Action returns plan action for the diff result status
+func Action(status string) string {
+	switch status {
+	case diff.StatusMissing:
+		return ActionCreate
+	case diff.StatusDrifted:
+		return ActionUpdate
+	default:
+		return ActionNoOp
+	}
+}
+
+// Hash returns hash of raw live state, empty string is returned for nil state
+func Hash(raw []byte) string {
+	if raw == nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashObject returns hash of normalized live object, empty string is returned for nil object
+func HashObject(obj interface{}) (string, error) {
+	normalized, err := diff.Normalize(obj)
+	if err != nil || normalized == nil {
+		return "", err
+	}
+
+	// encoding/json sorts map keys, so equal objects always have equal hashes
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal object: %v", err)
+	}
+
+	return Hash(raw), nil
+}
+
+// Summary returns number of changes per action
+func (p *Plan) Summary() map[string]int {
+	summary := map[string]int{ActionCreate: 0, ActionUpdate: 0, ActionDelete: 0, ActionNoOp: 0}
+	for _, change := range p.Changes {
+		summary[change.Action]++
+	}
+
+	return summary
+}
+
+// HasChanges reports whether plan contains any action except no-op
+func (p *Plan) HasChanges() bool {
+	return p.Summary()[ActionNoOp] != len(p.Changes)
+}
+
+// String returns one line summary of the plan
+func (p *Plan) String() string {
+	summary := p.Summary()
+
+	return fmt.Sprintf("Plan: %d to create, %d to update, %d to delete, %d unchanged",
+		summary[ActionCreate], summary[ActionUpdate], summary[ActionDelete], summary[ActionNoOp])
+}
+
+// Write saves plan into the file
+func Write(filePath string, p *Plan) error {
+	raw, err := json.MarshalIndent(p, "", " ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %v", err)
+	}
+
+	if err := os.WriteFile(filePath, raw, 0600); err != nil {
+		return fmt.Errorf("failed to write plan file '%s': %v", filePath, err)
+	}
+
+	return nil
+}
+
+// Read loads plan from the file
+func Read(filePath string) (*Plan, error) {
+	raw, err := os.ReadFile(filePath) //nolint:gosec // path is provided by user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file '%s': %v", filePath, err)
+	}
+
+	p := &Plan{}
+	if err := json.Unmarshal(raw, p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plan file '%s': %v", filePath, err)
+	}
+
+	if p.Version != Version {
+		return nil, fmt.Errorf("unsupported plan file version %d, expected %d", p.Version, Version)
+	}
+
+	return p, nil
+}
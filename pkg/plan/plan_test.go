@@ -0,0 +1,129 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/ealebed/spini/pkg/diff"
+)
+
+func TestAction(t *testing.T) {
+	tests := map[string]string{
+		diff.StatusMissing: ActionCreate,
+		diff.StatusDrifted: ActionUpdate,
+		diff.StatusInSync:  ActionNoOp,
+	}
+
+	for status, expected := range tests {
+		if result := Action(status); result != expected {
+			t.Errorf("Action(%q) = %q, want %q", status, result, expected)
+		}
+	}
+}
+
+func TestHashObject(t *testing.T) {
+	first, err := HashObject(map[string]interface{}{"name": "deploy", "disabled": false, "updateTs": "1"})
+	if err != nil {
+		t.Fatalf("HashObject returned error: %v", err)
+	}
+	second, err := HashObject(map[string]interface{}{"disabled": false, "name": "deploy", "updateTs": "2"})
+	if err != nil {
+		t.Fatalf("HashObject returned error: %v", err)
+	}
+	if first != second {
+		t.Errorf("Expected equal hashes ignoring key order and server-managed fields, got %s and %s", first, second)
+	}
+
+	changed, err := HashObject(map[string]interface{}{"name": "deploy", "disabled": true})
+	if err != nil {
+		t.Fatalf("HashObject returned error: %v", err)
+	}
+	if changed == first {
+		t.Errorf("Expected different hash for changed object")
+	}
+
+	if missing, err := HashObject(map[string]interface{}(nil)); err != nil || missing != "" {
+		t.Errorf("Expected empty hash for missing object, got %q, %v", missing, err)
+	}
+	if missing := Hash(nil); missing != "" {
+		t.Errorf("Expected empty hash for missing file, got %q", missing)
+	}
+	if empty := Hash([]byte{}); empty == "" {
+		t.Errorf("Expected non-empty hash for empty file")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	p := New("ealebed", "test-k8s")
+	if p.HasChanges() {
+		t.Errorf("Expected empty plan to have no changes")
+	}
+
+	p.Changes = append(p.Changes,
+		&Change{Kind: KindApplication, Name: "myapp", Action: ActionNoOp},
+		&Change{Kind: KindPipeline, Name: "myapp/build-image", Action: ActionUpdate},
+		&Change{Kind: KindPipeline, Name: "myapp/deploy-old", Action: ActionDelete},
+		&Change{Kind: KindManifest, Name: "datacenters/gke1/default/myapp.yaml", Action: ActionCreate},
+	)
+
+	if !p.HasChanges() {
+		t.Errorf("Expected plan to have changes")
+	}
+	expected := "Plan: 1 to create, 1 to update, 1 to delete, 1 unchanged"
+	if p.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, p.String())
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	planFile := path.Join(t.TempDir(), "spini.plan.json")
+
+	p := New("ealebed", "test-k8s")
+	p.Changes = append(p.Changes, &Change{
+		Kind:     KindManifest,
+		Name:     "datacenters/gke1/default/myapp.yaml",
+		Action:   ActionUpdate,
+		LiveHash: Hash([]byte("kind: List\n")),
+		Desired:  []byte(`"kind: List\nitems: []\n"`),
+	})
+
+	if err := Write(planFile, p); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	read, err := Read(planFile)
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if len(read.Changes) != 1 || read.Changes[0].LiveHash != p.Changes[0].LiveHash ||
+		string(read.Changes[0].Desired) != string(p.Changes[0].Desired) {
+		t.Errorf("Expected %+v, got %+v", p.Changes[0], read.Changes[0])
+	}
+	if !read.CreatedAt.Equal(p.CreatedAt) || read.ManifestsRepository != "test-k8s" {
+		t.Errorf("Expected plan metadata to be preserved, got %+v", read)
+	}
+
+	if err := os.WriteFile(planFile, []byte(`{"version": 99}`), 0600); err != nil {
+		t.Fatalf("failed to write plan file: %v", err)
+	}
+	if _, err := Read(planFile); err == nil {
+		t.Errorf("Expected error for unsupported plan version")
+	}
+}
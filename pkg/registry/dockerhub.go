@@ -0,0 +1,49 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+
+	dha "github.com/ealebed/dha/pkg/dockerhub"
+)
+
+// DockerHub lists image tags with Docker Hub API
+type DockerHub struct {
+	client *dha.Client
+}
+
+// NewDockerHub returns Docker Hub client for images of the namespace,
+// credentials are read from DOCKERHUB_USERNAME and DOCKERHUB_PASSWORD environment variables
+func NewDockerHub(namespace string) *DockerHub {
+	return &DockerHub{client: dha.NewClient(namespace, "")}
+}
+
+// ListTags returns tags of the image, most recently pushed tags first
+func (d *DockerHub) ListTags(image string) ([]string, error) {
+	tags, err := d.client.ListTags(image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s/%s: %w", d.client.ORG, image, err)
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,219 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tagsPageSize is the number of tags requested per page from OCI distribution API
+const tagsPageSize = 1000
+
+var (
+	// nextLinkRegexp extracts URL of the next page from Link header
+	nextLinkRegexp = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+	// challengeParamRegexp extracts key="value" parameters of WWW-Authenticate challenge
+	challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// OCI lists image tags with OCI distribution API (/v2/<name>/tags/list)
+type OCI struct {
+	client    *http.Client
+	baseURL   string
+	namespace string
+	username  string
+	password  string
+	// tokens are bearer tokens by repository, because scope of the token covers single repository
+	tokens map[string]string
+}
+
+// tagList represents response of OCI distribution API tags list endpoint
+type tagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// tokenResponse represents response of registry token endpoint
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// NewOCI returns OCI distribution API client for images of the namespace on the registry host,
+// credentials are read from REGISTRY_USERNAME and REGISTRY_PASSWORD environment variables
+func NewOCI(host, namespace string, insecure bool) *OCI {
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+
+	return &OCI{
+		client:    &http.Client{Timeout: time.Second * 30},
+		baseURL:   scheme + "://" + host,
+		namespace: namespace,
+		username:  os.Getenv("REGISTRY_USERNAME"),
+		password:  os.Getenv("REGISTRY_PASSWORD"),
+		tokens:    map[string]string{},
+	}
+}
+
+// ListTags returns all tags of the image in reverse lexical order, which is most recent first for date-based tags,
+// because distribution API doesn't expose push time
+func (o *OCI) ListTags(image string) ([]string, error) {
+	repository := strings.TrimPrefix(o.namespace+"/"+image, "/")
+	next := fmt.Sprintf("%s/v2/%s/tags/list?n=%d", o.baseURL, repository, tagsPageSize)
+	tags := []string{}
+
+	for next != "" {
+		page, link, err := o.listTagsRequest(repository, next, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", repository, err)
+		}
+		tags = append(tags, page.Tags...)
+
+		next, err = nextPage(next, link)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", repository, err)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(tags)))
+
+	return tags, nil
+}
+
+// listTagsRequest returns single page of tags and Link header pointing to the next page,
+// authenticated reports that token of the repository was just requested
+func (o *OCI) listTagsRequest(repository, pageURL string, authenticated bool) (*tagList, string, error) {
+	resp, err := o.do(pageURL, o.tokens[repository])
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close() //nolint:errcheck // acceptable to ignore close errors in defer
+
+	// registry answers with 401 and bearer challenge when token authentication is required or token has expired
+	if resp.StatusCode == http.StatusUnauthorized && !authenticated {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			return nil, "", fmt.Errorf("unauthorized")
+		}
+		token, err := o.authenticate(challenge)
+		if err != nil {
+			return nil, "", err
+		}
+		o.tokens[repository] = token
+		return o.listTagsRequest(repository, pageURL, true)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, pageURL)
+	}
+
+	page := &tagList{}
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, "", fmt.Errorf("failed to decode tags list: %v", err)
+	}
+
+	return page, resp.Header.Get("Link"), nil
+}
+
+// do sends GET request with bearer token or basic credentials
+func (o *OCI) do(requestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, requestURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case token != "":
+		req.Header.Set("Authorization", "Bearer "+token)
+	case o.username != "":
+		req.SetBasicAuth(o.username, o.password)
+	}
+
+	return o.client.Do(req) //nolint:gosec // registry URL comes from configuration
+}
+
+// authenticate returns bearer token requested from realm of the challenge
+// e.g. Bearer realm="https://auth.example.com/token",service="registry",scope="repository:org/app:pull"
+func (o *OCI) authenticate(challenge string) (string, error) {
+	params := map[string]string{}
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+	if params["realm"] == "" {
+		return "", fmt.Errorf("bearer challenge without realm: %s", challenge)
+	}
+
+	query := url.Values{}
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+
+	resp, err := o.do(params["realm"]+"?"+query.Encode(), "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() //nolint:errcheck // acceptable to ignore close errors in defer
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d from token endpoint %s", resp.StatusCode, params["realm"])
+	}
+
+	token := &tokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return "", fmt.Errorf("failed to decode token: %v", err)
+	}
+
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+
+	return "", fmt.Errorf("token endpoint %s returned empty token", params["realm"])
+}
+
+// nextPage returns absolute URL of the next page from Link header, empty string is returned for the last page
+func nextPage(current, link string) (string, error) {
+	match := nextLinkRegexp.FindStringSubmatch(link)
+	if match == nil {
+		return "", nil
+	}
+
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+	next, err := base.Parse(match[1])
+	if err != nil {
+		return "", err
+	}
+
+	return next.String(), nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ealebed/spini/types"
+)
+
+// newDistributionServer returns stand-in of registry:2 serving tags of org/app in two pages and tags of org/other,
+// bearer token scoped to the repository is required when token isn't empty
+func newDistributionServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repository := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/"), "/tags/list")
+		scope := "repository:" + repository + ":pull"
+
+		switch {
+		case r.URL.Path == "/token":
+			fmt.Fprintf(w, `{"token": %q}`, token+"|"+r.URL.Query().Get("scope"))
+		case token != "" && r.Header.Get("Authorization") != "Bearer "+token+"|"+scope:
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope=%q`, server.URL, scope))
+			w.WriteHeader(http.StatusUnauthorized)
+		case repository == "org/other":
+			fmt.Fprint(w, `{"name": "org/other", "tags": ["1.0.0"]}`)
+		case repository != "org/app":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Query().Get("last") == "":
+			w.Header().Set("Link", `</v2/org/app/tags/list?n=1000&last=24.01.02-10.00>; rel="next"`)
+			fmt.Fprint(w, `{"name": "org/app", "tags": ["24.01.01-10.00", "24.01.02-10.00"]}`)
+		default:
+			fmt.Fprint(w, `{"name": "org/app", "tags": ["24.01.03-10.00"]}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestOCIListTags(t *testing.T) {
+	expected := []string{"24.01.03-10.00", "24.01.02-10.00", "24.01.01-10.00"}
+
+	for _, token := range []string{"", "secret"} {
+		t.Run("token "+token, func(t *testing.T) {
+			server := newDistributionServer(t, token)
+			client := NewOCI(strings.TrimPrefix(server.URL, "http://"), "org", true)
+
+			tags, err := client.ListTags("app")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tags, expected) {
+				t.Errorf("Expected %v, got %v", expected, tags)
+			}
+
+			// token of the first repository doesn't grant access to the second one
+			tags, err = client.ListTags("other")
+			if err != nil {
+				t.Fatalf("Unexpected error listing second repository: %v", err)
+			}
+			if !reflect.DeepEqual(tags, []string{"1.0.0"}) {
+				t.Errorf("Expected [1.0.0], got %v", tags)
+			}
+		})
+	}
+}
+
+func TestOCIListTagsNotFound(t *testing.T) {
+	server := newDistributionServer(t, "")
+
+	if _, err := NewOCI(strings.TrimPrefix(server.URL, "http://"), "org", true).ListTags("missing"); err == nil {
+		t.Error("Expected error for missing repository")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, ok := mustNew(t, types.DefaultRegistry("org")).(*DockerHub); !ok {
+		t.Error("Expected Docker Hub client for default registry")
+	}
+	if _, ok := mustNew(t, &types.Registry{Type: types.RegistryTypeOCI, Host: "registry.local"}).(*OCI); !ok {
+		t.Error("Expected OCI client for oci registry")
+	}
+	if _, err := New(&types.Registry{Type: "ecr"}); err == nil {
+		t.Error("Expected error for unsupported registry type")
+	}
+}
+
+func mustNew(t *testing.T, registry *types.Registry) Registry {
+	t.Helper()
+
+	client, err := New(registry)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return client
+}
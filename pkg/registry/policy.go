@@ -0,0 +1,133 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/ealebed/spini/types"
+)
+
+// SelectTags returns tags matching the policy filter and not matching any exclude pattern, ordered by the policy
+// from the most recent tag
+func SelectTags(tags []string, policy *types.TagPolicy) ([]string, error) {
+	filter, err := regexp.Compile(policy.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag filter '%s': %v", policy.Filter, err)
+	}
+
+	excludes := make([]*regexp.Regexp, 0, len(policy.Exclude))
+	for _, pattern := range policy.Exclude {
+		exclude, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag exclude pattern '%s': %v", pattern, err)
+		}
+		excludes = append(excludes, exclude)
+	}
+
+	selected := []string{}
+	for _, tag := range tags {
+		if filter.MatchString(tag) && !matchesAny(excludes, tag) {
+			selected = append(selected, tag)
+		}
+	}
+
+	switch policy.Order {
+	case types.TagOrderRegistry, "":
+		return selected, nil
+	case types.TagOrderSemver:
+		return orderBySemver(selected), nil
+	case types.TagOrderDate:
+		return orderByDate(selected, policy.DateFormat), nil
+	default:
+		return nil, fmt.Errorf("unsupported tag order '%s'", policy.Order)
+	}
+}
+
+// LatestTag returns the most recent tag of the image selected by the policy
+func LatestTag(client Registry, image string, policy *types.TagPolicy) (string, error) {
+	tags, err := client.ListTags(image)
+	if err != nil {
+		return "", err
+	}
+
+	selected, err := SelectTags(tags, policy)
+	if err != nil {
+		return "", err
+	}
+	if len(selected) == 0 {
+		return "", fmt.Errorf("no tags of image '%s' match tag policy (%d tags found)", image, len(tags))
+	}
+
+	return selected[0], nil
+}
+
+// matchesAny checks if tag matches any of regular expressions
+func matchesAny(patterns []*regexp.Regexp, tag string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orderBySemver returns semantic version tags from the highest version, other tags are skipped
+func orderBySemver(tags []string) []string {
+	versions := map[string]*semver.Version{}
+	ordered := []string{}
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		versions[tag] = version
+		ordered = append(ordered, tag)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool { return versions[ordered[i]].GreaterThan(versions[ordered[j]]) })
+
+	return ordered
+}
+
+// orderByDate returns tags parsed with Go time layout from the latest date, other tags are skipped
+func orderByDate(tags []string, layout string) []string {
+	if layout == "" {
+		layout = types.DefaultTagDateFormat
+	}
+
+	dates := map[string]time.Time{}
+	ordered := []string{}
+	for _, tag := range tags {
+		date, err := time.Parse(layout, tag)
+		if err != nil {
+			continue
+		}
+		dates[tag] = date
+		ordered = append(ordered, tag)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool { return dates[ordered[i]].After(dates[ordered[j]]) })
+
+	return ordered
+}
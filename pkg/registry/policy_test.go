@@ -0,0 +1,113 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ealebed/spini/types"
+)
+
+// staticRegistry returns the same tags for any image
+type staticRegistry []string
+
+func (s staticRegistry) ListTags(string) ([]string, error) {
+	return s, nil
+}
+
+func TestSelectTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		policy   *types.TagPolicy
+		expected []string
+	}{
+		{
+			name:     "default policy keeps registry order of CI tags",
+			tags:     []string{"latest", "24.01.02-10.00", "24.03.01-09.30", "24.02.01-10.00-rc"},
+			policy:   types.DefaultTagPolicy(),
+			expected: []string{"24.01.02-10.00", "24.03.01-09.30"},
+		},
+		{
+			name:     "date order",
+			tags:     []string{"24.01.02-10.00", "24.03.01-09.30", "23.12.31-23.59"},
+			policy:   &types.TagPolicy{Filter: ".*", Order: types.TagOrderDate},
+			expected: []string{"24.03.01-09.30", "24.01.02-10.00", "23.12.31-23.59"},
+		},
+		{
+			name:     "date order with custom format skips other tags",
+			tags:     []string{"2024-01-02", "latest", "2024-11-30"},
+			policy:   &types.TagPolicy{Filter: ".*", Order: types.TagOrderDate, DateFormat: "2006-01-02"},
+			expected: []string{"2024-11-30", "2024-01-02"},
+		},
+		{
+			name:     "semver order with excluded release candidates",
+			tags:     []string{"v1.2.0", "1.10.0", "1.9.3", "2.0.0-rc.1", "main"},
+			policy:   &types.TagPolicy{Filter: ".*", Exclude: []string{"-rc"}, Order: types.TagOrderSemver},
+			expected: []string{"1.10.0", "1.9.3", "v1.2.0"},
+		},
+		{
+			name:     "no tags",
+			tags:     []string{},
+			policy:   types.DefaultTagPolicy(),
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SelectTags(tt.tags, tt.policy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSelectTagsInvalidPolicy(t *testing.T) {
+	policies := []*types.TagPolicy{
+		{Filter: "("},
+		{Filter: ".*", Exclude: []string{"["}},
+		{Filter: ".*", Order: "random"},
+	}
+
+	for _, policy := range policies {
+		if _, err := SelectTags([]string{"1.0.0"}, policy); err == nil {
+			t.Errorf("Expected error for policy %+v", policy)
+		}
+	}
+}
+
+func TestLatestTag(t *testing.T) {
+	policy := &types.TagPolicy{Filter: ".*", Order: types.TagOrderSemver}
+
+	tag, err := LatestTag(staticRegistry{"1.0.0", "1.1.0"}, "app", policy)
+	if err != nil || tag != "1.1.0" {
+		t.Errorf("Expected 1.1.0, got %q (%v)", tag, err)
+	}
+
+	if _, err := LatestTag(staticRegistry{}, "app", policy); err == nil {
+		t.Error("Expected error for image without tags")
+	}
+	if _, err := LatestTag(staticRegistry{"latest"}, "app", policy); err == nil {
+		t.Error("Expected error when no tags match policy")
+	}
+}
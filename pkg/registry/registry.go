@@ -0,0 +1,42 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/ealebed/spini/types"
+)
+
+// Registry lists tags of container images
+type Registry interface {
+	// ListTags returns tags of the image inside registry namespace, most recent tags first
+	// for registries tracking push time
+	ListTags(image string) ([]string, error)
+}
+
+// New returns registry client for the configured registry type
+func New(registry *types.Registry) (Registry, error) {
+	switch registry.Type {
+	case types.RegistryTypeDockerHub:
+		return NewDockerHub(registry.Namespace), nil
+	case types.RegistryTypeOCI:
+		return NewOCI(registry.Host, registry.Namespace, registry.Insecure), nil
+	default:
+		return nil, fmt.Errorf("unsupported registry type '%s'", registry.Type)
+	}
+}
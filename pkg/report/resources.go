@@ -0,0 +1,157 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ealebed/spini/types"
+)
+
+const (
+	// GroupByTier reports every datacenter of every application
+	GroupByTier = "tier"
+	// GroupByApplication reports applications with resources of all their datacenters
+	GroupByApplication = "application"
+	// GroupByNodePool reports node pools with resources of all applications scheduled on them
+	GroupByNodePool = "nodePool"
+)
+
+// GroupBy lists supported groupings of resources report
+var GroupBy = []string{GroupByTier, GroupByApplication, GroupByNodePool}
+
+// ResourceUsage represents requested resources of datacenter, application or node pool
+type ResourceUsage struct {
+	Application string `json:"application,omitempty"`
+	Profile     string `json:"profile,omitempty"`
+	Tier        string `json:"tier,omitempty"`
+	NodePool    string `json:"nodePool,omitempty"`
+	// Replicas is the minimum number of pods, i.e. replicas or minimum replicas of autoscaling
+	Replicas int32 `json:"replicas"`
+	// PodCPU and PodMemory are requests of single pod including sidecars and init containers
+	PodCPU    string `json:"podCpu,omitempty"`
+	PodMemory string `json:"podMemory,omitempty"`
+	// CPU and Memory are requests of all replicas
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+
+	cpu    resource.Quantity
+	memory resource.Quantity
+}
+
+// Resources returns requested resources of applications grouped by tier, application or node pool.
+// Configuration should have defaults resolved, error is returned for invalid resource quantities.
+func Resources(configuration []*types.Configuration, groupBy string) ([]*ResourceUsage, error) {
+	usages := []*ResourceUsage{}
+
+	for _, app := range configuration {
+		if app.Profiles == nil {
+			continue
+		}
+		for _, profile := range *app.Profiles {
+			if profile.Datacenters == nil {
+				continue
+			}
+			for _, tier := range *profile.Datacenters {
+				if err := app.CheckPodResources(tier); err != nil {
+					return nil, fmt.Errorf("application %q: %w", app.Application, err)
+				}
+				usages = append(usages, tierUsage(app, profile.ProfileName, tier))
+			}
+		}
+	}
+
+	switch groupBy {
+	case GroupByApplication:
+		usages = group(usages, func(usage *ResourceUsage) *ResourceUsage {
+			return &ResourceUsage{Application: usage.Application, NodePool: usage.NodePool}
+		})
+	case GroupByNodePool:
+		usages = group(usages, func(usage *ResourceUsage) *ResourceUsage {
+			return &ResourceUsage{NodePool: usage.NodePool}
+		})
+	}
+
+	for _, usage := range usages {
+		usage.CPU = usage.cpu.String()
+		usage.Memory = usage.memory.String()
+	}
+
+	return usages, nil
+}
+
+// tierUsage returns requested resources of the application datacenter
+func tierUsage(app *types.Configuration, profile string, tier *types.Datacenter) *ResourceUsage {
+	requests := app.PodResources(tier).Requests
+	replicas := tier.MinReplicas()
+
+	usage := &ResourceUsage{
+		Application: app.Application,
+		Profile:     profile,
+		Tier:        tier.TierName,
+		NodePool:    app.NodePool,
+		Replicas:    replicas,
+		PodCPU:      requests.Cpu().String(),
+		PodMemory:   requests.Memory().String(),
+	}
+	usage.cpu = multiply(*requests.Cpu(), replicas)
+	usage.memory = multiply(*requests.Memory(), replicas)
+
+	return usage
+}
+
+// group sums replicas and requests of usages with the same application and node pool returned by key,
+// groups are sorted by application and node pool
+func group(usages []*ResourceUsage, key func(*ResourceUsage) *ResourceUsage) []*ResourceUsage {
+	groups := []*ResourceUsage{}
+	index := map[string]*ResourceUsage{}
+
+	for _, usage := range usages {
+		grouped := key(usage)
+		groupKey := grouped.Application + "/" + grouped.NodePool
+		if existing, ok := index[groupKey]; ok {
+			grouped = existing
+		} else {
+			index[groupKey] = grouped
+			groups = append(groups, grouped)
+		}
+
+		grouped.Replicas += usage.Replicas
+		grouped.cpu.Add(usage.cpu)
+		grouped.memory.Add(usage.memory)
+	}
+
+	slices.SortFunc(groups, func(a, b *ResourceUsage) int {
+		return strings.Compare(a.Application+"/"+a.NodePool, b.Application+"/"+b.NodePool)
+	})
+
+	return groups
+}
+
+// multiply returns quantity multiplied by number of replicas
+func multiply(quantity resource.Quantity, replicas int32) resource.Quantity {
+	total := resource.NewMilliQuantity(quantity.MilliValue()*int64(replicas), quantity.Format)
+	if quantity.Format == resource.BinarySI {
+		total = resource.NewQuantity(quantity.Value()*int64(replicas), quantity.Format)
+	}
+
+	return *total
+}
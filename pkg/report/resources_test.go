@@ -0,0 +1,114 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ealebed/spini/types"
+)
+
+func testConfiguration() []*types.Configuration {
+	tier := func(name string, replicas int32, cpu, memory string) *types.Datacenter {
+		return &types.Datacenter{
+			TierName:  name,
+			Replicas:  replicas,
+			Resources: &types.ResourceRequirements{Requests: &types.ResourceList{CPU: cpu, Memory: memory}},
+		}
+	}
+
+	return []*types.Configuration{
+		{
+			Application: "api",
+			NodePool:    "base-pool",
+			Sidecars:    []types.Container{{Name: "proxy", Resources: &types.ResourceRequirements{Requests: &types.ResourceList{CPU: "100m", Memory: "64Mi"}}}},
+			Profiles: &[]*types.Profile{
+				{ProfileName: "production", Datacenters: &[]*types.Datacenter{tier("gke1", 3, "400m", "448Mi")}},
+				{ProfileName: "beta", Datacenters: &[]*types.Datacenter{tier("gke1", 1, "400m", "448Mi")}},
+			},
+		},
+		{
+			Application: "worker",
+			NodePool:    "batch-pool",
+			Profiles:    &[]*types.Profile{{ProfileName: "production", Datacenters: &[]*types.Datacenter{tier("gke1", 2, "1500m", "2Gi")}}},
+		},
+	}
+}
+
+func TestResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		groupBy  string
+		expected []ResourceUsage
+	}{
+		{
+			name:    "by tier",
+			groupBy: GroupByTier,
+			expected: []ResourceUsage{
+				{Application: "api", Profile: "production", Tier: "gke1", NodePool: "base-pool", Replicas: 3, PodCPU: "500m", PodMemory: "512Mi", CPU: "1500m", Memory: "1536Mi"},
+				{Application: "api", Profile: "beta", Tier: "gke1", NodePool: "base-pool", Replicas: 1, PodCPU: "500m", PodMemory: "512Mi", CPU: "500m", Memory: "512Mi"},
+				{Application: "worker", Profile: "production", Tier: "gke1", NodePool: "batch-pool", Replicas: 2, PodCPU: "1500m", PodMemory: "2Gi", CPU: "3", Memory: "4Gi"},
+			},
+		},
+		{
+			name:    "by application",
+			groupBy: GroupByApplication,
+			expected: []ResourceUsage{
+				{Application: "api", NodePool: "base-pool", Replicas: 4, CPU: "2", Memory: "2Gi"},
+				{Application: "worker", NodePool: "batch-pool", Replicas: 2, CPU: "3", Memory: "4Gi"},
+			},
+		},
+		{
+			name:    "by node pool",
+			groupBy: GroupByNodePool,
+			expected: []ResourceUsage{
+				{NodePool: "base-pool", Replicas: 4, CPU: "2", Memory: "2Gi"},
+				{NodePool: "batch-pool", Replicas: 2, CPU: "3", Memory: "4Gi"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usages, err := Resources(testConfiguration(), tt.groupBy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(usages) != len(tt.expected) {
+				t.Fatalf("Expected %d rows, got %d: %+v", len(tt.expected), len(usages), usages)
+			}
+			for i, expected := range tt.expected {
+				usage := *usages[i]
+				if usage.Application != expected.Application || usage.Profile != expected.Profile || usage.Tier != expected.Tier ||
+					usage.NodePool != expected.NodePool || usage.Replicas != expected.Replicas || usage.PodCPU != expected.PodCPU ||
+					usage.PodMemory != expected.PodMemory || usage.CPU != expected.CPU || usage.Memory != expected.Memory {
+					t.Errorf("Expected row %+v, got %+v", expected, usage)
+				}
+			}
+		})
+	}
+}
+
+func TestResourcesInvalidQuantity(t *testing.T) {
+	configuration := testConfiguration()
+	(*(*configuration[1].Profiles)[0].Datacenters)[0].Resources.Requests.Memory = "2 gig"
+
+	if _, err := Resources(configuration, GroupByTier); err == nil || !strings.Contains(err.Error(), `"worker"`) {
+		t.Errorf("Expected error of invalid worker memory, got %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ealebed/spini/types"
+)
+
+const (
+	// PodSecurityLevelBaseline prevents known privilege escalations
+	PodSecurityLevelBaseline = "baseline"
+	// PodSecurityLevelRestricted enforces pod hardening best practices
+	PodSecurityLevelRestricted = "restricted"
+)
+
+// PodSecurityLevels are Pod Security Admission levels ordered from the least to the most restrictive
+var PodSecurityLevels = []string{PodSecurityLevelBaseline, PodSecurityLevelRestricted}
+
+// baselineCapabilities can be added to containers on baseline level
+var baselineCapabilities = []string{
+	"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD", "NET_BIND_SERVICE",
+	"SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+}
+
+// PodSecurityViolation represents security setting which makes Pod Security Admission reject application pods
+type PodSecurityViolation struct {
+	Problem
+	// Application is the name of rejected application
+	Application string `json:"application"`
+	// Level is the least restrictive Pod Security Admission level rejecting the setting
+	Level string `json:"level"`
+}
+
+// String returns human-readable representation of the violation
+func (v PodSecurityViolation) String() string {
+	return v.Level + ": " + v.Problem.String()
+}
+
+// RejectedBy checks if the violation makes pods rejected in namespaces enforcing the level
+func (v PodSecurityViolation) RejectedBy(level string) bool {
+	return slices.Index(PodSecurityLevels, v.Level) <= slices.Index(PodSecurityLevels, level)
+}
+
+// ValidPodSecurityLevel checks that level is known Pod Security Admission level
+func ValidPodSecurityLevel(level string) bool {
+	return slices.Contains(PodSecurityLevels, level)
+}
+
+// PodSecurity reports settings of configuration files which are rejected by Pod Security Admission levels.
+// Applications which can't be decoded are skipped, their problems are reported by Validate.
+func PodSecurity(files []*types.ConfigurationFile) []PodSecurityViolation {
+	violations := []PodSecurityViolation{}
+
+	for _, file := range files {
+		fileViolations := CheckPodSecurity(decodeApplications(file.Content))
+		for i := range fileViolations {
+			fileViolations[i].Problem = locate(file, []Problem{fileViolations[i].Problem})[0]
+		}
+		violations = append(violations, fileViolations...)
+	}
+
+	return violations
+}
+
+// CheckPodSecurity checks resolved security settings of application pods, sidecars and init containers against
+// Pod Security Admission levels. Violation of container inherited from the application is reported once.
+func CheckPodSecurity(configuration []*types.Configuration) []PodSecurityViolation {
+	violations := []PodSecurityViolation{}
+
+	for i, app := range configuration {
+		if app == nil {
+			continue
+		}
+		appPointer := pointer(i)
+		app.ResolveDefaults()
+
+		reported := map[string]bool{}
+		check := func(settings *types.SecurityContext, path string) {
+			for _, violation := range podSecurityViolations(settings) {
+				if reported[violation.Message] {
+					continue
+				}
+				reported[violation.Message] = true
+
+				violation.Application = app.Application
+				violation.Pointer = path
+				violations = append(violations, violation)
+			}
+		}
+		checkContainers := func(containers []types.Container, path string) {
+			for j, container := range containers {
+				check(app.ContainerSecurityContext(container), path+pointer(j)+"/securityContext")
+			}
+		}
+
+		check(app.PodSecurityContext(), appPointer+"/securityContext")
+		checkContainers(app.Sidecars, appPointer+"/sidecars")
+		checkContainers(app.InitContainers, appPointer+"/initContainers")
+
+		if app.Profiles == nil {
+			continue
+		}
+		for j, profile := range *app.Profiles {
+			if profile.Datacenters == nil {
+				continue
+			}
+			for k, tier := range *profile.Datacenters {
+				tierPointer := appPointer + "/profiles" + pointer(j) + "/datacenters" + pointer(k)
+				checkContainers(tier.Sidecars, tierPointer+"/sidecars")
+				checkContainers(tier.InitContainers, tierPointer+"/initContainers")
+			}
+		}
+	}
+
+	return violations
+}
+
+// podSecurityViolations returns violations of Pod Security Admission baseline and restricted levels
+func podSecurityViolations(settings *types.SecurityContext) []PodSecurityViolation {
+	violations := []PodSecurityViolation{}
+	violate := func(level, message string) {
+		violations = append(violations, PodSecurityViolation{Problem: Problem{Message: message}, Level: level})
+	}
+
+	if settings.Privileged != nil && *settings.Privileged {
+		violate(PodSecurityLevelBaseline, "privileged containers are not allowed")
+	}
+	if settings.SeccompProfile == types.SeccompProfileUnconfined {
+		violate(PodSecurityLevelBaseline, "seccompProfile must not be Unconfined")
+	}
+
+	if settings.RunAsNonRoot == nil || !*settings.RunAsNonRoot {
+		violate(PodSecurityLevelRestricted, "runAsNonRoot must be true")
+	}
+	if settings.RunAsUser != nil && *settings.RunAsUser == 0 {
+		violate(PodSecurityLevelRestricted, "runAsUser must not be 0")
+	}
+	if settings.AllowPrivilegeEscalation == nil || *settings.AllowPrivilegeEscalation {
+		violate(PodSecurityLevelRestricted, "allowPrivilegeEscalation must be false")
+	}
+	if settings.SeccompProfile == "" {
+		violate(PodSecurityLevelRestricted, "seccompProfile must be "+types.SeccompProfileRuntimeDefault)
+	}
+
+	capabilities := settings.Capabilities
+	if capabilities == nil {
+		capabilities = &types.Capabilities{}
+	}
+	if !slices.Contains(capabilities.Drop, "ALL") {
+		violate(PodSecurityLevelRestricted, "capabilities must drop ALL")
+	}
+	for _, capability := range capabilities.Add {
+		switch {
+		case !slices.Contains(baselineCapabilities, capability):
+			violate(PodSecurityLevelBaseline, fmt.Sprintf("capability %s must not be added", capability))
+		case capability != "NET_BIND_SERVICE":
+			violate(PodSecurityLevelRestricted, fmt.Sprintf("capability %s must not be added", capability))
+		}
+	}
+
+	return violations
+}
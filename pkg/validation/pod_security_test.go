@@ -0,0 +1,107 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/ealebed/spini/types"
+)
+
+func TestCheckPodSecurity(t *testing.T) {
+	enabled, disabled, root := true, false, int64(0)
+
+	tests := []struct {
+		name     string
+		modify   func(*types.Configuration)
+		expected []string
+	}{
+		{
+			name:     "default security context",
+			modify:   func(c *types.Configuration) {},
+			expected: []string{},
+		},
+		{
+			name: "root user with escalation",
+			modify: func(c *types.Configuration) {
+				c.SecurityContext = &types.SecurityContext{RunAsNonRoot: &disabled, RunAsUser: &root, AllowPrivilegeEscalation: &enabled}
+			},
+			expected: []string{
+				"restricted: /0/securityContext: runAsNonRoot must be true",
+				"restricted: /0/securityContext: runAsUser must not be 0",
+				"restricted: /0/securityContext: allowPrivilegeEscalation must be false",
+			},
+		},
+		{
+			name: "privileged sidecar and capabilities",
+			modify: func(c *types.Configuration) {
+				c.SecurityContext = &types.SecurityContext{Capabilities: &types.Capabilities{Add: []string{"NET_BIND_SERVICE", "CHOWN"}}}
+				(*(*c.Profiles)[0].Datacenters)[0].Sidecars = []types.Container{{
+					Name:            "vpn",
+					Image:           "vpn:1.0",
+					SecurityContext: &types.SecurityContext{Privileged: &enabled, Capabilities: &types.Capabilities{Add: []string{"NET_ADMIN"}}},
+				}}
+			},
+			expected: []string{
+				"restricted: /0/securityContext: capabilities must drop ALL",
+				"restricted: /0/securityContext: capability CHOWN must not be added",
+				"baseline: /0/profiles/0/datacenters/0/sidecars/0/securityContext: privileged containers are not allowed",
+				"baseline: /0/profiles/0/datacenters/0/sidecars/0/securityContext: capability NET_ADMIN must not be added",
+			},
+		},
+		{
+			name: "unconfined init container",
+			modify: func(c *types.Configuration) {
+				c.InitContainers = []types.Container{{
+					Name:            "migrate",
+					Image:           "migrate:1.0",
+					SecurityContext: &types.SecurityContext{SeccompProfile: types.SeccompProfileUnconfined},
+				}}
+			},
+			expected: []string{"baseline: /0/initContainers/0/securityContext: seccompProfile must not be Unconfined"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfiguration()
+			tt.modify(config)
+
+			violations := CheckPodSecurity([]*types.Configuration{config})
+			if len(violations) != len(tt.expected) {
+				t.Fatalf("Expected %d violations, got %d: %v", len(tt.expected), len(violations), violations)
+			}
+			for i := range tt.expected {
+				if violations[i].String() != tt.expected[i] || violations[i].Application != "myapp" {
+					t.Errorf("Expected violation %q, got %q", tt.expected[i], violations[i].String())
+				}
+			}
+		})
+	}
+}
+
+func TestPodSecurityViolationRejectedBy(t *testing.T) {
+	baseline := PodSecurityViolation{Level: PodSecurityLevelBaseline}
+	restricted := PodSecurityViolation{Level: PodSecurityLevelRestricted}
+
+	if !baseline.RejectedBy(PodSecurityLevelBaseline) || !baseline.RejectedBy(PodSecurityLevelRestricted) {
+		t.Errorf("Expected baseline violation to be rejected by baseline and restricted levels")
+	}
+	if restricted.RejectedBy(PodSecurityLevelBaseline) || !restricted.RejectedBy(PodSecurityLevelRestricted) {
+		t.Errorf("Expected restricted violation to be rejected by restricted level only")
+	}
+}
@@ -0,0 +1,1475 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	_ "embed" // required for go:embed directive
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ealebed/spini/types"
+)
+
+// Schema is the published JSON Schema of configuration.json
+//
+//go:embed configuration.schema.json
+var Schema []byte
+
+// Problem represents single configuration problem with its location
+type Problem struct {
+	// File is the configuration file containing the problem
+	File string `json:"file,omitempty"`
+	// Pointer is a JSON pointer (RFC 6901) to the invalid value inside the configuration file
+	Pointer string `json:"pointer"`
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// String returns human-readable representation of the problem
+func (p Problem) String() string {
+	pointer := p.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	if p.File != "" {
+		return p.File + "#" + pointer + ": " + p.Message
+	}
+
+	return pointer + ": " + p.Message
+}
+
+// Validate checks configuration files against JSON Schema and semantic rules, resources are checked
+// against organization resource policy (nil when organization doesn't set it).
+// Semantic rules are checked for every application which can be decoded, so all problems are reported at once.
+func Validate(files []*types.ConfigurationFile, policy *types.ResourcePolicy) ([]Problem, error) {
+	problems := []Problem{}
+
+	// applications defined in previous files, duplicates inside single file are reported by ValidateConfiguration
+	definedIn := map[string]string{}
+
+	for _, file := range files {
+		schemaProblems, err := ValidateSchema(file.Content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file.Path, err)
+		}
+		problems = append(problems, locate(file, schemaProblems)...)
+
+		configuration := decodeApplications(file.Content)
+		types.ApplyResourcePolicy(configuration, policy)
+
+		fileProblems := []Problem{}
+		for i, app := range configuration {
+			if app == nil {
+				continue
+			}
+			if previous, ok := definedIn[app.Application]; ok {
+				fileProblems = append(fileProblems, Problem{
+					Pointer: pointer(i) + "/application",
+					Message: fmt.Sprintf("application %q is already defined in %s", app.Application, previous),
+				})
+			}
+		}
+		for _, app := range configuration {
+			if app != nil {
+				definedIn[app.Application] = file.Path
+			}
+		}
+
+		fileProblems = append(fileProblems, ValidateConfiguration(configuration)...)
+		problems = append(problems, locate(file, fileProblems)...)
+	}
+
+	return problems, nil
+}
+
+// decodeApplications decodes every application of the configuration file separately, applications which
+// can't be decoded are left nil to keep indexes of the others, their problems are reported by JSON Schema
+func decodeApplications(content []byte) []*types.Configuration {
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil
+	}
+
+	configuration := make([]*types.Configuration, len(raw))
+	for i := range raw {
+		var app *types.Configuration
+		if err := json.Unmarshal(raw[i], &app); err == nil && hasNoNullProfiles(app) {
+			configuration[i] = app
+		}
+	}
+
+	return configuration
+}
+
+// hasNoNullProfiles checks that decoded application and its profiles don't contain null profiles or datacenters
+func hasNoNullProfiles(app *types.Configuration) bool {
+	if app == nil {
+		return false
+	}
+	if app.Profiles == nil {
+		return true
+	}
+
+	for _, profile := range *app.Profiles {
+		if profile == nil {
+			return false
+		}
+		if profile.Datacenters != nil && slices.Contains(*profile.Datacenters, nil) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// locate sets file to problems and removes list index from pointers of single application files
+func locate(file *types.ConfigurationFile, problems []Problem) []Problem {
+	for i := range problems {
+		problems[i].File = file.Path
+		if file.Wrapped {
+			problems[i].Pointer = strings.TrimPrefix(problems[i].Pointer, pointer(0))
+		}
+	}
+
+	return problems
+}
+
+// ValidateSchema checks raw configuration.json content against JSON Schema
+func ValidateSchema(raw []byte) ([]Problem, error) {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(Schema), gojsonschema.NewBytesLoader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate configuration against schema: %v", err)
+	}
+
+	problems := []Problem{}
+	for _, resultError := range result.Errors() {
+		// allOf failure only repeats problems already reported for its subschemas
+		if resultError.Type() == "number_all_of" {
+			continue
+		}
+		problems = append(problems, Problem{
+			Pointer: strings.TrimPrefix(resultError.Context().String("/"), gojsonschema.STRING_CONTEXT_ROOT),
+			Message: resultError.Description(),
+		})
+	}
+
+	return problems, nil
+}
+
+// ValidateConfiguration checks semantic rules which can't be expressed with JSON Schema.
+// Datacenters are checked after application and profile defaults are resolved into them,
+// nil applications which failed to decode are skipped.
+func ValidateConfiguration(configuration []*types.Configuration) []Problem {
+	problems := []Problem{}
+	applications := map[string]int{}
+	namespaces := map[string]int{}
+
+	for i, app := range configuration {
+		if app == nil {
+			continue
+		}
+		appPointer := pointer(i)
+
+		if first, ok := applications[app.Application]; ok {
+			problems = append(problems, Problem{
+				Pointer: appPointer + "/application",
+				Message: fmt.Sprintf("application %q is already defined at %s", app.Application, pointer(first)),
+			})
+		} else {
+			applications[app.Application] = i
+		}
+
+		problems = append(problems, validateDefaults(app, appPointer)...)
+		app.ResolveDefaults()
+
+		problems = append(problems, validateRegistry(app.Registry, appPointer+"/registry")...)
+		problems = append(problems, validateTagPolicy(app.TagPolicy, appPointer+"/tagPolicy")...)
+		problems = append(problems, validateStrategy(app.Strategy, appPointer+"/strategy")...)
+		problems = append(problems, validatePorts(app.Ports, appPointer+"/ports")...)
+		problems = append(problems, validateWorkload(app, appPointer)...)
+		problems = append(problems, validateContainers(app.Sidecars, false, appPointer+"/sidecars")...)
+		problems = append(problems, validateContainers(app.InitContainers, true, appPointer+"/initContainers")...)
+		problems = append(problems, validateVolumes(app, appPointer)...)
+		problems = append(problems, validateEnvFromSources(app.EnvFromSources, appPointer+"/envFromSources")...)
+		problems = append(problems, validateSecurityContext(app.SecurityContext, false, appPointer+"/securityContext")...)
+		problems = append(problems, validateIngress(app, appPointer+"/ingress")...)
+		problems = append(problems, validateNetworkPolicy(app, appPointer+"/networkPolicy")...)
+		problems = append(problems, validateMonitoring(app, appPointer+"/monitoring")...)
+		problems = append(problems, validateProfiles(app, appPointer)...)
+		problems = append(problems, validatePromotions(app, appPointer)...)
+		problems = append(problems, validateResourcePolicy(app, appPointer)...)
+
+		if app.NamespaceSettings != nil {
+			problems = append(problems, validateNamespaceSettings(configuration, namespaces, i)...)
+			if _, ok := namespaces[app.Namespace]; !ok {
+				namespaces[app.Namespace] = i
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateNamespaceSettings checks namespace settings of the application and rejects settings which differ
+// from settings of the same namespace defined by another application
+func validateNamespaceSettings(configuration []*types.Configuration, namespaces map[string]int, index int) []Problem {
+	problems := []Problem{}
+	app := configuration[index]
+	settings := app.NamespaceSettings
+	path := pointer(index) + "/namespaceSettings"
+
+	if app.Namespace == "" || app.Namespace == "default" {
+		return append(problems, Problem{Pointer: path, Message: "namespaceSettings aren't supported for default namespace"})
+	}
+
+	if quota := settings.ResourceQuota; quota != nil {
+		problems = append(problems, validateQuantities(quota.Requests, path+"/resourceQuota/requests")...)
+		problems = append(problems, validateQuantities(quota.Limits, path+"/resourceQuota/limits")...)
+	}
+	if limits := settings.LimitRange; limits != nil {
+		problems = append(problems, validateQuantities(limits.Default, path+"/limitRange/default")...)
+		problems = append(problems, validateQuantities(limits.DefaultRequest, path+"/limitRange/defaultRequest")...)
+		problems = append(problems, validateQuantities(limits.Max, path+"/limitRange/max")...)
+	}
+
+	first, ok := namespaces[app.Namespace]
+	if !ok {
+		return problems
+	}
+	defined := configuration[first].NamespaceSettings
+
+	for _, key := range slices.Sorted(maps.Keys(settings.Labels)) {
+		if definedValue, ok := defined.Labels[key]; ok && definedValue != settings.Labels[key] {
+			problems = append(problems, Problem{
+				Pointer: path + "/labels/" + escapePointer(key),
+				Message: fmt.Sprintf("label %q of namespace %q is already set to %q at %s", key, app.Namespace, definedValue, pointer(first)),
+			})
+		}
+	}
+	if settings.ResourceQuota != nil && defined.ResourceQuota != nil && !reflect.DeepEqual(settings.ResourceQuota, defined.ResourceQuota) {
+		problems = append(problems, Problem{
+			Pointer: path + "/resourceQuota",
+			Message: fmt.Sprintf("resourceQuota of namespace %q differs from %s", app.Namespace, pointer(first)),
+		})
+	}
+	if settings.LimitRange != nil && defined.LimitRange != nil && !reflect.DeepEqual(settings.LimitRange, defined.LimitRange) {
+		problems = append(problems, Problem{
+			Pointer: path + "/limitRange",
+			Message: fmt.Sprintf("limitRange of namespace %q differs from %s", app.Namespace, pointer(first)),
+		})
+	}
+
+	return problems
+}
+
+// validateDefaults checks that application and profile defaults don't define tier name
+func validateDefaults(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+
+	if app.Defaults != nil && app.Defaults.TierName != "" {
+		problems = append(problems, Problem{Pointer: path + "/defaults/tierName", Message: "tierName is not allowed in defaults"})
+	}
+
+	if app.Profiles == nil {
+		return problems
+	}
+
+	for i, profile := range *app.Profiles {
+		if profile.Defaults != nil && profile.Defaults.TierName != "" {
+			problems = append(problems, Problem{
+				Pointer: path + "/profiles" + pointer(i) + "/defaults/tierName",
+				Message: "tierName is not allowed in defaults",
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateRegistry checks that OCI registry has host, Docker Hub host is used by default
+func validateRegistry(registry *types.Registry, path string) []Problem {
+	if registry == nil || registry.Type != types.RegistryTypeOCI || registry.Host != "" {
+		return nil
+	}
+
+	return []Problem{{Pointer: path + "/host", Message: "host is required for oci registry"}}
+}
+
+// validateTagPolicy checks that tag filter and exclude patterns are valid regular expressions
+func validateTagPolicy(policy *types.TagPolicy, path string) []Problem {
+	problems := []Problem{}
+	if policy == nil {
+		return problems
+	}
+
+	if _, err := regexp.Compile(policy.Filter); err != nil {
+		problems = append(problems, Problem{Pointer: path + "/filter", Message: fmt.Sprintf("invalid regular expression: %v", err)})
+	}
+
+	for i, pattern := range policy.Exclude {
+		if _, err := regexp.Compile(pattern); err != nil {
+			problems = append(problems, Problem{
+				Pointer: path + "/exclude" + pointer(i),
+				Message: fmt.Sprintf("invalid regular expression: %v", err),
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateStrategy checks that RollingUpdate strategy has rollingUpdate block
+func validateStrategy(strategy *types.DeployStrategy, path string) []Problem {
+	if strategy == nil || strategy.Type != "RollingUpdate" || strategy.RollingUpdate != nil {
+		return nil
+	}
+
+	return []Problem{{Pointer: path + "/rollingUpdate", Message: "rollingUpdate is required for RollingUpdate strategy"}}
+}
+
+// validateContainers checks that sidecars or init containers have unique names and tagged images,
+// only init containers can be native sidecars with restartPolicy
+func validateContainers(containers []types.Container, init bool, path string) []Problem {
+	problems := []Problem{}
+	names := map[string]bool{}
+
+	for i, container := range containers {
+		containerPointer := path + pointer(i)
+
+		if names[container.Name] {
+			problems = append(problems, Problem{Pointer: containerPointer + "/name", Message: fmt.Sprintf("duplicate container name %q", container.Name)})
+		}
+		names[container.Name] = true
+
+		if !imageTagged(container.Image) {
+			problems = append(problems, Problem{
+				Pointer: containerPointer + "/image",
+				Message: fmt.Sprintf("image %q must have tag or digest", container.Image),
+			})
+		}
+
+		if container.RestartPolicy != "" && !init {
+			problems = append(problems, Problem{
+				Pointer: containerPointer + "/restartPolicy",
+				Message: "restartPolicy is supported only by initContainers, sidecars always run along the application",
+			})
+		}
+
+		problems = append(problems, validateEnv(container.Env, containerPointer+"/env")...)
+		problems = append(problems, validatePorts(container.Ports, containerPointer+"/ports")...)
+		problems = append(problems, validateSecurityContext(container.SecurityContext, true, containerPointer+"/securityContext")...)
+		if container.Resources != nil {
+			problems = append(problems, validateQuantities(container.Resources.Requests, containerPointer+"/resources/requests")...)
+			problems = append(problems, validateQuantities(container.Resources.Limits, containerPointer+"/resources/limits")...)
+		}
+	}
+
+	return problems
+}
+
+// validateSecurityContext checks that security settings don't contradict each other and capabilities
+// are named without CAP_ prefix, fsGroup is supported only by the application pod
+func validateSecurityContext(settings *types.SecurityContext, container bool, path string) []Problem {
+	problems := []Problem{}
+	if settings == nil {
+		return problems
+	}
+
+	if settings.RunAsNonRoot != nil && *settings.RunAsNonRoot && settings.RunAsUser != nil && *settings.RunAsUser == 0 {
+		problems = append(problems, Problem{Pointer: path + "/runAsUser", Message: "runAsUser 0 contradicts runAsNonRoot"})
+	}
+
+	if settings.FSGroup != nil && container {
+		problems = append(problems, Problem{
+			Pointer: path + "/fsGroup",
+			Message: "fsGroup is applied to the whole pod, set it on application level",
+		})
+	}
+
+	if settings.Capabilities != nil {
+		problems = append(problems, validateCapabilities(settings.Capabilities.Add, path+"/capabilities/add")...)
+		problems = append(problems, validateCapabilities(settings.Capabilities.Drop, path+"/capabilities/drop")...)
+	}
+
+	return problems
+}
+
+// validateCapabilities checks that linux capabilities are named without CAP_ prefix
+func validateCapabilities(capabilities []string, path string) []Problem {
+	problems := []Problem{}
+
+	for i, capability := range capabilities {
+		if strings.HasPrefix(capability, "CAP_") {
+			problems = append(problems, Problem{
+				Pointer: path + pointer(i),
+				Message: fmt.Sprintf("capability %q must be named without CAP_ prefix", capability),
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateVolumes checks that volumes have unique names and mount paths and exactly one source,
+// and that sidecars and init containers mount only volumes of the pod
+func validateVolumes(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+	names := map[string]bool{}
+	mountPaths := map[string]bool{}
+
+	if app.StatefulSet != nil {
+		for _, claim := range app.StatefulSet.VolumeClaimTemplates {
+			names[claim.Name] = true
+			mountPaths[claim.MountPath] = true
+		}
+	}
+
+	for i, volume := range app.Volumes {
+		volumePointer := path + "/volumes" + pointer(i)
+
+		if names[volume.Name] {
+			problems = append(problems, Problem{Pointer: volumePointer + "/name", Message: fmt.Sprintf("duplicate volume name %q", volume.Name)})
+		}
+		names[volume.Name] = true
+
+		if volume.MountPath != "" {
+			if mountPaths[volume.MountPath] {
+				problems = append(problems, Problem{Pointer: volumePointer + "/mountPath", Message: fmt.Sprintf("duplicate mount path %q", volume.MountPath)})
+			}
+			mountPaths[volume.MountPath] = true
+		}
+
+		sources := countSet(
+			volume.ConfigMap != nil,
+			volume.Secret != nil,
+			volume.EmptyDir != nil,
+			volume.PersistentVolumeClaim != nil,
+			volume.Projected != nil,
+			volume.CSI != nil,
+		)
+		if sources != 1 {
+			problems = append(problems, Problem{
+				Pointer: volumePointer,
+				Message: "exactly one of configMap, secret, emptyDir, persistentVolumeClaim, projected or csi must be set",
+			})
+		}
+
+		if volume.EmptyDir != nil && volume.EmptyDir.SizeLimit != "" {
+			if _, err := resource.ParseQuantity(volume.EmptyDir.SizeLimit); err != nil {
+				problems = append(problems, Problem{
+					Pointer: volumePointer + "/emptyDir/sizeLimit",
+					Message: fmt.Sprintf("invalid sizeLimit quantity %q: %v", volume.EmptyDir.SizeLimit, err),
+				})
+			}
+		}
+
+		if volume.Projected != nil {
+			for j, source := range volume.Projected.Sources {
+				if countSet(source.ConfigMap != nil, source.Secret != nil, source.ServiceAccountToken != nil) == 1 {
+					continue
+				}
+				problems = append(problems, Problem{
+					Pointer: volumePointer + "/projected/sources" + pointer(j),
+					Message: "exactly one of configMap, secret or serviceAccountToken must be set",
+				})
+			}
+		}
+	}
+
+	for _, volume := range app.PodVolumes() {
+		names[volume.Name] = true
+	}
+
+	problems = append(problems, validateVolumeMounts(app.Sidecars, names, path+"/sidecars")...)
+	problems = append(problems, validateVolumeMounts(app.InitContainers, names, path+"/initContainers")...)
+	if app.Profiles != nil {
+		for i, profile := range *app.Profiles {
+			if profile.Datacenters == nil {
+				continue
+			}
+			for j, tier := range *profile.Datacenters {
+				tierPointer := path + "/profiles" + pointer(i) + "/datacenters" + pointer(j)
+				problems = append(problems, validateVolumeMounts(tier.Sidecars, names, tierPointer+"/sidecars")...)
+				problems = append(problems, validateVolumeMounts(tier.InitContainers, names, tierPointer+"/initContainers")...)
+			}
+		}
+	}
+
+	return problems
+}
+
+// countSet returns number of true values
+func countSet(values ...bool) int {
+	count := 0
+	for _, value := range values {
+		if value {
+			count++
+		}
+	}
+
+	return count
+}
+
+// validateVolumeMounts checks that containers mount existing volumes
+func validateVolumeMounts(containers []types.Container, volumes map[string]bool, path string) []Problem {
+	problems := []Problem{}
+
+	for i, container := range containers {
+		for j, mount := range container.VolumeMounts {
+			if !volumes[mount.Name] {
+				problems = append(problems, Problem{
+					Pointer: path + pointer(i) + "/volumeMounts" + pointer(j) + "/name",
+					Message: fmt.Sprintf("volume %q isn't defined in volumes", mount.Name),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// envFieldPaths are pod fields supported by fieldRef of environment variable
+var envFieldPaths = regexp.MustCompile(`^(metadata\.(name|namespace|uid|labels\['[^']+'\]|annotations\['[^']+'\])|` +
+	`spec\.(nodeName|serviceAccountName)|status\.(hostIP|hostIPs|podIP|podIPs))$`)
+
+// envResources are container resources supported by resourceFieldRef of environment variable
+var envResources = []string{
+	"limits.cpu", "limits.memory", "limits.ephemeral-storage",
+	"requests.cpu", "requests.memory", "requests.ephemeral-storage",
+}
+
+// validateEnv checks that environment variable has either literal value or exactly one valid valueFrom source
+func validateEnv(envs []types.EnvVar, path string) []Problem {
+	problems := []Problem{}
+
+	for i, env := range envs {
+		source := env.ValueFrom
+		if source == nil {
+			continue
+		}
+		envPointer := path + pointer(i)
+
+		if env.Value != "" {
+			problems = append(problems, Problem{Pointer: envPointer, Message: fmt.Sprintf("variable %q has both value and valueFrom", env.Name)})
+		}
+
+		if countSet(source.SecretKeyRef != nil, source.ConfigMapKeyRef != nil, source.FieldRef != nil, source.ResourceFieldRef != nil) != 1 {
+			problems = append(problems, Problem{
+				Pointer: envPointer + "/valueFrom",
+				Message: "exactly one of secretKeyRef, configMapKeyRef, fieldRef or resourceFieldRef must be set",
+			})
+		}
+
+		if ref := source.FieldRef; ref != nil && !envFieldPaths.MatchString(ref.FieldPath) {
+			problems = append(problems, Problem{
+				Pointer: envPointer + "/valueFrom/fieldRef/fieldPath",
+				Message: fmt.Sprintf("unsupported field path %q, e.g. status.podIP or metadata.labels['app'] is expected", ref.FieldPath),
+			})
+		}
+
+		if ref := source.ResourceFieldRef; ref != nil {
+			if !slices.Contains(envResources, ref.Resource) {
+				problems = append(problems, Problem{
+					Pointer: envPointer + "/valueFrom/resourceFieldRef/resource",
+					Message: fmt.Sprintf("unsupported resource %q, expected one of %s", ref.Resource, strings.Join(envResources, ", ")),
+				})
+			}
+			if ref.Divisor != "" {
+				if _, err := resource.ParseQuantity(ref.Divisor); err != nil {
+					problems = append(problems, Problem{
+						Pointer: envPointer + "/valueFrom/resourceFieldRef/divisor",
+						Message: fmt.Sprintf("invalid divisor quantity %q: %v", ref.Divisor, err),
+					})
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateEnvFromSources checks that every source references either secret or configmap
+func validateEnvFromSources(sources []types.EnvFromSource, path string) []Problem {
+	problems := []Problem{}
+
+	for i, source := range sources {
+		if (source.Secret == "") == (source.ConfigMap == "") {
+			problems = append(problems, Problem{Pointer: path + pointer(i), Message: "exactly one of secret or configMap must be set"})
+		}
+	}
+
+	return problems
+}
+
+// imageTagged checks that image reference has a tag or digest, e.g. "envoy:v1.30.1" or "registry:5000/envoy@sha256:..."
+func imageTagged(image string) bool {
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	return strings.Contains(name, ":") || strings.Contains(name, "@")
+}
+
+// cronMacros are predefined schedules supported by kubernetes cronjob
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// validateWorkload checks that workload settings belong to the application type, cronjob has a schedule,
+// statefulset volume claims are valid and batch applications aren't autoscaled
+func validateWorkload(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+
+	if app.CronJob != nil && app.Type != types.WorkloadTypeCronJob {
+		problems = append(problems, Problem{Pointer: path + "/cronJob", Message: "cronJob settings are supported only by cronjob applications"})
+	}
+	if app.Job != nil && !app.IsBatch() {
+		problems = append(problems, Problem{Pointer: path + "/job", Message: "job settings are supported only by job and cronjob applications"})
+	}
+	if app.StatefulSet != nil && app.Type != types.WorkloadTypeStatefulSet {
+		problems = append(problems, Problem{Pointer: path + "/statefulSet", Message: "statefulSet settings are supported only by statefulset applications"})
+	}
+
+	if app.Type == types.WorkloadTypeCronJob {
+		switch {
+		case app.CronJob == nil || app.CronJob.Schedule == "":
+			problems = append(problems, Problem{Pointer: path + "/cronJob/schedule", Message: "schedule is required for cronjob applications"})
+		case !validSchedule(app.CronJob.Schedule):
+			problems = append(problems, Problem{
+				Pointer: path + "/cronJob/schedule",
+				Message: fmt.Sprintf("invalid schedule %q, expected 5 cron fields or one of %s", app.CronJob.Schedule, strings.Join(cronMacros, ", ")),
+			})
+		}
+	}
+
+	if app.StatefulSet != nil {
+		names := map[string]bool{}
+		mountPaths := map[string]bool{}
+		for i, claim := range app.StatefulSet.VolumeClaimTemplates {
+			claimPointer := path + "/statefulSet/volumeClaimTemplates" + pointer(i)
+
+			if names[claim.Name] {
+				problems = append(problems, Problem{Pointer: claimPointer + "/name", Message: fmt.Sprintf("duplicate volume claim name %q", claim.Name)})
+			}
+			names[claim.Name] = true
+
+			if mountPaths[claim.MountPath] {
+				problems = append(problems, Problem{Pointer: claimPointer + "/mountPath", Message: fmt.Sprintf("duplicate mount path %q", claim.MountPath)})
+			}
+			mountPaths[claim.MountPath] = true
+
+			if _, err := resource.ParseQuantity(claim.Size); err != nil {
+				problems = append(problems, Problem{
+					Pointer: claimPointer + "/size",
+					Message: fmt.Sprintf("invalid size quantity %q: %v", claim.Size, err),
+				})
+			}
+		}
+	}
+
+	if app.IsBatch() && app.Profiles != nil {
+		for i, profile := range *app.Profiles {
+			if profile.Datacenters == nil {
+				continue
+			}
+			for j, tier := range *profile.Datacenters {
+				tierPointer := path + "/profiles" + pointer(i) + "/datacenters" + pointer(j)
+				if tier.Autoscaling != nil {
+					problems = append(problems, Problem{Pointer: tierPointer + "/autoscaling", Message: "autoscaling isn't supported by " + app.Type + " applications"})
+				}
+				if tier.DisruptionBudget != nil {
+					problems = append(problems, Problem{Pointer: tierPointer + "/disruptionBudget", Message: "disruptionBudget isn't supported by " + app.Type + " applications"})
+				}
+				if tier.ChaosMonkey != nil && tier.ChaosMonkey.Enabled {
+					problems = append(problems, Problem{Pointer: tierPointer + "/chaosMonkey/enabled", Message: "chaosMonkey isn't supported by " + app.Type + " applications"})
+				}
+				if len(app.PodSidecars(tier)) != 0 {
+					problems = append(problems, Problem{
+						Pointer: tierPointer + "/sidecars",
+						Message: "sidecars never complete and block " + app.Type + " completion, use initContainers with restartPolicy Always",
+					})
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// validSchedule checks that cron schedule is a predefined macro or has 5 fields
+func validSchedule(schedule string) bool {
+	if strings.HasPrefix(schedule, "@") {
+		return slices.Contains(cronMacros, schedule)
+	}
+
+	return len(strings.Fields(schedule)) == 5
+}
+
+// validateIngress checks that ingress belongs to service application, settings match ingress kind
+// and paths are routed to existing ports
+func validateIngress(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+	ingress := app.Ingress
+	if ingress == nil {
+		return problems
+	}
+
+	if app.Type != types.WorkloadTypeService {
+		problems = append(problems, Problem{Pointer: path, Message: "ingress is supported only by service applications"})
+	}
+
+	if ingress.IngressKind() == types.IngressKindHTTPRoute {
+		if ingress.Gateway == nil {
+			problems = append(problems, Problem{Pointer: path + "/gateway", Message: "gateway is required for httpRoute"})
+		}
+		if ingress.ClassName != "" {
+			problems = append(problems, Problem{Pointer: path + "/className", Message: "className is supported only by ingress, use gateway"})
+		}
+		if ingress.TLSSecret != "" {
+			problems = append(problems, Problem{Pointer: path + "/tlsSecret", Message: "tlsSecret is supported only by ingress, configure TLS on gateway listener"})
+		}
+	}
+
+	if len(app.Ports) == 0 {
+		return append(problems, Problem{Pointer: path, Message: "ports are required to route ingress traffic"})
+	}
+
+	for i, routed := range ingress.RoutedPaths(app.Ports) {
+		if types.FindPort(app.Ports, routed.Port) == nil {
+			problems = append(problems, Problem{
+				Pointer: path + "/paths" + pointer(i) + "/port",
+				Message: fmt.Sprintf("port %q isn't defined in ports", routed.Port),
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateNetworkPolicy checks that ingress sources are valid and open existing ports
+func validateNetworkPolicy(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+	if app.NetworkPolicy == nil {
+		return problems
+	}
+
+	for i, source := range app.NetworkPolicy.IngressFrom {
+		sourcePointer := path + "/ingressFrom" + pointer(i)
+
+		if source.CIDR != "" {
+			if _, _, err := net.ParseCIDR(source.CIDR); err != nil {
+				problems = append(problems, Problem{Pointer: sourcePointer + "/cidr", Message: err.Error()})
+			}
+			if source.Namespace != "" || len(source.PodLabels) > 0 {
+				problems = append(problems, Problem{Pointer: sourcePointer, Message: "cidr can't be combined with namespace or podLabels"})
+			}
+		}
+
+		for j, name := range source.Ports {
+			if types.FindPort(app.Ports, name) == nil {
+				problems = append(problems, Problem{
+					Pointer: sourcePointer + "/ports" + pointer(j),
+					Message: fmt.Sprintf("port %q isn't defined in ports", name),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateMonitoring checks that configured monitoring scrapes existing port and ServiceMonitor has Service to select
+func validateMonitoring(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+	if app.Monitoring == nil || app.Monitoring.Disabled {
+		return problems
+	}
+
+	if types.FindPort(app.Ports, app.MetricsPort()) == nil {
+		problems = append(problems, Problem{
+			Pointer: path + "/port",
+			Message: fmt.Sprintf("metrics port %q isn't defined in ports", app.MetricsPort()),
+		})
+	}
+
+	if app.MonitorKind() == types.MonitorKindServiceMonitor && !app.HasService() {
+		problems = append(problems, Problem{
+			Pointer: path + "/kind",
+			Message: "serviceMonitor requires service application, use podMonitor",
+		})
+	}
+
+	return problems
+}
+
+// validatePorts checks that port names are unique, and container and service port numbers are unique per protocol
+func validatePorts(ports []types.Port, path string) []Problem {
+	problems := []Problem{}
+	names := map[string]bool{}
+	numbers := map[string]bool{}
+	exposed := map[string]bool{}
+
+	for i, port := range ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = "TCP"
+		}
+		number := fmt.Sprintf("%d/%s", port.ContainerPort, protocol)
+		exposedNumber := fmt.Sprintf("%d/%s", port.ExposedPort(), protocol)
+
+		if names[port.Name] {
+			problems = append(problems, Problem{
+				Pointer: path + pointer(i) + "/name",
+				Message: fmt.Sprintf("duplicate port name %q", port.Name),
+			})
+		}
+		if numbers[number] {
+			problems = append(problems, Problem{
+				Pointer: path + pointer(i) + "/containerPort",
+				Message: fmt.Sprintf("duplicate container port %d", port.ContainerPort),
+			})
+		} else if exposed[exposedNumber] {
+			problems = append(problems, Problem{
+				Pointer: path + pointer(i) + "/servicePort",
+				Message: fmt.Sprintf("duplicate service port %d", port.ExposedPort()),
+			})
+		}
+		names[port.Name] = true
+		numbers[number] = true
+		exposed[exposedNumber] = true
+	}
+
+	return problems
+}
+
+// validateProfiles checks profile names and all datacenters of the application
+func validateProfiles(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+	if app.Profiles == nil {
+		return problems
+	}
+
+	profiles := map[string]bool{}
+	for i, profile := range *app.Profiles {
+		profilePointer := path + "/profiles" + pointer(i)
+
+		if profiles[profile.ProfileName] {
+			problems = append(problems, Problem{
+				Pointer: profilePointer + "/profileName",
+				Message: fmt.Sprintf("duplicate profile name %q", profile.ProfileName),
+			})
+		}
+		profiles[profile.ProfileName] = true
+
+		if profile.Datacenters == nil {
+			continue
+		}
+
+		tiers := map[string]bool{}
+		for j, tier := range *profile.Datacenters {
+			tierPointer := profilePointer + "/datacenters" + pointer(j)
+
+			if tiers[tier.TierName] {
+				problems = append(problems, Problem{
+					Pointer: tierPointer + "/tierName",
+					Message: fmt.Sprintf("duplicate tier name %q in profile %q", tier.TierName, profile.ProfileName),
+				})
+			}
+			tiers[tier.TierName] = true
+
+			problems = append(problems, validateDatacenter(tier, tierPointer)...)
+		}
+	}
+
+	return problems
+}
+
+// validatePromotions checks that profiles are promoted from existing profile and tier without cycles
+func validatePromotions(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+	if app.Profiles == nil {
+		return problems
+	}
+
+	for i, profile := range *app.Profiles {
+		if profile.PromotesFrom == nil {
+			continue
+		}
+		promotionPointer := path + "/profiles" + pointer(i) + "/promotesFrom"
+
+		source := app.Profile(profile.PromotesFrom.ProfileName)
+		switch {
+		case profile.PromotesFrom.ProfileName == profile.ProfileName:
+			problems = append(problems, Problem{
+				Pointer: promotionPointer + "/profileName",
+				Message: fmt.Sprintf("profile %q can't be promoted from itself", profile.ProfileName),
+			})
+			continue
+		case source == nil:
+			problems = append(problems, Problem{
+				Pointer: promotionPointer + "/profileName",
+				Message: fmt.Sprintf("unknown source profile %q", profile.PromotesFrom.ProfileName),
+			})
+			continue
+		case profile.PromotesFrom.TierName != "" && !hasTier(source, profile.PromotesFrom.TierName):
+			problems = append(problems, Problem{
+				Pointer: promotionPointer + "/tierName",
+				Message: fmt.Sprintf("unknown tier %q in source profile %q", profile.PromotesFrom.TierName, source.ProfileName),
+			})
+		}
+
+		if cycle := promotionCycle(app, profile); cycle != nil {
+			problems = append(problems, Problem{
+				Pointer: promotionPointer,
+				Message: "promotion cycle " + strings.Join(cycle, " -> "),
+			})
+		}
+	}
+
+	return problems
+}
+
+// promotionCycle returns profile names of promotion cycle starting from the profile, nil is returned when there is no cycle
+func promotionCycle(app *types.Configuration, profile *types.Profile) []string {
+	chain := []string{profile.ProfileName}
+
+	for current := profile; current.PromotesFrom != nil; {
+		current = app.Profile(current.PromotesFrom.ProfileName)
+		if current == nil {
+			return nil
+		}
+
+		chain = append(chain, current.ProfileName)
+		if current.ProfileName == profile.ProfileName {
+			return chain
+		}
+		// cycle which doesn't include the profile is reported for profiles inside it
+		if len(chain) > len(*app.Profiles) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// hasTier checks if profile has datacenter with the tier name
+func hasTier(profile *types.Profile, tierName string) bool {
+	if profile.Datacenters == nil {
+		return false
+	}
+
+	for _, tier := range *profile.Datacenters {
+		if tier.TierName == tierName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateDatacenter checks that resolved datacenter can be rendered into kubernetes manifest
+func validateDatacenter(tier *types.Datacenter, path string) []Problem {
+	problems := []Problem{}
+
+	if tier.LivenessProbe == nil {
+		problems = append(problems, Problem{Pointer: path + "/livenessProbe", Message: "livenessProbe is required"})
+	}
+	problems = append(problems, validateProbe(tier.LivenessProbe, false, path+"/livenessProbe")...)
+	problems = append(problems, validateProbe(tier.ReadinessProbe, true, path+"/readinessProbe")...)
+	problems = append(problems, validateProbe(tier.StartupProbe, false, path+"/startupProbe")...)
+
+	if tier.ChaosMonkey == nil {
+		problems = append(problems, Problem{
+			Pointer: path + "/chaosMonkey",
+			Message: "chaosMonkey is required on datacenter, defaults or application level",
+		})
+	}
+
+	problems = append(problems, validateAutoscaling(tier, path+"/autoscaling")...)
+	problems = append(problems, validateDisruptionBudget(tier, path+"/disruptionBudget")...)
+	problems = append(problems, validateContainers(tier.Sidecars, false, path+"/sidecars")...)
+	problems = append(problems, validateContainers(tier.InitContainers, true, path+"/initContainers")...)
+	if tier.Env != nil {
+		problems = append(problems, validateEnv(*tier.Env, path+"/env")...)
+	}
+	problems = append(problems, validateEnvFromSources(tier.EnvFromSources, path+"/envFromSources")...)
+	problems = append(problems, validateScheduling(tier.Scheduling, path+"/scheduling")...)
+
+	if tier.Resources == nil {
+		problems = append(problems, Problem{Pointer: path + "/resources", Message: "resources are required"})
+		return problems
+	}
+
+	if tier.Resources.Requests == nil {
+		problems = append(problems, Problem{Pointer: path + "/resources/requests", Message: "resource requests are required"})
+	}
+
+	problems = append(problems, validateResourceList(tier.Resources.Requests, path+"/resources/requests")...)
+	problems = append(problems, validateResourceList(tier.Resources.Limits, path+"/resources/limits")...)
+
+	return problems
+}
+
+// validateScheduling checks that topology spread constraints have unique topology keys and tolerations
+// can be accepted by kubernetes
+func validateScheduling(scheduling *types.Scheduling, path string) []Problem {
+	problems := []Problem{}
+	if scheduling == nil {
+		return problems
+	}
+
+	topologyKeys := map[string]bool{}
+	for i, constraint := range scheduling.TopologySpreadConstraints {
+		topologyKey := constraint.TopologyKey
+		if topologyKey == "" {
+			topologyKey = "topology.kubernetes.io/zone"
+		}
+		if topologyKeys[topologyKey] {
+			problems = append(problems, Problem{
+				Pointer: path + "/topologySpreadConstraints" + pointer(i) + "/topologyKey",
+				Message: fmt.Sprintf("duplicate topology key %q", topologyKey),
+			})
+		}
+		topologyKeys[topologyKey] = true
+	}
+
+	for i, toleration := range scheduling.Tolerations {
+		tolerationPointer := path + "/tolerations" + pointer(i)
+
+		if toleration.Operator == "Exists" && toleration.Value != "" {
+			problems = append(problems, Problem{Pointer: tolerationPointer + "/value", Message: "value must be empty for Exists operator"})
+		}
+		if toleration.Key == "" && toleration.Operator != "Exists" {
+			problems = append(problems, Problem{Pointer: tolerationPointer + "/operator", Message: "toleration without key must use Exists operator"})
+		}
+		if toleration.TolerationSeconds != nil && toleration.Effect != "NoExecute" {
+			problems = append(problems, Problem{
+				Pointer: tolerationPointer + "/tolerationSeconds",
+				Message: "tolerationSeconds is supported only by NoExecute effect",
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateProbe checks that probe has settings of its type only, exec probe has command and
+// only readiness probe can require several successes
+func validateProbe(probe *types.Probe, readiness bool, path string) []Problem {
+	problems := []Problem{}
+	if probe == nil {
+		return problems
+	}
+
+	http := probe.Type == "http" || probe.Type == ""
+	if probe.Type == "exec" && len(probe.Command) == 0 {
+		problems = append(problems, Problem{Pointer: path + "/command", Message: "command is required for exec probe"})
+	}
+	if probe.Type != "exec" && len(probe.Command) != 0 {
+		problems = append(problems, Problem{Pointer: path + "/command", Message: "command is supported only by exec probe"})
+	}
+	if probe.Type != "grpc" && probe.Service != "" {
+		problems = append(problems, Problem{Pointer: path + "/service", Message: "service is supported only by grpc probe"})
+	}
+	if !http && probe.Scheme != "" {
+		problems = append(problems, Problem{Pointer: path + "/scheme", Message: "scheme is supported only by http probe"})
+	}
+	if !http && len(probe.Headers) != 0 {
+		problems = append(problems, Problem{Pointer: path + "/headers", Message: "headers are supported only by http probe"})
+	}
+	if !readiness && probe.SuccessThreshold > 1 {
+		problems = append(problems, Problem{
+			Pointer: path + "/successThreshold",
+			Message: "successThreshold must be 1 for liveness and startup probes",
+		})
+	}
+
+	return problems
+}
+
+// validateDisruptionBudget checks PodDisruptionBudget values and rejects budgets which make deployment un-drainable,
+// i.e. which never allow eviction of a single pod
+func validateDisruptionBudget(tier *types.Datacenter, path string) []Problem {
+	problems := []Problem{}
+	budget := tier.DisruptionBudget
+	if budget == nil || budget.Disabled {
+		return problems
+	}
+
+	if budget.MinAvailable != "" && budget.MaxUnavailable != "" {
+		return append(problems, Problem{Pointer: path, Message: "only one of minAvailable and maxUnavailable is allowed"})
+	}
+
+	if budget.MaxUnavailable != "" {
+		value, percent, err := parseIntOrPercent(budget.MaxUnavailable)
+		switch {
+		case err != nil:
+			problems = append(problems, Problem{Pointer: path + "/maxUnavailable", Message: err.Error()})
+		case value == 0:
+			problems = append(problems, Problem{Pointer: path + "/maxUnavailable", Message: "maxUnavailable 0 makes deployment un-drainable"})
+		case percent && value > 100:
+			problems = append(problems, Problem{Pointer: path + "/maxUnavailable", Message: "percent can't be greater than 100%"})
+		}
+	}
+
+	if budget.MinAvailable != "" {
+		value, percent, err := parseIntOrPercent(budget.MinAvailable)
+		if err != nil {
+			return append(problems, Problem{Pointer: path + "/minAvailable", Message: err.Error()})
+		}
+
+		replicas := int(tier.MinReplicas())
+		required := value
+		if percent {
+			// policy controller rounds percent of minAvailable up
+			required = (value*replicas + 99) / 100
+		}
+		if (percent && value >= 100) || (replicas > 0 && required >= replicas) {
+			problems = append(problems, Problem{
+				Pointer: path + "/minAvailable",
+				Message: fmt.Sprintf("minAvailable %s of %d replicas makes deployment un-drainable", budget.MinAvailable, replicas),
+			})
+		}
+	}
+
+	return problems
+}
+
+// parseIntOrPercent parses non-negative integer or percent value like "1" or "25%"
+func parseIntOrPercent(value string) (int, bool, error) {
+	trimmed, percent := strings.CutSuffix(value, "%")
+	number, err := strconv.Atoi(trimmed)
+	if err != nil || number < 0 {
+		return 0, false, fmt.Errorf("%q isn't a non-negative integer or percent", value)
+	}
+
+	return number, percent, nil
+}
+
+// validateAutoscaling checks replicas range and custom metrics targets of HorizontalPodAutoscaler
+func validateAutoscaling(tier *types.Datacenter, path string) []Problem {
+	problems := []Problem{}
+	autoscaling := tier.Autoscaling
+	if autoscaling == nil {
+		return problems
+	}
+
+	minReplicas := tier.MinReplicas()
+	if minReplicas > autoscaling.MaxReplicas {
+		problems = append(problems, Problem{
+			Pointer: path + "/maxReplicas",
+			Message: fmt.Sprintf("maxReplicas %d is less than minReplicas %d", autoscaling.MaxReplicas, minReplicas),
+		})
+	}
+
+	for i, metric := range autoscaling.Metrics {
+		metricPointer := path + "/metrics" + pointer(i)
+
+		switch {
+		case metric.TargetAverageValue == "" && metric.TargetValue == "":
+			problems = append(problems, Problem{Pointer: metricPointer, Message: "targetAverageValue or targetValue is required"})
+			continue
+		case metric.TargetAverageValue != "" && metric.TargetValue != "":
+			problems = append(problems, Problem{Pointer: metricPointer, Message: "only one of targetAverageValue and targetValue is allowed"})
+			continue
+		case metric.Type == "pods" && metric.TargetValue != "":
+			problems = append(problems, Problem{
+				Pointer: metricPointer + "/targetValue",
+				Message: "pods metric supports only targetAverageValue",
+			})
+			continue
+		}
+
+		target, name := metric.TargetAverageValue, "targetAverageValue"
+		if target == "" {
+			target, name = metric.TargetValue, "targetValue"
+		}
+		if _, err := resource.ParseQuantity(target); err != nil {
+			problems = append(problems, Problem{
+				Pointer: metricPointer + "/" + name,
+				Message: fmt.Sprintf("invalid quantity %q: %v", target, err),
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateResourceList checks that cpu and memory are valid kubernetes quantities
+func validateResourceList(list *types.ResourceList, path string) []Problem {
+	problems := []Problem{}
+	if list == nil {
+		return problems
+	}
+
+	quantities := []struct {
+		name  string
+		value string
+	}{
+		{name: "cpu", value: list.CPU},
+		{name: "memory", value: list.Memory},
+	}
+
+	for _, quantity := range quantities {
+		if _, err := resource.ParseQuantity(quantity.value); err != nil {
+			problems = append(problems, Problem{
+				Pointer: path + "/" + quantity.name,
+				Message: fmt.Sprintf("invalid %s quantity %q: %v", quantity.name, quantity.value, err),
+			})
+		}
+	}
+
+	return append(problems, validateExtendedResources(list, path)...)
+}
+
+// validateQuantities checks cpu and memory quantities of the list which are set
+func validateQuantities(list *types.ResourceList, path string) []Problem {
+	problems := []Problem{}
+	if list == nil {
+		return problems
+	}
+
+	quantities := []struct {
+		name  string
+		value string
+	}{
+		{name: "cpu", value: list.CPU},
+		{name: "memory", value: list.Memory},
+	}
+
+	for _, quantity := range quantities {
+		if quantity.value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity.value); err != nil {
+			problems = append(problems, Problem{
+				Pointer: path + "/" + quantity.name,
+				Message: fmt.Sprintf("invalid %s quantity %q: %v", quantity.name, quantity.value, err),
+			})
+		}
+	}
+
+	return append(problems, validateExtendedResources(list, path)...)
+}
+
+// extendedResourceName is fully-qualified name of extended resource, e.g. nvidia.com/gpu
+var extendedResourceName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+/[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+// validateExtendedResources checks ephemeral storage quantity and that extended resources have fully-qualified
+// names outside of kubernetes.io domain and whole number quantities
+func validateExtendedResources(list *types.ResourceList, path string) []Problem {
+	problems := []Problem{}
+
+	if list.EphemeralStorage != "" {
+		if _, err := resource.ParseQuantity(list.EphemeralStorage); err != nil {
+			problems = append(problems, Problem{
+				Pointer: path + "/ephemeralStorage",
+				Message: fmt.Sprintf("invalid ephemeral-storage quantity %q: %v", list.EphemeralStorage, err),
+			})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(list.Extended)) {
+		namePointer := path + "/extended/" + escapePointer(name)
+		domain, _, _ := strings.Cut(name, "/")
+
+		if !extendedResourceName.MatchString(name) || domain == "kubernetes.io" || strings.HasSuffix(domain, ".kubernetes.io") {
+			problems = append(problems, Problem{
+				Pointer: namePointer,
+				Message: fmt.Sprintf("extended resource %q must be fully-qualified name outside of kubernetes.io domain, e.g. nvidia.com/gpu", name),
+			})
+		}
+
+		quantity, err := resource.ParseQuantity(list.Extended[name])
+		switch {
+		case err != nil:
+			problems = append(problems, Problem{
+				Pointer: namePointer,
+				Message: fmt.Sprintf("invalid %s quantity %q: %v", name, list.Extended[name], err),
+			})
+		case quantity.MilliValue()%1000 != 0:
+			problems = append(problems, Problem{
+				Pointer: namePointer,
+				Message: fmt.Sprintf("%s quantity %q must be a whole number", name, list.Extended[name]),
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateResourcePolicy checks that resources of the application datacenters, sidecars and init containers
+// follow the resource policy and pods fit into its maximum
+func validateResourcePolicy(app *types.Configuration, path string) []Problem {
+	problems := []Problem{}
+	policy := app.PodResourcePolicy()
+	if app.ResourcePolicy != nil {
+		problems = append(problems, validateQuantities(app.ResourcePolicy.MaxPod, path+"/resourcePolicy/maxPod")...)
+		problems = append(problems, validateOrganizationResourcePolicy(app.ResourcePolicy, app.OrganizationResourcePolicy, path+"/resourcePolicy")...)
+	}
+
+	for i, container := range app.Sidecars {
+		problems = append(problems, validateResourceLimits(policy, container.Resources, path+"/sidecars"+pointer(i)+"/resources")...)
+	}
+	for i, container := range app.InitContainers {
+		problems = append(problems, validateResourceLimits(policy, container.Resources, path+"/initContainers"+pointer(i)+"/resources")...)
+	}
+
+	if app.Profiles == nil {
+		return problems
+	}
+	for i, profile := range *app.Profiles {
+		if profile.Datacenters == nil {
+			continue
+		}
+		for j, tier := range *profile.Datacenters {
+			tierPointer := path + "/profiles" + pointer(i) + "/datacenters" + pointer(j)
+
+			problems = append(problems, validateResourceLimits(policy, tier.Resources, tierPointer+"/resources")...)
+			for k, container := range tier.Sidecars {
+				problems = append(problems, validateResourceLimits(policy, container.Resources, tierPointer+"/sidecars"+pointer(k)+"/resources")...)
+			}
+			for k, container := range tier.InitContainers {
+				problems = append(problems, validateResourceLimits(policy, container.Resources, tierPointer+"/initContainers"+pointer(k)+"/resources")...)
+			}
+
+			// invalid quantities are reported by resource validation, pod resources can't be summed with them
+			if app.CheckPodResources(tier) == nil {
+				problems = append(problems, validatePodResources(policy, app.PodResources(tier), tierPointer+"/resources")...)
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateOrganizationResourcePolicy checks that resource policy of the application doesn't loosen
+// limit rules set by organization and doesn't raise its maximum of the pod
+func validateOrganizationResourcePolicy(policy, organization *types.ResourcePolicy, path string) []Problem {
+	problems := []Problem{}
+	if organization == nil {
+		return problems
+	}
+
+	if organization.CPULimit != "" && policy.CPULimit != "" && policy.CPULimit != organization.CPULimit {
+		problems = append(problems, Problem{
+			Pointer: path + "/cpuLimit",
+			Message: fmt.Sprintf("cpuLimit %q can't override %q of organization resource policy", policy.CPULimit, organization.CPULimit),
+		})
+	}
+	if organization.MemoryLimit != "" && policy.MemoryLimit != "" && policy.MemoryLimit != organization.MemoryLimit {
+		problems = append(problems, Problem{
+			Pointer: path + "/memoryLimit",
+			Message: fmt.Sprintf("memoryLimit %q can't override %q of organization resource policy", policy.MemoryLimit, organization.MemoryLimit),
+		})
+	}
+
+	ceilings := organization.MaxPod.Quantities()
+	quantities := policy.MaxPod.Quantities()
+	for _, name := range slices.Sorted(maps.Keys(quantities)) {
+		ceiling, err := resource.ParseQuantity(ceilings[name])
+		if err != nil {
+			continue
+		}
+		if quantity, err := resource.ParseQuantity(quantities[name]); err == nil && quantity.Cmp(ceiling) > 0 {
+			problems = append(problems, Problem{
+				Pointer: path + "/maxPod",
+				Message: fmt.Sprintf("maxPod %s %s exceeds organization resource policy maximum %s", name, quantity.String(), ceiling.String()),
+			})
+		}
+	}
+
+	return problems
+}
+
+// validateResourceLimits checks that limits don't contradict the resource policy and extended resources
+// have limits equal to requests
+func validateResourceLimits(policy *types.ResourcePolicy, resources *types.ResourceRequirements, path string) []Problem {
+	problems := []Problem{}
+	if resources == nil || resources.Limits == nil {
+		return problems
+	}
+
+	limits, requests := resources.Limits, resources.Requests
+	if requests == nil {
+		requests = &types.ResourceList{}
+	}
+
+	if policy.CPULimit == types.LimitNone && limits.CPU != "" {
+		problems = append(problems, Problem{Pointer: path + "/limits/cpu", Message: "cpu limit is forbidden by resource policy"})
+	}
+	if policy.MemoryLimit == types.LimitEqualRequest && limits.Memory != "" && requests.Memory != "" && !equalQuantities(limits.Memory, requests.Memory) {
+		problems = append(problems, Problem{
+			Pointer: path + "/limits/memory",
+			Message: fmt.Sprintf("memory limit %s must be equal to request %s by resource policy", limits.Memory, requests.Memory),
+		})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(limits.Extended)) {
+		request, ok := requests.Extended[name]
+		if ok && !equalQuantities(limits.Extended[name], request) {
+			problems = append(problems, Problem{
+				Pointer: path + "/limits/extended/" + escapePointer(name),
+				Message: fmt.Sprintf("%s limit %s must be equal to request %s", name, limits.Extended[name], request),
+			})
+		}
+	}
+
+	return problems
+}
+
+// validatePodResources checks that requests and limits of the pod don't exceed maximum of the resource policy
+func validatePodResources(policy *types.ResourcePolicy, pod apiv1.ResourceRequirements, path string) []Problem {
+	problems := []Problem{}
+	for _, message := range policy.ExceededResources(pod) {
+		problems = append(problems, Problem{Pointer: path, Message: message})
+	}
+
+	return problems
+}
+
+// equalQuantities checks if valid quantities are equal, e.g. "1Gi" and "1024Mi"
+func equalQuantities(a, b string) bool {
+	first, err := resource.ParseQuantity(a)
+	if err != nil {
+		return a == b
+	}
+	second, err := resource.ParseQuantity(b)
+	if err != nil {
+		return false
+	}
+
+	return first.Cmp(second) == 0
+}
+
+// escapePointer escapes object key for JSON pointer segment
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// pointer returns JSON pointer segment for array index
+func pointer(index int) string {
+	return "/" + strconv.Itoa(index)
+}
@@ -0,0 +1,824 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ealebed/spini/types"
+)
+
+// validConfiguration returns minimal configuration which passes all semantic rules
+func validConfiguration() *types.Configuration {
+	return &types.Configuration{
+		Application: "myapp",
+		Type:        "service",
+		Ports: []types.Port{
+			{Name: "http", ContainerPort: 8080},
+			{Name: "metrics", ContainerPort: 9113},
+		},
+		ChaosMonkey: &types.ChaosMonkey{Enabled: false},
+		Profiles: &[]*types.Profile{
+			{
+				ProfileName: "production",
+				Datacenters: &[]*types.Datacenter{
+					{
+						TierName: "gke1",
+						Resources: &types.ResourceRequirements{
+							Requests: &types.ResourceList{CPU: "500m", Memory: "512Mi"},
+						},
+						LivenessProbe: &types.Probe{Type: "http"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateSampleConfiguration(t *testing.T) {
+	raw, err := os.ReadFile("../../configuration.json")
+	if err != nil {
+		t.Fatalf("failed to read sample configuration: %v", err)
+	}
+
+	problems, err := Validate([]*types.ConfigurationFile{{Path: "configuration.json", Content: raw}}, nil)
+	if err != nil {
+		t.Fatalf("Validate returned error: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("Expected sample configuration to be valid, got %v", problems)
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []Problem
+	}{
+		{
+			name:     "empty list",
+			raw:      `[]`,
+			expected: []Problem{},
+		},
+		{
+			name:     "not a list",
+			raw:      `{}`,
+			expected: []Problem{{Pointer: "", Message: "Invalid type. Expected: array, given: object"}},
+		},
+		{
+			name: "missing required field and unknown field",
+			raw:  `[{"application": "myapp", "profiles": [], "typo": true}]`,
+			expected: []Problem{
+				{Pointer: "/0", Message: "type is required"},
+				{Pointer: "/0", Message: "Additional property typo is not allowed"},
+				{Pointer: "/0/profiles", Message: "Array must have at least 1 items"},
+			},
+		},
+		{
+			name: "invalid workload settings",
+			raw: `[{"application": "myapp", "type": "cronjob", "cronJob": {"concurrencyPolicy": "Never"}, "profiles": [{"profileName": "production", "datacenters": [
+				{"tierName": "gke1", "resources": {"requests": {"cpu": "1", "memory": "1Gi"}}, "livenessProbe": {"type": "http"}}]}]}]`,
+			expected: []Problem{
+				{Pointer: "/0/cronJob", Message: "schedule is required"},
+				{Pointer: "/0/cronJob/concurrencyPolicy", Message: `0.cronJob.concurrencyPolicy must be one of the following: "Allow", "Forbid", "Replace"`},
+			},
+		},
+		{
+			name: "invalid probe type",
+			raw: `[{"application": "myapp", "type": "service", "profiles": [{"profileName": "production", "datacenters": [
+				{"tierName": "gke1", "resources": {"requests": {"cpu": "1", "memory": "1Gi"}}, "livenessProbe": {"type": "udp"}}]}]}]`,
+			expected: []Problem{
+				{Pointer: "/0/profiles/0/datacenters/0/livenessProbe/type", Message: `0.profiles.0.datacenters.0.livenessProbe.type must be one of the following: "file", "http", "exec", "grpc", "tcp"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := ValidateSchema([]byte(tt.raw))
+			if err != nil {
+				t.Fatalf("ValidateSchema returned error: %v", err)
+			}
+			if len(problems) != len(tt.expected) {
+				t.Fatalf("Expected %d problems, got %d: %v", len(tt.expected), len(problems), problems)
+			}
+			for i := range tt.expected {
+				if problems[i] != tt.expected[i] {
+					t.Errorf("Expected problem %v, got %v", tt.expected[i], problems[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func([]*types.Configuration) []*types.Configuration
+		expected []string
+	}{
+		{
+			name:     "valid configuration",
+			modify:   func(c []*types.Configuration) []*types.Configuration { return c },
+			expected: []string{},
+		},
+		{
+			name: "duplicate application",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				return append(c, validConfiguration())
+			},
+			expected: []string{`/1/application: application "myapp" is already defined at /0`},
+		},
+		{
+			name: "rolling update without block",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Strategy = &types.DeployStrategy{Type: "RollingUpdate"}
+				return c
+			},
+			expected: []string{"/0/strategy/rollingUpdate: rollingUpdate is required for RollingUpdate strategy"},
+		},
+		{
+			name: "duplicate ports",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Ports = append(c[0].Ports, types.Port{Name: "http", ContainerPort: 9113})
+				return c
+			},
+			expected: []string{
+				`/0/ports/2/name: duplicate port name "http"`,
+				"/0/ports/2/containerPort: duplicate container port 9113",
+			},
+		},
+		{
+			name: "duplicate service port and same port of other protocol",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Ports = append(c[0].Ports,
+					types.Port{Name: "http-udp", ContainerPort: 8080, Protocol: "UDP"},
+					types.Port{Name: "admin", ContainerPort: 8081, ServicePort: 8080})
+				return c
+			},
+			expected: []string{"/0/ports/3/servicePort: duplicate service port 8080"},
+		},
+		{
+			name: "conflicting namespace settings",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Namespace = "team"
+				c[0].NamespaceSettings = &types.NamespaceSettings{
+					Labels:        map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+					ResourceQuota: &types.ResourceQuota{Requests: &types.ResourceList{CPU: "10"}},
+				}
+				other := validConfiguration()
+				other.Application = "other"
+				other.Namespace = "team"
+				other.NamespaceSettings = &types.NamespaceSettings{
+					Labels:        map[string]string{"pod-security.kubernetes.io/enforce": "baseline", "team": "core"},
+					ResourceQuota: &types.ResourceQuota{Requests: &types.ResourceList{CPU: "20"}},
+					LimitRange:    &types.LimitRange{Max: &types.ResourceList{Memory: "lots"}},
+				}
+				return append(c, other)
+			},
+			expected: []string{
+				`/1/namespaceSettings/limitRange/max/memory: invalid memory quantity "lots": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+				`/1/namespaceSettings/labels/pod-security.kubernetes.io~1enforce: label "pod-security.kubernetes.io/enforce" of namespace "team" is already set to "restricted" at /0`,
+				`/1/namespaceSettings/resourceQuota: resourceQuota of namespace "team" differs from /0`,
+			},
+		},
+		{
+			name: "namespace settings of default namespace",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].NamespaceSettings = &types.NamespaceSettings{Labels: map[string]string{"team": "core"}}
+				return c
+			},
+			expected: []string{"/0/namespaceSettings: namespaceSettings aren't supported for default namespace"},
+		},
+		{
+			name: "invalid network policy sources",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].NetworkPolicy = &types.NetworkPolicy{IngressFrom: []types.NetworkPolicySource{
+					{Namespace: "monitoring", Ports: []string{"metrics"}},
+					{CIDR: "10.0.0.0/33", Namespace: "ingress"},
+					{PodLabels: map[string]string{"app": "gateway"}, Ports: []string{"grpc"}},
+				}}
+				return c
+			},
+			expected: []string{
+				"/0/networkPolicy/ingressFrom/1/cidr: invalid CIDR address: 10.0.0.0/33",
+				"/0/networkPolicy/ingressFrom/1: cidr can't be combined with namespace or podLabels",
+				`/0/networkPolicy/ingressFrom/2/ports/0: port "grpc" isn't defined in ports`,
+			},
+		},
+		{
+			name: "monitoring without metrics port",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Type = "worker"
+				c[0].Monitoring = &types.Monitoring{Kind: types.MonitorKindServiceMonitor, Port: "prometheus"}
+				return c
+			},
+			expected: []string{
+				`/0/monitoring/port: metrics port "prometheus" isn't defined in ports`,
+				"/0/monitoring/kind: serviceMonitor requires service application, use podMonitor",
+			},
+		},
+		{
+			name: "oci registry without host",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Registry = &types.Registry{Type: types.RegistryTypeOCI}
+				return c
+			},
+			expected: []string{"/0/registry/host: host is required for oci registry"},
+		},
+		{
+			name: "invalid tag policy patterns",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].TagPolicy = &types.TagPolicy{Filter: "(", Exclude: []string{"-rc", "["}}
+				return c
+			},
+			expected: []string{
+				"/0/tagPolicy/filter: invalid regular expression: error parsing regexp: missing closing ): `(`",
+				"/0/tagPolicy/exclude/1: invalid regular expression: error parsing regexp: missing closing ]: `[`",
+			},
+		},
+		{
+			name: "autoscaling replicas and metrics",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				(*(*c[0].Profiles)[0].Datacenters)[0].Replicas = 3
+				(*(*c[0].Profiles)[0].Datacenters)[0].Autoscaling = &types.Autoscaling{
+					MaxReplicas: 2,
+					Metrics: []types.AutoscalingMetric{
+						{Type: "pods", Name: "rps", TargetAverageValue: "100"},
+						{Type: "pods", Name: "rps", TargetValue: "100"},
+						{Type: "external", Name: "queue"},
+						{Type: "external", Name: "queue", TargetValue: "lots"},
+					},
+				}
+				return c
+			},
+			expected: []string{
+				"/0/profiles/0/datacenters/0/autoscaling/maxReplicas: maxReplicas 2 is less than minReplicas 3",
+				"/0/profiles/0/datacenters/0/autoscaling/metrics/1/targetValue: pods metric supports only targetAverageValue",
+				"/0/profiles/0/datacenters/0/autoscaling/metrics/2: targetAverageValue or targetValue is required",
+				`/0/profiles/0/datacenters/0/autoscaling/metrics/3/targetValue: invalid quantity "lots": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+			},
+		},
+		{
+			name: "ingress of worker application",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Type = "worker"
+				c[0].Ingress = &types.Ingress{Hosts: []string{"api.example.com"}, Paths: []types.IngressPath{{Path: "/", Port: "grpc"}}}
+				return c
+			},
+			expected: []string{
+				"/0/ingress: ingress is supported only by service applications",
+				`/0/ingress/paths/0/port: port "grpc" isn't defined in ports`,
+			},
+		},
+		{
+			name: "http route without gateway",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Ingress = &types.Ingress{Kind: types.IngressKindHTTPRoute, Hosts: []string{"api.example.com"}, TLSSecret: "api-tls"}
+				return c
+			},
+			expected: []string{
+				"/0/ingress/gateway: gateway is required for httpRoute",
+				"/0/ingress/tlsSecret: tlsSecret is supported only by ingress, configure TLS on gateway listener",
+			},
+		},
+		{
+			name: "disruption budget with both values",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				(*(*c[0].Profiles)[0].Datacenters)[0].DisruptionBudget = &types.DisruptionBudget{MinAvailable: "1", MaxUnavailable: "1"}
+				return c
+			},
+			expected: []string{"/0/profiles/0/datacenters/0/disruptionBudget: only one of minAvailable and maxUnavailable is allowed"},
+		},
+		{
+			name: "disruption budget with zero maxUnavailable",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				(*(*c[0].Profiles)[0].Datacenters)[0].DisruptionBudget = &types.DisruptionBudget{MaxUnavailable: "0%"}
+				return c
+			},
+			expected: []string{"/0/profiles/0/datacenters/0/disruptionBudget/maxUnavailable: maxUnavailable 0 makes deployment un-drainable"},
+		},
+		{
+			name: "disruption budget with minAvailable of all replicas",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				(*(*c[0].Profiles)[0].Datacenters)[0].Replicas = 3
+				(*(*c[0].Profiles)[0].Datacenters)[0].DisruptionBudget = &types.DisruptionBudget{MinAvailable: "70%"}
+				return c
+			},
+			expected: []string{"/0/profiles/0/datacenters/0/disruptionBudget/minAvailable: minAvailable 70% of 3 replicas makes deployment un-drainable"},
+		},
+		{
+			name: "disruption budget with minAvailable below replicas",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				(*(*c[0].Profiles)[0].Datacenters)[0].Replicas = 3
+				(*(*c[0].Profiles)[0].Datacenters)[0].DisruptionBudget = &types.DisruptionBudget{MinAvailable: "2"}
+				return c
+			},
+			expected: []string{},
+		},
+		{
+			name: "duplicate profile names",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				profiles := *c[0].Profiles
+				duplicate := *validConfiguration().Profiles
+				profiles = append(profiles, duplicate[0])
+				c[0].Profiles = &profiles
+				return c
+			},
+			expected: []string{
+				`/0/profiles/1/profileName: duplicate profile name "production"`,
+			},
+		},
+		{
+			name: "arbitrary profile names are allowed",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				(*c[0].Profiles)[0].ProfileName = "staging"
+				return c
+			},
+			expected: []string{},
+		},
+		{
+			name: "promotion from existing profile and tier",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				staging := *validConfiguration().Profiles
+				staging[0].ProfileName = "staging"
+				profiles := append(*c[0].Profiles, staging[0])
+				profiles[0].PromotesFrom = &types.Promotion{ProfileName: "staging", TierName: "gke1"}
+				c[0].Profiles = &profiles
+				return c
+			},
+			expected: []string{},
+		},
+		{
+			name: "promotion from unknown profile, tier and itself",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				staging := *validConfiguration().Profiles
+				staging[0].ProfileName = "staging"
+				staging[0].PromotesFrom = &types.Promotion{ProfileName: "dev"}
+				qa := *validConfiguration().Profiles
+				qa[0].ProfileName = "qa"
+				qa[0].PromotesFrom = &types.Promotion{ProfileName: "qa"}
+				profiles := append(*c[0].Profiles, staging[0], qa[0])
+				profiles[0].PromotesFrom = &types.Promotion{ProfileName: "staging", TierName: "gke2"}
+				c[0].Profiles = &profiles
+				return c
+			},
+			expected: []string{
+				`/0/profiles/0/promotesFrom/tierName: unknown tier "gke2" in source profile "staging"`,
+				`/0/profiles/1/promotesFrom/profileName: unknown source profile "dev"`,
+				`/0/profiles/2/promotesFrom/profileName: profile "qa" can't be promoted from itself`,
+			},
+		},
+		{
+			name: "promotion cycle",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				staging := *validConfiguration().Profiles
+				staging[0].ProfileName = "staging"
+				staging[0].PromotesFrom = &types.Promotion{ProfileName: "production"}
+				profiles := append(*c[0].Profiles, staging[0])
+				profiles[0].PromotesFrom = &types.Promotion{ProfileName: "staging"}
+				c[0].Profiles = &profiles
+				return c
+			},
+			expected: []string{
+				"/0/profiles/0/promotesFrom: promotion cycle production -> staging -> production",
+				"/0/profiles/1/promotesFrom: promotion cycle staging -> production -> staging",
+			},
+		},
+		{
+			name: "duplicate tier, missing probe and chaosMonkey",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].ChaosMonkey = nil
+				datacenters := (*c[0].Profiles)[0].Datacenters
+				tier := *(*datacenters)[0]
+				tier.LivenessProbe = nil
+				*datacenters = append(*datacenters, &tier)
+				return c
+			},
+			expected: []string{
+				"/0/profiles/0/datacenters/0/chaosMonkey: chaosMonkey is required on datacenter, defaults or application level",
+				`/0/profiles/0/datacenters/1/tierName: duplicate tier name "gke1" in profile "production"`,
+				"/0/profiles/0/datacenters/1/livenessProbe: livenessProbe is required",
+				"/0/profiles/0/datacenters/1/chaosMonkey: chaosMonkey is required on datacenter, defaults or application level",
+			},
+		},
+		{
+			name: "probe, resources and chaosMonkey inherited from defaults",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				profile := (*c[0].Profiles)[0]
+				tier := (*profile.Datacenters)[0]
+				c[0].Defaults = &types.Datacenter{LivenessProbe: tier.LivenessProbe, ChaosMonkey: c[0].ChaosMonkey}
+				profile.Defaults = &types.Datacenter{Resources: tier.Resources}
+				c[0].ChaosMonkey = nil
+				tier.LivenessProbe = nil
+				tier.Resources = nil
+				return c
+			},
+			expected: []string{},
+		},
+		{
+			name: "tier name in defaults and missing requests",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				profile := (*c[0].Profiles)[0]
+				c[0].Defaults = &types.Datacenter{TierName: "gke1"}
+				profile.Defaults = &types.Datacenter{TierName: "gke2"}
+				(*profile.Datacenters)[0].Resources.Requests = nil
+				return c
+			},
+			expected: []string{
+				"/0/defaults/tierName: tierName is not allowed in defaults",
+				"/0/profiles/0/defaults/tierName: tierName is not allowed in defaults",
+				"/0/profiles/0/datacenters/0/resources/requests: resource requests are required",
+			},
+		},
+		{
+			name: "invalid resource quantities",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.Resources.Limits = &types.ResourceList{CPU: "one", Memory: "1Gi"}
+				tier.Resources.Requests.Memory = ""
+				return c
+			},
+			expected: []string{
+				`/0/profiles/0/datacenters/0/resources/requests/memory: invalid memory quantity "": quantities must match the regular expression ` +
+					`'^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+				`/0/profiles/0/datacenters/0/resources/limits/cpu: invalid cpu quantity "one": quantities must match the regular expression ` +
+					`'^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+			},
+		},
+		{
+			name: "cronjob with job settings",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Type = types.WorkloadTypeCronJob
+				c[0].CronJob = &types.CronJobSettings{Schedule: "@daily", ConcurrencyPolicy: "Replace"}
+				c[0].Job = &types.JobSettings{ActiveDeadlineSeconds: 600}
+				return c
+			},
+			expected: []string{},
+		},
+		{
+			name: "cronjob without schedule, autoscaled and with chaosMonkey",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Type = types.WorkloadTypeCronJob
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.Autoscaling = &types.Autoscaling{MinReplicas: 2, MaxReplicas: 4}
+				tier.DisruptionBudget = &types.DisruptionBudget{MaxUnavailable: "1"}
+				tier.ChaosMonkey = &types.ChaosMonkey{Enabled: true}
+				return c
+			},
+			expected: []string{
+				"/0/cronJob/schedule: schedule is required for cronjob applications",
+				"/0/profiles/0/datacenters/0/autoscaling: autoscaling isn't supported by cronjob applications",
+				"/0/profiles/0/datacenters/0/disruptionBudget: disruptionBudget isn't supported by cronjob applications",
+				"/0/profiles/0/datacenters/0/chaosMonkey/enabled: chaosMonkey isn't supported by cronjob applications",
+			},
+		},
+		{
+			name: "invalid cronjob schedule",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Type = types.WorkloadTypeCronJob
+				c[0].CronJob = &types.CronJobSettings{Schedule: "*/5 * * *"}
+				return c
+			},
+			expected: []string{
+				`/0/cronJob/schedule: invalid schedule "*/5 * * *", expected 5 cron fields or one of @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly`,
+			},
+		},
+		{
+			name: "workload settings of another type",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].CronJob = &types.CronJobSettings{Schedule: "@hourly"}
+				c[0].Job = &types.JobSettings{}
+				c[0].StatefulSet = &types.StatefulSetSettings{}
+				return c
+			},
+			expected: []string{
+				"/0/cronJob: cronJob settings are supported only by cronjob applications",
+				"/0/job: job settings are supported only by job and cronjob applications",
+				"/0/statefulSet: statefulSet settings are supported only by statefulset applications",
+			},
+		},
+		{
+			name: "statefulset volume claims",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Type = types.WorkloadTypeStatefulSet
+				c[0].StatefulSet = &types.StatefulSetSettings{VolumeClaimTemplates: []types.VolumeClaimTemplate{
+					{Name: "data", MountPath: "/data", Size: "10Gi"},
+					{Name: "data", MountPath: "/data", Size: "ten"},
+				}}
+				return c
+			},
+			expected: []string{
+				`/0/statefulSet/volumeClaimTemplates/1/name: duplicate volume claim name "data"`,
+				`/0/statefulSet/volumeClaimTemplates/1/mountPath: duplicate mount path "/data"`,
+				`/0/statefulSet/volumeClaimTemplates/1/size: invalid size quantity "ten": quantities must match the regular expression ` +
+					`'^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+			},
+		},
+		{
+			name: "sidecars and init containers",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Sidecars = []types.Container{
+					{Name: "proxy", Image: "docker.io/envoyproxy/envoy:v1.30.1"},
+					{Name: "proxy", Image: "registry:5000/agent", RestartPolicy: types.ContainerRestartPolicyAlways},
+				}
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.InitContainers = []types.Container{
+					{Name: "agent", Image: "agent@sha256:abc", RestartPolicy: types.ContainerRestartPolicyAlways},
+					{Name: "migrate", Image: "migrate:1.0", Resources: &types.ResourceRequirements{Requests: &types.ResourceList{CPU: "lots"}}},
+				}
+				return c
+			},
+			expected: []string{
+				`/0/sidecars/1/name: duplicate container name "proxy"`,
+				`/0/sidecars/1/image: image "registry:5000/agent" must have tag or digest`,
+				"/0/sidecars/1/restartPolicy: restartPolicy is supported only by initContainers, sidecars always run along the application",
+				`/0/profiles/0/datacenters/0/initContainers/1/resources/requests/cpu: invalid cpu quantity "lots": quantities must match the regular expression ` +
+					`'^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+			},
+		},
+		{
+			name: "sidecars of job",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].Type = types.WorkloadTypeJob
+				c[0].Sidecars = []types.Container{{Name: "proxy", Image: "envoy:1.0"}}
+				return c
+			},
+			expected: []string{
+				"/0/profiles/0/datacenters/0/sidecars: sidecars never complete and block job completion, use initContainers with restartPolicy Always",
+			},
+		},
+		{
+			name: "volumes",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].DependsOn = []types.DependsOn{{Name: "maxmind"}}
+				c[0].Volumes = []types.Volume{
+					{Name: "cache", MountPath: "/cache", EmptyDir: &types.EmptyDirVolume{SizeLimit: "big"}},
+					{Name: "cache", MountPath: "/cache"},
+					{Name: "tls", Secret: &types.SecretVolume{SecretName: "tls"}, CSI: &types.CSIVolume{Driver: "csi"}},
+					{Name: "token", Projected: &types.ProjectedVolume{Sources: []types.ProjectedSource{{}}}},
+				}
+				c[0].Sidecars = []types.Container{{Name: "proxy", Image: "envoy:1.0", VolumeMounts: []types.VolumeMount{
+					{Name: "tls", MountPath: "/tls"},
+					{Name: "geoip-files", MountPath: "/geoip"},
+					{Name: "certs", MountPath: "/certs"},
+				}}}
+				return c
+			},
+			expected: []string{
+				`/0/volumes/0/emptyDir/sizeLimit: invalid sizeLimit quantity "big": quantities must match the regular expression ` +
+					`'^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+				`/0/volumes/1/name: duplicate volume name "cache"`,
+				`/0/volumes/1/mountPath: duplicate mount path "/cache"`,
+				"/0/volumes/1: exactly one of configMap, secret, emptyDir, persistentVolumeClaim, projected or csi must be set",
+				"/0/volumes/2: exactly one of configMap, secret, emptyDir, persistentVolumeClaim, projected or csi must be set",
+				"/0/volumes/3/projected/sources/0: exactly one of configMap, secret or serviceAccountToken must be set",
+				`/0/sidecars/0/volumeMounts/2/name: volume "certs" isn't defined in volumes`,
+			},
+		},
+		{
+			name: "env value sources",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].EnvFromSources = []types.EnvFromSource{{Secret: "db", ConfigMap: "db"}, {Prefix: "DB_"}}
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.Env = &[]types.EnvVar{
+					{Name: "DB_PASSWORD", ValueFrom: &types.EnvVarSource{SecretKeyRef: &types.KeySelector{Name: "db", Key: "password"}}},
+					{Name: "POD_LABEL", ValueFrom: &types.EnvVarSource{FieldRef: &types.FieldSelector{FieldPath: "metadata.labels['app']"}}},
+					{Name: "TOKEN", Value: "secret", ValueFrom: &types.EnvVarSource{
+						SecretKeyRef:    &types.KeySelector{Name: "api", Key: "token"},
+						ConfigMapKeyRef: &types.KeySelector{Name: "api", Key: "token"},
+					}},
+					{Name: "NODE", ValueFrom: &types.EnvVarSource{FieldRef: &types.FieldSelector{FieldPath: "spec.hostname"}}},
+					{Name: "MEMORY", ValueFrom: &types.EnvVarSource{ResourceFieldRef: &types.ResourceFieldSelector{Resource: "memory", Divisor: "mega"}}},
+				}
+				tier.EnvFromSources = []types.EnvFromSource{{ConfigMap: "features"}}
+				return c
+			},
+			expected: []string{
+				"/0/envFromSources/0: exactly one of secret or configMap must be set",
+				"/0/envFromSources/1: exactly one of secret or configMap must be set",
+				`/0/profiles/0/datacenters/0/env/2: variable "TOKEN" has both value and valueFrom`,
+				"/0/profiles/0/datacenters/0/env/2/valueFrom: exactly one of secretKeyRef, configMapKeyRef, fieldRef or resourceFieldRef must be set",
+				`/0/profiles/0/datacenters/0/env/3/valueFrom/fieldRef/fieldPath: unsupported field path "spec.hostname", e.g. status.podIP or metadata.labels['app'] is expected`,
+				`/0/profiles/0/datacenters/0/env/4/valueFrom/resourceFieldRef/resource: unsupported resource "memory", expected one of ` +
+					"limits.cpu, limits.memory, limits.ephemeral-storage, requests.cpu, requests.memory, requests.ephemeral-storage",
+				`/0/profiles/0/datacenters/0/env/4/valueFrom/resourceFieldRef/divisor: invalid divisor quantity "mega": quantities must match the regular expression ` +
+					`'^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+			},
+		},
+		{
+			name: "security context",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				nonRoot, root, group := true, int64(0), int64(2000)
+				c[0].SecurityContext = &types.SecurityContext{
+					RunAsNonRoot: &nonRoot,
+					RunAsUser:    &root,
+					Capabilities: &types.Capabilities{Add: []string{"NET_BIND_SERVICE"}, Drop: []string{"CAP_ALL"}},
+				}
+				c[0].Sidecars = []types.Container{{Name: "proxy", Image: "envoy:1.0", SecurityContext: &types.SecurityContext{FSGroup: &group}}}
+				return c
+			},
+			expected: []string{
+				"/0/sidecars/0/securityContext/fsGroup: fsGroup is applied to the whole pod, set it on application level",
+				"/0/securityContext/runAsUser: runAsUser 0 contradicts runAsNonRoot",
+				`/0/securityContext/capabilities/drop/0: capability "CAP_ALL" must be named without CAP_ prefix`,
+			},
+		},
+		{
+			name: "scheduling",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				seconds := int64(60)
+				c[0].Defaults = &types.Datacenter{Scheduling: &types.Scheduling{
+					TopologySpreadConstraints: []types.TopologySpreadConstraint{{}, {TopologyKey: "topology.kubernetes.io/zone"}},
+				}}
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.Scheduling = &types.Scheduling{Tolerations: []types.Toleration{
+					{Key: "spot", Operator: "Exists", Value: "true", Effect: "NoSchedule", TolerationSeconds: &seconds},
+					{Value: "any"},
+				}}
+				return c
+			},
+			expected: []string{
+				`/0/profiles/0/datacenters/0/scheduling/topologySpreadConstraints/1/topologyKey: duplicate topology key "topology.kubernetes.io/zone"`,
+				"/0/profiles/0/datacenters/0/scheduling/tolerations/0/value: value must be empty for Exists operator",
+				"/0/profiles/0/datacenters/0/scheduling/tolerations/0/tolerationSeconds: tolerationSeconds is supported only by NoExecute effect",
+				"/0/profiles/0/datacenters/0/scheduling/tolerations/1/operator: toleration without key must use Exists operator",
+			},
+		},
+		{
+			name: "probe settings of other type",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.LivenessProbe = &types.Probe{Type: "exec", SuccessThreshold: 2}
+				tier.ReadinessProbe = &types.Probe{Type: "grpc", Scheme: "HTTPS", Headers: []types.HTTPHeader{{Name: "Host", Value: "myapp"}}, SuccessThreshold: 2}
+				tier.StartupProbe = &types.Probe{Type: "tcp", Command: []string{"true"}, Service: "health"}
+				return c
+			},
+			expected: []string{
+				"/0/profiles/0/datacenters/0/livenessProbe/command: command is required for exec probe",
+				"/0/profiles/0/datacenters/0/livenessProbe/successThreshold: successThreshold must be 1 for liveness and startup probes",
+				"/0/profiles/0/datacenters/0/readinessProbe/scheme: scheme is supported only by http probe",
+				"/0/profiles/0/datacenters/0/readinessProbe/headers: headers are supported only by http probe",
+				"/0/profiles/0/datacenters/0/startupProbe/command: command is supported only by exec probe",
+				"/0/profiles/0/datacenters/0/startupProbe/service: service is supported only by grpc probe",
+			},
+		},
+		{
+			name: "extended resources",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.Resources.Requests.EphemeralStorage = "lots"
+				tier.Resources.Requests.Extended = map[string]string{"nvidia.com/gpu": "500m", "gpu": "1", "example.com/fpga": "1"}
+				tier.Resources.Limits = &types.ResourceList{CPU: "1", Memory: "1Gi", Extended: map[string]string{"example.com/fpga": "2"}}
+				return c
+			},
+			expected: []string{
+				`/0/profiles/0/datacenters/0/resources/requests/ephemeralStorage: invalid ephemeral-storage quantity "lots": ` +
+					`quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+				`/0/profiles/0/datacenters/0/resources/requests/extended/gpu: extended resource "gpu" must be fully-qualified name ` +
+					"outside of kubernetes.io domain, e.g. nvidia.com/gpu",
+				`/0/profiles/0/datacenters/0/resources/requests/extended/nvidia.com~1gpu: nvidia.com/gpu quantity "500m" must be a whole number`,
+				"/0/profiles/0/datacenters/0/resources/limits/extended/example.com~1fpga: example.com/fpga limit 2 must be equal to request 1",
+			},
+		},
+		{
+			name: "resource policy",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].ResourcePolicy = &types.ResourcePolicy{
+					CPULimit:    types.LimitNone,
+					MemoryLimit: types.LimitEqualRequest,
+					MaxPod:      &types.ResourceList{CPU: "1", Memory: "1Gi"},
+				}
+				c[0].Sidecars = []types.Container{{
+					Name:      "proxy",
+					Image:     "envoy:1.0",
+					Resources: &types.ResourceRequirements{Requests: &types.ResourceList{CPU: "600m", Memory: "128Mi"}},
+				}}
+				tier := (*(*c[0].Profiles)[0].Datacenters)[0]
+				tier.Resources.Limits = &types.ResourceList{CPU: "1", Memory: "1Gi"}
+				return c
+			},
+			expected: []string{
+				"/0/profiles/0/datacenters/0/resources/limits/cpu: cpu limit is forbidden by resource policy",
+				"/0/profiles/0/datacenters/0/resources/limits/memory: memory limit 1Gi must be equal to request 512Mi by resource policy",
+				"/0/profiles/0/datacenters/0/resources: pod cpu requests 1100m exceed resource policy maximum 1",
+			},
+		},
+		{
+			name: "resource policy loosening organization policy",
+			modify: func(c []*types.Configuration) []*types.Configuration {
+				c[0].ResourcePolicy = &types.ResourcePolicy{CPULimit: types.LimitFromRequest, MaxPod: &types.ResourceList{CPU: "8"}}
+				c[0].OrganizationResourcePolicy = &types.ResourcePolicy{CPULimit: types.LimitNone, MaxPod: &types.ResourceList{CPU: "4"}}
+				return c
+			},
+			expected: []string{
+				`/0/resourcePolicy/cpuLimit: cpuLimit "fromRequest" can't override "none" of organization resource policy`,
+				"/0/resourcePolicy/maxPod: maxPod cpu 8 exceeds organization resource policy maximum 4",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := ValidateConfiguration(tt.modify([]*types.Configuration{validConfiguration()}))
+			if len(problems) != len(tt.expected) {
+				t.Fatalf("Expected %d problems, got %d: %v", len(tt.expected), len(problems), problems)
+			}
+			for i := range tt.expected {
+				if problems[i].String() != tt.expected[i] {
+					t.Errorf("Expected problem %q, got %q", tt.expected[i], problems[i].String())
+				}
+			}
+		})
+	}
+}
+
+func TestProblemString(t *testing.T) {
+	if result := (Problem{Message: "root problem"}).String(); result != "/: root problem" {
+		t.Errorf("Expected '/: root problem', got %q", result)
+	}
+	if result := (Problem{Pointer: "/0/type", Message: "type problem"}).String(); result != "/0/type: type problem" {
+		t.Errorf("Expected '/0/type: type problem', got %q", result)
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+	single := []byte(`{"application": "myapp", "type": "service", "chaosMonkey": {"enabled": false}, "profiles": [
+		{"profileName": "production", "datacenters": [
+			{"tierName": "gke1", "resources": {"requests": {"cpu": "1", "memory": "1Gi"}}, "livenessProbe": {"type": "http"}}]}]}`)
+	valid := append(append([]byte("["), single...), ']')
+
+	tests := []struct {
+		name     string
+		files    []*types.ConfigurationFile
+		expected []string
+	}{
+		{
+			name:     "single valid file",
+			files:    []*types.ConfigurationFile{{Path: "configuration.json", Content: valid}},
+			expected: []string{},
+		},
+		{
+			name: "schema problem in single application file",
+			files: []*types.ConfigurationFile{
+				{Path: "apps/myapp.yaml", Content: []byte(`[{"application": "myapp", "type": "service"}]`), Wrapped: true},
+			},
+			expected: []string{"apps/myapp.yaml#/: profiles is required"},
+		},
+		{
+			name: "schema and semantic problems reported together",
+			files: []*types.ConfigurationFile{
+				{Path: "configuration.json", Content: []byte(`[{"application": 1}, {"application": "myapp", "type": "service",
+					"ports": [{"name": "http", "containerPort": 8080}, {"name": "http", "containerPort": 8081}]}]`)},
+			},
+			expected: []string{
+				"configuration.json#/0: type is required",
+				"configuration.json#/0: profiles is required",
+				"configuration.json#/0/application: Invalid type. Expected: string, given: integer",
+				"configuration.json#/1: profiles is required",
+				`configuration.json#/1/ports/1/name: duplicate port name "http"`,
+			},
+		},
+		{
+			name: "application defined in two files",
+			files: []*types.ConfigurationFile{
+				{Path: "apps/a.json", Content: valid},
+				{Path: "apps/b.yaml", Content: valid, Wrapped: true},
+			},
+			expected: []string{`apps/b.yaml#/application: application "myapp" is already defined in apps/a.json`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := Validate(tt.files, nil)
+			if err != nil {
+				t.Fatalf("Validate returned error: %v", err)
+			}
+			if len(problems) != len(tt.expected) {
+				t.Fatalf("Expected %d problems, got %d: %v", len(tt.expected), len(problems), problems)
+			}
+			for i := range tt.expected {
+				if problems[i].String() != tt.expected[i] {
+					t.Errorf("Expected problem %q, got %q", tt.expected[i], problems[i].String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "strings"
+
+const (
+	// ContainerRestartPolicyAlways turns init container into native sidecar running along the application container
+	ContainerRestartPolicyAlways = "Always"
+
+	// maxmindContainerName is the name of init container copying GeoIP databases for applications depending on maxmind
+	maxmindContainerName = "data-container"
+)
+
+// Container represents additional container of the application pod, i.e. sidecar or init container
+type Container struct {
+	Name string `json:"name"`
+	// Image is the image in the application registry with tag, e.g. "envoy:v1.30.1",
+	// or full image reference when it contains "/", e.g. "docker.io/envoyproxy/envoy:v1.30.1"
+	Image   string   `json:"image"`
+	Command []string `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	Env     []EnvVar `json:"env,omitempty"`
+	Ports   []Port   `json:"ports,omitempty"`
+	// Resources of the container, limits are equal to requests when not set
+	Resources    *ResourceRequirements `json:"resources,omitempty"`
+	VolumeMounts []VolumeMount         `json:"volumeMounts,omitempty"`
+	// RestartPolicy "Always" makes init container a native sidecar, which is started before
+	// the application container and doesn't block completion of jobs
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+	// SecurityContext overrides security settings of the application for the container
+	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
+}
+
+// VolumeMount represents mount of pod volume into container
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	SubPath   string `json:"subPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// ImageName returns full image reference of the container, images without "/" are pulled from the registry
+func (c *Container) ImageName(registry *Registry) string {
+	if strings.Contains(c.Image, "/") {
+		return c.Image
+	}
+
+	return registry.ImageName(c.Image)
+}
+
+// PodSidecars returns sidecar containers of the application pod in the datacenter,
+// sidecars of the datacenter override application sidecars with the same name
+func (c *Configuration) PodSidecars(tier *Datacenter) []Container {
+	return mergeContainers(c.Sidecars, tier.Sidecars)
+}
+
+// PodInitContainers returns init containers of the application pod in the datacenter,
+// maxmind dependency adds container copying GeoIP databases into volume shared with the application container
+func (c *Configuration) PodInitContainers(tier *Datacenter) []Container {
+	containers := []Container{}
+	if c.HasDependency("maxmind") {
+		containers = append(containers, Container{
+			Name:    maxmindContainerName,
+			Image:   MaxmindImage,
+			Command: []string{"cp", "-a", "/usr/share/GeoIP/.", "/tmp"},
+			VolumeMounts: []VolumeMount{
+				{
+					Name:      volumeNameGeoIPFiles,
+					MountPath: "/tmp",
+				},
+			},
+		})
+	}
+
+	return append(containers, mergeContainers(c.InitContainers, tier.InitContainers)...)
+}
@@ -0,0 +1,75 @@
+package types
+
+import "testing"
+
+func TestContainerImageName(t *testing.T) {
+	registry := DefaultRegistry("myorg")
+
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "agent:1.0", expected: "index.docker.io/myorg/agent:1.0"},
+		{image: "docker.io/envoyproxy/envoy:v1.30.1", expected: "docker.io/envoyproxy/envoy:v1.30.1"},
+		{image: MaxmindImage, expected: "index.docker.io/myorg/" + MaxmindImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			container := &Container{Image: tt.image}
+			if result := container.ImageName(registry); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPodContainers(t *testing.T) {
+	config := &Configuration{
+		Application: "myapp",
+		Sidecars: []Container{
+			{Name: "proxy", Image: "envoy:1.0"},
+			{Name: "agent", Image: "agent:1.0"},
+		},
+		InitContainers: []Container{{Name: "migrate", Image: "migrate:1.0"}},
+	}
+	tier := &Datacenter{Sidecars: []Container{{Name: "agent", Image: "agent:2.0"}, {Name: "cache", Image: "redis:7"}}}
+
+	sidecars := config.PodSidecars(tier)
+	if len(sidecars) != 3 || sidecars[1].Image != "agent:2.0" || sidecars[2].Name != "cache" {
+		t.Errorf("Expected datacenter sidecars to override application sidecars by name, got %+v", sidecars)
+	}
+
+	initContainers := config.PodInitContainers(tier)
+	if len(initContainers) != 1 || initContainers[0].Name != "migrate" {
+		t.Errorf("Expected application init container, got %+v", initContainers)
+	}
+
+	config.DependsOn = []DependsOn{{Name: "maxmind"}}
+	initContainers = config.PodInitContainers(tier)
+	if len(initContainers) != 2 || initContainers[0].Name != maxmindContainerName || initContainers[0].Image != MaxmindImage {
+		t.Fatalf("Expected maxmind init container first, got %+v", initContainers)
+	}
+	if mounts := initContainers[0].VolumeMounts; len(mounts) != 1 || mounts[0].Name != volumeNameGeoIPFiles {
+		t.Errorf("Expected GeoIP volume mount, got %+v", mounts)
+	}
+
+	if result := config.PodInitContainers(&Datacenter{}); len(result) != 2 {
+		t.Errorf("Expected init containers without datacenter overrides, got %+v", result)
+	}
+}
+
+func TestMergeContainers(t *testing.T) {
+	if result := mergeContainers(nil, nil); result != nil {
+		t.Errorf("Expected nil containers, got %v", result)
+	}
+
+	base := []Container{{Name: "proxy", Image: "envoy:1.0"}}
+	result := mergeContainers(base, []Container{{Name: "proxy", Image: "envoy:2.0"}})
+	if len(result) != 1 || result[0].Image != "envoy:2.0" {
+		t.Errorf("Expected override container, got %+v", result)
+	}
+	if base[0].Image != "envoy:1.0" {
+		t.Errorf("Expected base containers to stay unchanged, got %+v", base)
+	}
+}
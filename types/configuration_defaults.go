@@ -0,0 +1,278 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// ResolveDefaults deep-merges application level defaults, profile level defaults and datacenter settings
+// (in this order, later wins) into every datacenter of the application, so generators receive fully resolved tiers.
+//
+// Merge semantics:
+//   - scalars (replicas, nodePool, podPriority, version, ...) are overridden when set to non-zero value;
+//   - resources are merged field by field (cpu, memory, ephemeralStorage and extended resources by name);
+//   - probes, chaosMonkey, autoscaling and disruptionBudget are replaced as a whole object;
+//   - env is merged by variable name, overridden variables keep their position, new ones are appended;
+//   - envFrom is a union of all levels without duplicates;
+//   - envFromSources are merged by secret or configmap name, overridden sources keep their position;
+//   - sidecars and initContainers are merged by container name;
+//   - scheduling is merged field by field, topologySpreadConstraints and tolerations are replaced as a whole list;
+//   - command is replaced as a whole list.
+//
+// Application level chaosMonkey is used when it isn't set on any datacenter level.
+// ResolveDefaults is idempotent.
+func (c *Configuration) ResolveDefaults() {
+	if c.Profiles == nil {
+		return
+	}
+
+	for _, profile := range *c.Profiles {
+		if profile.Datacenters == nil {
+			continue
+		}
+
+		defaults := mergeDatacenter(c.Defaults, profile.Defaults)
+		for i, tier := range *profile.Datacenters {
+			resolved := mergeDatacenter(defaults, tier)
+			if resolved.ChaosMonkey == nil && c.ChaosMonkey != nil {
+				chaosMonkey := *c.ChaosMonkey
+				resolved.ChaosMonkey = &chaosMonkey
+			}
+			(*profile.Datacenters)[i] = resolved
+		}
+	}
+}
+
+// mergeDatacenter returns new datacenter with override settings merged on top of base ones.
+// Arguments are never modified and may be nil.
+func mergeDatacenter(base, override *Datacenter) *Datacenter {
+	if base == nil {
+		base = &Datacenter{}
+	}
+	if override == nil {
+		override = &Datacenter{}
+	}
+
+	return &Datacenter{
+		TierName:         mergeString(base.TierName, override.TierName),
+		Replicas:         mergeInt32(base.Replicas, override.Replicas),
+		NodePool:         mergeString(base.NodePool, override.NodePool),
+		Env:              mergeEnv(base.Env, override.Env),
+		EnvFrom:          mergeUnion(base.EnvFrom, override.EnvFrom),
+		EnvFromSources:   mergeEnvFromSources(base.EnvFromSources, override.EnvFromSources),
+		Command:          mergeList(base.Command, override.Command),
+		Resources:        mergeResources(base.Resources, override.Resources),
+		ProgressDeadline: mergeInt32(base.ProgressDeadline, override.ProgressDeadline),
+		LivenessProbe:    mergeProbe(base.LivenessProbe, override.LivenessProbe),
+		ReadinessProbe:   mergeProbe(base.ReadinessProbe, override.ReadinessProbe),
+		StartupProbe:     mergeProbe(base.StartupProbe, override.StartupProbe),
+		PodPriority:      mergeString(base.PodPriority, override.PodPriority),
+		ChaosMonkey:      mergeChaosMonkey(base.ChaosMonkey, override.ChaosMonkey),
+		Version:          mergeString(base.Version, override.Version),
+		Autoscaling:      mergeAutoscaling(base.Autoscaling, override.Autoscaling),
+		DisruptionBudget: mergeDisruptionBudget(base.DisruptionBudget, override.DisruptionBudget),
+		Sidecars:         mergeContainers(base.Sidecars, override.Sidecars),
+		InitContainers:   mergeContainers(base.InitContainers, override.InitContainers),
+		Scheduling:       mergeScheduling(base.Scheduling, override.Scheduling),
+	}
+}
+
+func mergeString(base, override string) string {
+	if override != "" {
+		return override
+	}
+
+	return base
+}
+
+func mergeInt32(base, override int32) int32 {
+	if override != 0 {
+		return override
+	}
+
+	return base
+}
+
+// mergeList returns copy of override list when it isn't empty, otherwise copy of base list
+func mergeList(base, override []string) []string {
+	if len(override) != 0 {
+		return append([]string{}, override...)
+	}
+	if base == nil {
+		return nil
+	}
+
+	return append([]string{}, base...)
+}
+
+// mergeUnion returns base list followed by override items not present in base list
+func mergeUnion(base, override []string) []string {
+	if base == nil && override == nil {
+		return nil
+	}
+
+	merged := []string{}
+	seen := map[string]bool{}
+	for _, item := range append(append([]string{}, base...), override...) {
+		if !seen[item] {
+			seen[item] = true
+			merged = append(merged, item)
+		}
+	}
+
+	return merged
+}
+
+// mergeEnv merges environment variables by name
+func mergeEnv(base, override *[]EnvVar) *[]EnvVar {
+	if base == nil && override == nil {
+		return nil
+	}
+
+	merged := []EnvVar{}
+	index := map[string]int{}
+	for _, envs := range []*[]EnvVar{base, override} {
+		if envs == nil {
+			continue
+		}
+		for _, env := range *envs {
+			if i, ok := index[env.Name]; ok {
+				merged[i] = env
+				continue
+			}
+			index[env.Name] = len(merged)
+			merged = append(merged, env)
+		}
+	}
+
+	return &merged
+}
+
+// mergeContainers merges containers by name, container of override list replaces base container
+func mergeContainers(base, override []Container) []Container {
+	if base == nil && override == nil {
+		return nil
+	}
+
+	merged := []Container{}
+	index := map[string]int{}
+	for _, containers := range [][]Container{base, override} {
+		for _, container := range containers {
+			if i, ok := index[container.Name]; ok {
+				merged[i] = container
+				continue
+			}
+			index[container.Name] = len(merged)
+			merged = append(merged, container)
+		}
+	}
+
+	return merged
+}
+
+func mergeResources(base, override *ResourceRequirements) *ResourceRequirements {
+	if base == nil && override == nil {
+		return nil
+	}
+	if base == nil {
+		base = &ResourceRequirements{}
+	}
+	if override == nil {
+		override = &ResourceRequirements{}
+	}
+
+	return &ResourceRequirements{
+		Limits:   mergeResourceList(base.Limits, override.Limits),
+		Requests: mergeResourceList(base.Requests, override.Requests),
+	}
+}
+
+func mergeResourceList(base, override *ResourceList) *ResourceList {
+	if base == nil && override == nil {
+		return nil
+	}
+	if base == nil {
+		base = &ResourceList{}
+	}
+	if override == nil {
+		override = &ResourceList{}
+	}
+
+	return &ResourceList{
+		CPU:              mergeString(base.CPU, override.CPU),
+		Memory:           mergeString(base.Memory, override.Memory),
+		EphemeralStorage: mergeString(base.EphemeralStorage, override.EphemeralStorage),
+		Extended:         mergeExtended(base.Extended, override.Extended),
+	}
+}
+
+// mergeProbe returns copy of override probe when it is set, otherwise copy of base probe
+func mergeProbe(base, override *Probe) *Probe {
+	if override == nil {
+		override = base
+	}
+	if override == nil {
+		return nil
+	}
+
+	probe := *override
+	return &probe
+}
+
+// mergeChaosMonkey returns copy of override settings when they are set, otherwise copy of base settings
+func mergeChaosMonkey(base, override *ChaosMonkey) *ChaosMonkey {
+	if override == nil {
+		override = base
+	}
+	if override == nil {
+		return nil
+	}
+
+	chaosMonkey := *override
+	return &chaosMonkey
+}
+
+// mergeAutoscaling returns deep copy of override settings when they are set, otherwise deep copy of base settings
+func mergeAutoscaling(base, override *Autoscaling) *Autoscaling {
+	if override == nil {
+		override = base
+	}
+	if override == nil {
+		return nil
+	}
+
+	autoscaling := *override
+	if override.Metrics != nil {
+		autoscaling.Metrics = append([]AutoscalingMetric{}, override.Metrics...)
+	}
+	if override.ScaleDown != nil {
+		scaleDown := *override.ScaleDown
+		autoscaling.ScaleDown = &scaleDown
+	}
+
+	return &autoscaling
+}
+
+// mergeDisruptionBudget returns copy of override settings when they are set, otherwise copy of base settings
+func mergeDisruptionBudget(base, override *DisruptionBudget) *DisruptionBudget {
+	if override == nil {
+		override = base
+	}
+	if override == nil {
+		return nil
+	}
+
+	budget := *override
+	return &budget
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveDefaults(t *testing.T) {
+	config := &Configuration{
+		Application: "myapp",
+		ChaosMonkey: &ChaosMonkey{Enabled: true, MTBF: "2"},
+		Defaults: &Datacenter{
+			Replicas: 2,
+			Env:      &[]EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx1g"}, {Name: "LOG_LEVEL", Value: "info"}},
+			EnvFrom:  []string{"kafka-configmap"},
+			Command:  []string{"run"},
+			Resources: &ResourceRequirements{
+				Requests: &ResourceList{CPU: "1", Memory: "1Gi"},
+			},
+			LivenessProbe: &Probe{Type: "http", Path: "/health", Delay: 15},
+			PodPriority:   "high-priority",
+			Autoscaling:   &Autoscaling{MaxReplicas: 20, Metrics: []AutoscalingMetric{{Type: "pods", Name: "rps"}}},
+		},
+		Profiles: &[]*Profile{
+			{
+				ProfileName: "production",
+				Defaults: &Datacenter{
+					Replicas: 10,
+					Env:      &[]EnvVar{{Name: "LOG_LEVEL", Value: "warn"}},
+				},
+				Datacenters: &[]*Datacenter{
+					{
+						TierName: "gke1",
+						Env:      &[]EnvVar{{Name: "DC", Value: "gke1"}},
+						EnvFrom:  []string{"minio-configmap", "kafka-configmap"},
+						Resources: &ResourceRequirements{
+							Requests: &ResourceList{Memory: "2Gi"},
+						},
+						LivenessProbe: &Probe{Type: "file"},
+						ChaosMonkey:   &ChaosMonkey{Enabled: false},
+					},
+					{
+						TierName:    "gke2",
+						Replicas:    3,
+						Command:     []string{"run", "--fast"},
+						Autoscaling: &Autoscaling{MaxReplicas: 5},
+					},
+				},
+			},
+		},
+	}
+
+	config.ResolveDefaults()
+	// resolving defaults twice must give the same result
+	config.ResolveDefaults()
+
+	gke1 := (*(*config.Profiles)[0].Datacenters)[0]
+	expectedGke1 := &Datacenter{
+		TierName: "gke1",
+		Replicas: 10,
+		Env: &[]EnvVar{
+			{Name: "JAVA_OPTS", Value: "-Xmx1g"},
+			{Name: "LOG_LEVEL", Value: "warn"},
+			{Name: "DC", Value: "gke1"},
+		},
+		EnvFrom: []string{"kafka-configmap", "minio-configmap"},
+		Command: []string{"run"},
+		Resources: &ResourceRequirements{
+			Requests: &ResourceList{CPU: "1", Memory: "2Gi"},
+		},
+		LivenessProbe: &Probe{Type: "file"},
+		PodPriority:   "high-priority",
+		ChaosMonkey:   &ChaosMonkey{Enabled: false},
+		Autoscaling:   &Autoscaling{MaxReplicas: 20, Metrics: []AutoscalingMetric{{Type: "pods", Name: "rps"}}},
+	}
+	if !reflect.DeepEqual(gke1, expectedGke1) {
+		t.Errorf("Expected gke1 %+v, got %+v", expectedGke1, gke1)
+	}
+
+	gke2 := (*(*config.Profiles)[0].Datacenters)[1]
+	if gke2.Replicas != 3 {
+		t.Errorf("Expected replicas 3, got %d", gke2.Replicas)
+	}
+	if !reflect.DeepEqual(gke2.Command, []string{"run", "--fast"}) {
+		t.Errorf("Expected command to be replaced, got %v", gke2.Command)
+	}
+	if gke2.ChaosMonkey == nil || !gke2.ChaosMonkey.Enabled || gke2.ChaosMonkey.MTBF != "2" {
+		t.Errorf("Expected application chaosMonkey, got %+v", gke2.ChaosMonkey)
+	}
+	if !reflect.DeepEqual(gke2.Autoscaling, &Autoscaling{MaxReplicas: 5}) {
+		t.Errorf("Expected autoscaling to be replaced, got %+v", gke2.Autoscaling)
+	}
+	if gke2.LivenessProbe == nil || gke2.LivenessProbe.Path != "/health" {
+		t.Errorf("Expected liveness probe from application defaults, got %+v", gke2.LivenessProbe)
+	}
+
+	// resolved datacenters must not share objects with defaults or each other
+	gke2.LivenessProbe.Path = "/changed"
+	gke2.Resources.Requests.CPU = "4"
+	gke1.Autoscaling.Metrics[0].Name = "changed"
+	if config.Defaults.LivenessProbe.Path != "/health" || config.Defaults.Resources.Requests.CPU != "1" ||
+		config.Defaults.Autoscaling.Metrics[0].Name != "rps" {
+		t.Errorf("Expected defaults to stay unchanged, got %+v", config.Defaults)
+	}
+}
+
+func TestResolveDefaultsWithoutProfiles(t *testing.T) {
+	config := &Configuration{Application: "myapp", Defaults: &Datacenter{Replicas: 2}}
+	config.ResolveDefaults()
+	if config.Profiles != nil {
+		t.Errorf("Expected profiles to stay nil")
+	}
+}
+
+func TestMergeEnv(t *testing.T) {
+	if result := mergeEnv(nil, nil); result != nil {
+		t.Errorf("Expected nil env, got %v", *result)
+	}
+
+	base := &[]EnvVar{{Name: "A", Value: "1"}}
+	result := mergeEnv(base, nil)
+	(*result)[0].Value = "2"
+	if (*base)[0].Value != "1" {
+		t.Errorf("Expected base env to stay unchanged, got %v", *base)
+	}
+}
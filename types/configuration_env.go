@@ -0,0 +1,87 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// EnvVarSource represents source of environment variable value, exactly one field must be set
+type EnvVarSource struct {
+	SecretKeyRef     *KeySelector           `json:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef  *KeySelector           `json:"configMapKeyRef,omitempty"`
+	FieldRef         *FieldSelector         `json:"fieldRef,omitempty"`
+	ResourceFieldRef *ResourceFieldSelector `json:"resourceFieldRef,omitempty"`
+}
+
+// KeySelector represents key of secret or configmap
+type KeySelector struct {
+	Name     string `json:"name"`
+	Key      string `json:"key"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// FieldSelector represents field of the pod, e.g. "status.podIP" or "metadata.namespace"
+type FieldSelector struct {
+	FieldPath string `json:"fieldPath"`
+}
+
+// ResourceFieldSelector represents resource of the container, e.g. "limits.memory"
+type ResourceFieldSelector struct {
+	// ContainerName is the container of the resource, the container of the variable by default
+	ContainerName string `json:"containerName,omitempty"`
+	Resource      string `json:"resource"`
+	// Divisor is the unit of exposed value, e.g. "1Mi" for memory in megabytes
+	Divisor string `json:"divisor,omitempty"`
+}
+
+// EnvFromSource represents all keys of secret or configmap exposed as environment variables,
+// exactly one of Secret and ConfigMap must be set
+type EnvFromSource struct {
+	Secret    string `json:"secret,omitempty"`
+	ConfigMap string `json:"configMap,omitempty"`
+	// Prefix is prepended to every variable name, e.g. "DB_"
+	Prefix   string `json:"prefix,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// key returns unique key of the source among secrets and configmaps
+func (s *EnvFromSource) key() string {
+	if s.Secret != "" {
+		return "secret/" + s.Secret
+	}
+
+	return "configmap/" + s.ConfigMap
+}
+
+// mergeEnvFromSources merges sources by secret or configmap name, source of override list replaces base source
+func mergeEnvFromSources(base, override []EnvFromSource) []EnvFromSource {
+	if base == nil && override == nil {
+		return nil
+	}
+
+	merged := []EnvFromSource{}
+	index := map[string]int{}
+	for _, sources := range [][]EnvFromSource{base, override} {
+		for _, source := range sources {
+			if i, ok := index[source.key()]; ok {
+				merged[i] = source
+				continue
+			}
+			index[source.key()] = len(merged)
+			merged = append(merged, source)
+		}
+	}
+
+	return merged
+}
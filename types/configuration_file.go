@@ -0,0 +1,27 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// ConfigurationFile represents single source file of applications configuration
+type ConfigurationFile struct {
+	// Path of the file locally or inside GitHub repository
+	Path string
+	// Content of the file, normalized to JSON list of applications
+	Content []byte
+	// Wrapped reports that the file contained single application object, which was wrapped into list
+	Wrapped bool
+}
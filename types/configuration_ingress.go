@@ -0,0 +1,115 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+const (
+	// IngressKindIngress generates networking.k8s.io/v1 Ingress
+	IngressKindIngress = "ingress"
+	// IngressKindHTTPRoute generates Gateway API gateway.networking.k8s.io/v1 HTTPRoute
+	IngressKindHTTPRoute = "httpRoute"
+
+	defaultIngressPath     = "/"
+	defaultIngressPathType = "Prefix"
+)
+
+// Ingress represents external access to the service application
+type Ingress struct {
+	// Kind of generated object, "ingress" (default) or "httpRoute"
+	Kind string `json:"kind,omitempty"`
+	// ClassName is the ingress class of Ingress
+	ClassName string `json:"className,omitempty"`
+	// Gateway is the parent gateway of HTTPRoute
+	Gateway *Gateway `json:"gateway,omitempty"`
+	// Hosts are host names of production stage, other stages get hosts prefixed with "<stage>-"
+	Hosts []string `json:"hosts"`
+	// Paths routed to the service, "/" to the first port by default
+	Paths []IngressPath `json:"paths,omitempty"`
+	// TLSSecret is the name of secret with TLS certificate for the hosts of Ingress
+	TLSSecret string `json:"tlsSecret,omitempty"`
+	// Annotations of generated object
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IngressPath represents path routed to the service port
+type IngressPath struct {
+	Path string `json:"path"`
+	// PathType is "Prefix" (default), "Exact" or "ImplementationSpecific"
+	PathType string `json:"pathType,omitempty"`
+	// Port is the name of application port, first port is used by default
+	Port string `json:"port,omitempty"`
+}
+
+// Gateway represents reference to the Gateway API gateway
+type Gateway struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// IngressKind returns kind of generated object, Ingress is generated by default
+func (i *Ingress) IngressKind() string {
+	if i.Kind == "" {
+		return IngressKindIngress
+	}
+
+	return i.Kind
+}
+
+// StageHosts returns host names of the stage, hosts of non-production stages are prefixed with stage name
+func (i *Ingress) StageHosts(stage string) []string {
+	hosts := make([]string, 0, len(i.Hosts))
+	for _, host := range i.Hosts {
+		if stage != stageProduction {
+			host = stage + "-" + host
+		}
+		hosts = append(hosts, host)
+	}
+
+	return hosts
+}
+
+// RoutedPaths returns paths with default values applied
+func (i *Ingress) RoutedPaths(ports []Port) []IngressPath {
+	paths := i.Paths
+	if len(paths) == 0 {
+		paths = []IngressPath{{Path: defaultIngressPath}}
+	}
+
+	routed := make([]IngressPath, 0, len(paths))
+	for _, path := range paths {
+		if path.PathType == "" {
+			path.PathType = defaultIngressPathType
+		}
+		if path.Port == "" && len(ports) > 0 {
+			path.Port = ports[0].Name
+		}
+		routed = append(routed, path)
+	}
+
+	return routed
+}
+
+// FindPort returns application port by name, nil is returned when port doesn't exist
+func FindPort(ports []Port, name string) *Port {
+	for i := range ports {
+		if ports[i].Name == name {
+			return &ports[i]
+		}
+	}
+
+	return nil
+}
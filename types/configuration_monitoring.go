@@ -0,0 +1,92 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+const (
+	// MonitorKindServiceMonitor generates monitoring.coreos.com/v1 ServiceMonitor scraping the application Service
+	MonitorKindServiceMonitor = "serviceMonitor"
+	// MonitorKindPodMonitor generates monitoring.coreos.com/v1 PodMonitor scraping the application pods
+	MonitorKindPodMonitor = "podMonitor"
+
+	defaultMetricsPort         = "metrics"
+	defaultMetricsPath         = "/metrics"
+	defaultPrometheusNamespace = "monitoring"
+)
+
+// Monitoring represents Prometheus scraping of the application metrics port
+type Monitoring struct {
+	// Disabled turns off generation of ServiceMonitor or PodMonitor
+	Disabled bool `json:"disabled,omitempty"`
+	// Kind is "serviceMonitor" (default for service applications) or "podMonitor" (default for other applications)
+	Kind string `json:"kind,omitempty"`
+	// Port is the name of metrics port, "metrics" by default
+	Port string `json:"port,omitempty"`
+	// Path of metrics endpoint, "/metrics" by default
+	Path string `json:"path,omitempty"`
+	// Interval between scrapes, e.g. "30s", Prometheus global interval is used by default
+	Interval string `json:"interval,omitempty"`
+	// ScrapeTimeout of single scrape, e.g. "10s"
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// Labels of generated object used by Prometheus to select monitors
+	Labels map[string]string `json:"labels,omitempty"`
+	// Relabelings applied to target labels before scraping
+	Relabelings []Relabeling `json:"relabelings,omitempty"`
+	// MetricRelabelings applied to scraped samples before ingestion
+	MetricRelabelings []Relabeling `json:"metricRelabelings,omitempty"`
+	// PrometheusNamespace is the namespace of Prometheus allowed by NetworkPolicy to scrape metrics port, "monitoring" by default
+	PrometheusNamespace string `json:"prometheusNamespace,omitempty"`
+}
+
+// Relabeling represents Prometheus relabel config
+type Relabeling struct {
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	Separator    string   `json:"separator,omitempty"`
+	TargetLabel  string   `json:"targetLabel,omitempty"`
+	Regex        string   `json:"regex,omitempty"`
+	Replacement  string   `json:"replacement,omitempty"`
+	Action       string   `json:"action,omitempty"`
+}
+
+// MonitorKind returns kind of generated monitor, ServiceMonitor is used only for applications with Service
+func (c *Configuration) MonitorKind() string {
+	if c.Monitoring != nil && c.Monitoring.Kind != "" {
+		return c.Monitoring.Kind
+	}
+	if c.HasService() {
+		return MonitorKindServiceMonitor
+	}
+
+	return MonitorKindPodMonitor
+}
+
+// MetricsPort returns name of the metrics port
+func (c *Configuration) MetricsPort() string {
+	if c.Monitoring != nil && c.Monitoring.Port != "" {
+		return c.Monitoring.Port
+	}
+
+	return defaultMetricsPort
+}
+
+// PrometheusNamespace returns namespace of Prometheus scraping the application metrics port
+func (c *Configuration) PrometheusNamespace() string {
+	if c.Monitoring != nil && c.Monitoring.PrometheusNamespace != "" {
+		return c.Monitoring.PrometheusNamespace
+	}
+
+	return defaultPrometheusNamespace
+}
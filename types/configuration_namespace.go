@@ -0,0 +1,90 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "fmt"
+
+// NamespaceSettings represents namespace of the application, settings of all applications deployed
+// to the same namespace are merged into single namespace manifest
+type NamespaceSettings struct {
+	// Labels of the namespace, e.g. pod security admission level
+	Labels map[string]string `json:"labels,omitempty"`
+	// ResourceQuota limits total resources of all pods in the namespace
+	ResourceQuota *ResourceQuota `json:"resourceQuota,omitempty"`
+	// LimitRange sets default resources of containers in the namespace
+	LimitRange *LimitRange `json:"limitRange,omitempty"`
+}
+
+// ResourceQuota represents hard limits of the namespace resources
+type ResourceQuota struct {
+	Requests *ResourceList `json:"requests,omitempty"`
+	Limits   *ResourceList `json:"limits,omitempty"`
+	Pods     int32         `json:"pods,omitempty"`
+}
+
+// LimitRange represents default and maximum resources of containers in the namespace
+type LimitRange struct {
+	Default        *ResourceList `json:"default,omitempty"`
+	DefaultRequest *ResourceList `json:"defaultRequest,omitempty"`
+	Max            *ResourceList `json:"max,omitempty"`
+}
+
+// CheckQuantities returns error of the first invalid quantity of resource quota or limit range
+func (s *NamespaceSettings) CheckQuantities() error {
+	if quota := s.ResourceQuota; quota != nil {
+		for _, list := range []*ResourceList{quota.Requests, quota.Limits} {
+			if err := list.CheckQuantities(); err != nil {
+				return fmt.Errorf("resourceQuota: %w", err)
+			}
+		}
+	}
+	if limits := s.LimitRange; limits != nil {
+		for _, list := range []*ResourceList{limits.Default, limits.DefaultRequest, limits.Max} {
+			if err := list.CheckQuantities(); err != nil {
+				return fmt.Errorf("limitRange: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MergeNamespaceSettings returns settings of the namespace merged from all applications deployed to it,
+// labels are merged by key and the first resourceQuota and limitRange found win
+func MergeNamespaceSettings(apps []*Configuration, namespace string) *NamespaceSettings {
+	merged := &NamespaceSettings{Labels: map[string]string{}}
+
+	for _, app := range apps {
+		if app.Namespace != namespace || app.NamespaceSettings == nil {
+			continue
+		}
+
+		for key, value := range app.NamespaceSettings.Labels {
+			if _, ok := merged.Labels[key]; !ok {
+				merged.Labels[key] = value
+			}
+		}
+		if merged.ResourceQuota == nil {
+			merged.ResourceQuota = app.NamespaceSettings.ResourceQuota
+		}
+		if merged.LimitRange == nil {
+			merged.LimitRange = app.NamespaceSettings.LimitRange
+		}
+	}
+
+	return merged
+}
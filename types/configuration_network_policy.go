@@ -0,0 +1,71 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// NetworkPolicy represents ingress NetworkPolicy of the application. By default traffic is allowed only
+// to declared ports, when ingressFrom is set ports are open only to its sources and to dependent applications.
+type NetworkPolicy struct {
+	// Disabled turns off generation of NetworkPolicy
+	Disabled bool `json:"disabled,omitempty"`
+	// IngressFrom are sources allowed to send traffic to the application
+	IngressFrom []NetworkPolicySource `json:"ingressFrom,omitempty"`
+}
+
+// NetworkPolicySource represents source of traffic allowed by NetworkPolicy
+type NetworkPolicySource struct {
+	// Namespace of source pods, namespace of the application by default
+	Namespace string `json:"namespace,omitempty"`
+	// PodLabels select source pods, all pods of the namespace by default
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+	// CIDR is IP block of source outside the cluster, namespace and podLabels aren't used with it
+	CIDR string `json:"cidr,omitempty"`
+	// Ports are names of application ports open to the source, all ports by default
+	Ports []string `json:"ports,omitempty"`
+}
+
+// Dependent represents application which declares dependsOn on another application
+type Dependent struct {
+	Application string
+	Namespace   string
+	Stages      []string
+}
+
+// ResolveDependents fills dependents of every application from dependsOn of all applications
+func ResolveDependents(apps []*Configuration) {
+	byName := map[string]*Configuration{}
+	for _, app := range apps {
+		app.Dependents = nil
+		byName[app.Application] = app
+	}
+
+	for _, app := range apps {
+		for _, dependency := range app.DependsOn {
+			target, ok := byName[dependency.Name]
+			if !ok || target == app {
+				continue
+			}
+
+			dependent := Dependent{Application: app.Application, Namespace: app.Namespace}
+			if app.Profiles != nil {
+				for _, profile := range *app.Profiles {
+					dependent.Stages = append(dependent.Stages, profile.ProfileName)
+				}
+			}
+			target.Dependents = append(target.Dependents, dependent)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+// Profile returns profile of the application by name, nil is returned when profile doesn't exist
+func (c *Configuration) Profile(name string) *Profile {
+	if c.Profiles == nil {
+		return nil
+	}
+
+	for _, profile := range *c.Profiles {
+		if profile.ProfileName == name {
+			return profile
+		}
+	}
+
+	return nil
+}
+
+// legacyPromotionTier is the datacenter of source profile which triggers legacy promotions
+const legacyPromotionTier = "gke1"
+
+// Promotion returns promotion of the profile, nil is returned when profile isn't promoted.
+// When no profile of the application sets promotesFrom, legacy promotion graph is used: beta → nightly → production,
+// or beta → production and nightly → production when only one of beta and nightly profiles exists.
+func (c *Configuration) Promotion(profile *Profile) *Promotion {
+	if profile.PromotesFrom != nil || c.Profiles == nil {
+		return profile.PromotesFrom
+	}
+	for _, other := range *c.Profiles {
+		if other.PromotesFrom != nil {
+			return nil
+		}
+	}
+
+	var source string
+	switch {
+	case profile.ProfileName == stageNightly && c.Profile(stageBeta) != nil:
+		source = stageBeta
+	case profile.ProfileName == stageProduction && c.Profile(stageNightly) != nil:
+		source = stageNightly
+	case profile.ProfileName == stageProduction && c.Profile(stageBeta) != nil:
+		source = stageBeta
+	default:
+		return nil
+	}
+
+	promotion := &Promotion{ProfileName: source}
+	if hasDatacenter(c.Profile(source), legacyPromotionTier) {
+		promotion.TierName = legacyPromotionTier
+	}
+
+	return promotion
+}
+
+// hasDatacenter checks if profile has datacenter with the tier name
+func hasDatacenter(profile *Profile, tierName string) bool {
+	if profile.Datacenters == nil {
+		return false
+	}
+
+	for _, tier := range *profile.Datacenters {
+		if tier.TierName == tierName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PromotionSource returns profile and tier names of deploy pipeline which triggers promotion to the profile.
+// Empty names are returned when profile isn't promoted or its source profile doesn't exist.
+func (c *Configuration) PromotionSource(profile *Profile) (string, string) {
+	promotion := c.Promotion(profile)
+	if promotion == nil {
+		return "", ""
+	}
+
+	source := c.Profile(promotion.ProfileName)
+	if source == nil {
+		return "", ""
+	}
+
+	if promotion.TierName != "" {
+		return source.ProfileName, promotion.TierName
+	}
+
+	if source.Datacenters == nil || len(*source.Datacenters) == 0 {
+		return "", ""
+	}
+
+	return source.ProfileName, (*source.Datacenters)[0].TierName
+}
@@ -16,42 +16,72 @@ limitations under the License.
 
 package types
 
-const (
-	dockerHubUrl = "index.docker.io/"
-)
-
 type Configuration struct {
-	Application                       string          `json:"application"`
-	DockerImage                       string          `json:"image,omitempty"`
-	Profiles                          *[]*Profile     `json:"profiles,omitempty"`
-	EnvFrom                           []string        `json:"envFrom,omitempty"`
-	DependsOn                         []DependsOn     `json:"dependsOn,omitempty"`
-	Type                              string          `json:"type"`
-	Owners                            string          `json:"owners,omitempty"`
-	OwnerEmail                        string          `json:"ownerEmail,omitempty"`
-	NodePool                          string          `json:"nodePool,omitempty"`
-	Namespace                         string          `json:"namespace,omitempty"`
-	SlackChannel                      string          `json:"slackChannel,omitempty"`
-	JenkinsJobName                    string          `json:"jenkinsJobName,omitempty"`
-	Ports                             []Port          `json:"ports,omitempty"`
-	Strategy                          *DeployStrategy `json:"strategy,omitempty"`
-	ChaosMonkey                       *ChaosMonkey    `json:"chaosMonkey,omitempty"`
-	Version                           string          `json:"version,omitempty"`
-	RestrictExecutionDuringTimeWindow bool            `json:"restrictExecutionDuringTimeWindow,omitempty"`
-	SkipAutogeneration                bool            `json:"skipAutogeneration,omitempty"`
+	Application                       string               `json:"application"`
+	DockerImage                       string               `json:"image,omitempty"`
+	Registry                          *Registry            `json:"registry,omitempty"`
+	TagPolicy                         *TagPolicy           `json:"tagPolicy,omitempty"`
+	Profiles                          *[]*Profile          `json:"profiles,omitempty"`
+	EnvFrom                           []string             `json:"envFrom,omitempty"`
+	EnvFromSources                    []EnvFromSource      `json:"envFromSources,omitempty"`
+	DependsOn                         []DependsOn          `json:"dependsOn,omitempty"`
+	Type                              string               `json:"type"`
+	Owners                            string               `json:"owners,omitempty"`
+	OwnerEmail                        string               `json:"ownerEmail,omitempty"`
+	NodePool                          string               `json:"nodePool,omitempty"`
+	Namespace                         string               `json:"namespace,omitempty"`
+	NamespaceSettings                 *NamespaceSettings   `json:"namespaceSettings,omitempty"`
+	SlackChannel                      string               `json:"slackChannel,omitempty"`
+	JenkinsJobName                    string               `json:"jenkinsJobName,omitempty"`
+	Ports                             []Port               `json:"ports,omitempty"`
+	Service                           *ServiceSettings     `json:"service,omitempty"`
+	Ingress                           *Ingress             `json:"ingress,omitempty"`
+	NetworkPolicy                     *NetworkPolicy       `json:"networkPolicy,omitempty"`
+	Monitoring                        *Monitoring          `json:"monitoring,omitempty"`
+	Strategy                          *DeployStrategy      `json:"strategy,omitempty"`
+	CronJob                           *CronJobSettings     `json:"cronJob,omitempty"`
+	Job                               *JobSettings         `json:"job,omitempty"`
+	StatefulSet                       *StatefulSetSettings `json:"statefulSet,omitempty"`
+	Sidecars                          []Container          `json:"sidecars,omitempty"`
+	InitContainers                    []Container          `json:"initContainers,omitempty"`
+	Volumes                           []Volume             `json:"volumes,omitempty"`
+	SecurityContext                   *SecurityContext     `json:"securityContext,omitempty"`
+	ResourcePolicy                    *ResourcePolicy      `json:"resourcePolicy,omitempty"`
+	ChaosMonkey                       *ChaosMonkey         `json:"chaosMonkey,omitempty"`
+	Defaults                          *Datacenter          `json:"defaults,omitempty"`
+	Version                           string               `json:"version,omitempty"`
+	RestrictExecutionDuringTimeWindow bool                 `json:"restrictExecutionDuringTimeWindow,omitempty"`
+	SkipAutogeneration                bool                 `json:"skipAutogeneration,omitempty"`
+
+	// Dependents are applications which depend on the application, resolved from dependsOn of all applications
+	Dependents []Dependent `json:"-"`
+	// OrganizationResourcePolicy is resource policy of the organization, which resourcePolicy of the application
+	// can only tighten, it is loaded next to the configuration with ApplyResourcePolicy
+	OrganizationResourcePolicy *ResourcePolicy `json:"-"`
 }
 
 type Profile struct {
-	ProfileName string         `json:"profileName"`
-	Datacenters *[]*Datacenter `json:"datacenters"`
+	ProfileName  string         `json:"profileName"`
+	PromotesFrom *Promotion     `json:"promotesFrom,omitempty"`
+	Defaults     *Datacenter    `json:"defaults,omitempty"`
+	Datacenters  *[]*Datacenter `json:"datacenters"`
+}
+
+// Promotion references deploy pipeline of another profile which triggers promotion to the profile
+type Promotion struct {
+	// ProfileName is the source profile of promotion
+	ProfileName string `json:"profileName"`
+	// TierName is the source datacenter of promotion, first datacenter of the source profile by default
+	TierName string `json:"tierName,omitempty"`
 }
 
 type Datacenter struct {
-	TierName         string                `json:"tierName"`
+	TierName         string                `json:"tierName,omitempty"`
 	Replicas         int32                 `json:"replicas"`
 	NodePool         string                `json:"nodePool,omitempty"`
 	Env              *[]EnvVar             `json:"env"`
 	EnvFrom          []string              `json:"envFrom,omitempty"`
+	EnvFromSources   []EnvFromSource       `json:"envFromSources,omitempty"`
 	Command          []string              `json:"command,omitempty"`
 	Resources        *ResourceRequirements `json:"resources"`
 	ProgressDeadline int32                 `json:"progressDeadline,omitempty"`
@@ -61,11 +91,73 @@ type Datacenter struct {
 	PodPriority      string                `json:"podPriority,omitempty"`
 	ChaosMonkey      *ChaosMonkey          `json:"chaosMonkey,omitempty"`
 	Version          string                `json:"version,omitempty"`
+	Autoscaling      *Autoscaling          `json:"autoscaling,omitempty"`
+	DisruptionBudget *DisruptionBudget     `json:"disruptionBudget,omitempty"`
+	Sidecars         []Container           `json:"sidecars,omitempty"`
+	InitContainers   []Container           `json:"initContainers,omitempty"`
+	Scheduling       *Scheduling           `json:"scheduling,omitempty"`
+}
+
+// DisruptionBudget represents PodDisruptionBudget settings, by default budget with maxUnavailable
+// from rolling update strategy (or 1) is generated for datacenters with more than one replica
+type DisruptionBudget struct {
+	// Disabled turns off generation of PodDisruptionBudget
+	Disabled bool `json:"disabled,omitempty"`
+	// MinAvailable is the number or percent of pods which must stay available during eviction
+	MinAvailable string `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percent of pods which can be unavailable during eviction
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+// Autoscaling represents HorizontalPodAutoscaler settings, replicas of the deployment aren't managed
+// by spinnaker when autoscaling is set
+type Autoscaling struct {
+	// MinReplicas is lower limit of replicas, datacenter replicas (or 1) by default
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is upper limit of replicas
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetCPUUtilization is average CPU utilization in percent of requests,
+	// 80 by default when no other metric is set
+	TargetCPUUtilization int32 `json:"targetCPUUtilization,omitempty"`
+	// TargetMemoryUtilization is average memory utilization in percent of requests
+	TargetMemoryUtilization int32 `json:"targetMemoryUtilization,omitempty"`
+	// Metrics lists custom pods and external metrics
+	Metrics []AutoscalingMetric `json:"metrics,omitempty"`
+	// ScaleDown limits how fast replicas are removed
+	ScaleDown *ScaleDownBehavior `json:"scaleDown,omitempty"`
+}
+
+// AutoscalingMetric represents custom metric of HorizontalPodAutoscaler
+type AutoscalingMetric struct {
+	// Type is "pods" for metrics of application pods or "external" for metrics not related to kubernetes objects
+	Type string `json:"type"`
+	// Name of the metric
+	Name string `json:"name"`
+	// Selector narrows down the metric by labels
+	Selector map[string]string `json:"selector,omitempty"`
+	// TargetAverageValue is target value of the metric averaged across pods, e.g. "100" or "500m"
+	TargetAverageValue string `json:"targetAverageValue,omitempty"`
+	// TargetValue is target value of external metric
+	TargetValue string `json:"targetValue,omitempty"`
+}
+
+// ScaleDownBehavior represents scale down behavior of HorizontalPodAutoscaler
+type ScaleDownBehavior struct {
+	// StabilizationWindowSeconds is the number of seconds past recommendations are considered while scaling down
+	StabilizationWindowSeconds *int32 `json:"stabilizationWindowSeconds,omitempty"`
+	// Percent is the maximum percent of replicas removed per period
+	Percent int32 `json:"percent,omitempty"`
+	// Pods is the maximum number of replicas removed per period
+	Pods int32 `json:"pods,omitempty"`
+	// PeriodSeconds is the period of Percent and Pods policies, 60 by default
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
 }
 
 type EnvVar struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// ValueFrom references value of the variable in secret, configmap or pod fields instead of literal value
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty"`
 }
 
 type DeployStrategy struct {
@@ -111,9 +203,14 @@ type ResourceRequirements struct {
 type ResourceList struct {
 	CPU    string `json:"cpu"`
 	Memory string `json:"memory"`
+	// EphemeralStorage is local storage of container writable layer, logs and emptyDir volumes
+	EphemeralStorage string `json:"ephemeralStorage,omitempty"`
+	// Extended are extended resources by fully-qualified name, e.g. nvidia.com/gpu
+	Extended map[string]string `json:"extended,omitempty"`
 }
 
 type Probe struct {
+	// Type is "http" (default), "file", "exec", "grpc" or "tcp"
 	Type             string `json:"type"`
 	Path             string `json:"path"`
 	Delay            int32  `json:"delay"`
@@ -122,6 +219,20 @@ type Probe struct {
 	PeriodSeconds    int32  `json:"periodSeconds"`
 	SuccessThreshold int32  `json:"successThreshold"`
 	FailureThreshold int32  `json:"failureThreshold"`
+	// Scheme is "HTTP" (default) or "HTTPS" of http probe
+	Scheme string `json:"scheme,omitempty"`
+	// Headers are custom headers of http probe request
+	Headers []HTTPHeader `json:"headers,omitempty"`
+	// Command is executed in the container by exec probe, zero exit code means success
+	Command []string `json:"command,omitempty"`
+	// Service is the name of gRPC health checking service of grpc probe
+	Service string `json:"service,omitempty"`
+}
+
+// HTTPHeader represents custom header of http probe request
+type HTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 type ChaosMonkey struct {
@@ -139,6 +250,29 @@ type DependsOn struct {
 type Port struct {
 	Name          string `json:"name"`
 	ContainerPort int32  `json:"containerPort"`
+	// ServicePort is the port exposed by the Service, container port is used by default
+	ServicePort int32 `json:"servicePort,omitempty"`
+	// Protocol is "TCP" (kubernetes default), "UDP" or "SCTP"
+	Protocol string `json:"protocol,omitempty"`
+	// AppProtocol is the application protocol of the Service port, e.g. "http" or "kubernetes.io/h2c"
+	AppProtocol string `json:"appProtocol,omitempty"`
+}
+
+// ExposedPort returns port number exposed by the Service
+func (p *Port) ExposedPort() int32 {
+	if p.ServicePort != 0 {
+		return p.ServicePort
+	}
+
+	return p.ContainerPort
+}
+
+// ServiceSettings represents Service of service application
+type ServiceSettings struct {
+	// Type is "Headless" (default), "ClusterIP", "NodePort" or "LoadBalancer"
+	Type string `json:"type,omitempty"`
+	// Annotations of the Service, e.g. load balancer settings of cloud provider
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // dependencyContains checks if a string is present in a dependencies slice as name
@@ -151,3 +285,20 @@ func dependencyContains(d []DependsOn, str string) bool {
 
 	return false
 }
+
+// HasDependency checks if application depends on the service with the name
+func (c *Configuration) HasDependency(name string) bool {
+	return dependencyContains(c.DependsOn, name)
+}
+
+// MinReplicas returns the lowest number of replicas of the datacenter deployment
+func (d *Datacenter) MinReplicas() int32 {
+	if d.Autoscaling == nil {
+		return d.Replicas
+	}
+	if d.Autoscaling.MinReplicas != 0 {
+		return d.Autoscaling.MinReplicas
+	}
+
+	return max(d.Replicas, 1)
+}
@@ -96,3 +96,43 @@ func TestDependencyContains(t *testing.T) {
 		})
 	}
 }
+
+func TestImageRegistry(t *testing.T) {
+	tests := []struct {
+		name          string
+		registry      *Registry
+		expectedImage string
+		expected      *Registry
+	}{
+		{
+			name:          "docker hub by default",
+			expectedImage: "index.docker.io/myorg/myapp",
+			expected:      &Registry{Type: RegistryTypeDockerHub, Host: "index.docker.io", Namespace: "myorg", Account: "myorg"},
+		},
+		{
+			name:          "private oci registry",
+			registry:      &Registry{Type: RegistryTypeOCI, Host: "registry.myorg.dev", Namespace: "team", Account: "private"},
+			expectedImage: "registry.myorg.dev/team/myapp",
+			expected:      &Registry{Type: RegistryTypeOCI, Host: "registry.myorg.dev", Namespace: "team", Account: "private"},
+		},
+		{
+			name:          "namespace and account default to organization",
+			registry:      &Registry{Type: RegistryTypeOCI, Host: "localhost:5000", Insecure: true},
+			expectedImage: "localhost:5000/myorg/myapp",
+			expected:      &Registry{Type: RegistryTypeOCI, Host: "localhost:5000", Namespace: "myorg", Account: "myorg", Insecure: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := (&Configuration{Registry: tt.registry}).ImageRegistry("myorg")
+
+			if *registry != *tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, registry)
+			}
+			if image := registry.ImageName("myapp"); image != tt.expectedImage {
+				t.Errorf("Expected image %q, got %q", tt.expectedImage, image)
+			}
+		})
+	}
+}
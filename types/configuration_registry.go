@@ -0,0 +1,76 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+const (
+	// RegistryTypeDockerHub is Docker Hub registry
+	RegistryTypeDockerHub = "dockerhub"
+	// RegistryTypeOCI is any registry implementing OCI distribution API
+	RegistryTypeOCI = "oci"
+
+	dockerHubHost = "index.docker.io"
+)
+
+// Registry represents container registry of application images
+type Registry struct {
+	// Type is RegistryTypeDockerHub (default) or RegistryTypeOCI
+	Type string `json:"type,omitempty"`
+	// Host of the registry used in image references, index.docker.io by default
+	Host string `json:"host,omitempty"`
+	// Namespace of application images inside the registry, organization by default
+	Namespace string `json:"namespace,omitempty"`
+	// Account is spinnaker docker registry account used by docker triggers, organization by default
+	Account string `json:"account,omitempty"`
+	// Insecure enables plain HTTP for OCI registry
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// DefaultRegistry returns Docker Hub registry with images in organization namespace
+func DefaultRegistry(organization string) *Registry {
+	return &Registry{
+		Type:      RegistryTypeDockerHub,
+		Host:      dockerHubHost,
+		Namespace: organization,
+		Account:   organization,
+	}
+}
+
+// ImageRegistry returns registry of application images, unset fields are defaulted like DefaultRegistry does
+func (c *Configuration) ImageRegistry(organization string) *Registry {
+	registry := DefaultRegistry(organization)
+	if c.Registry == nil {
+		return registry
+	}
+
+	registry.Type = mergeString(registry.Type, c.Registry.Type)
+	registry.Host = mergeString(registry.Host, c.Registry.Host)
+	registry.Namespace = mergeString(registry.Namespace, c.Registry.Namespace)
+	registry.Account = mergeString(registry.Account, c.Registry.Account)
+	registry.Insecure = c.Registry.Insecure
+
+	return registry
+}
+
+// Repository returns repository of the image inside the registry, e.g. organization/image
+func (r *Registry) Repository(image string) string {
+	return r.Namespace + "/" + image
+}
+
+// ImageName returns full image name without tag, e.g. index.docker.io/organization/image
+func (r *Registry) ImageName(image string) string {
+	return r.Host + "/" + r.Repository(image)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// LimitFromRequest sets missing limit of the resource to its request
+	LimitFromRequest = "fromRequest"
+	// LimitNone removes limit of the resource, e.g. to avoid CPU throttling
+	LimitNone = "none"
+	// LimitEqualRequest always sets limit of the resource to its request
+	LimitEqualRequest = "equalRequest"
+)
+
+// ResourcePolicy represents rules of container resources, unset fields are inherited from DefaultResourcePolicy
+type ResourcePolicy struct {
+	// CPULimit is "fromRequest" or "none"
+	CPULimit string `json:"cpuLimit,omitempty"`
+	// MemoryLimit is "fromRequest" or "equalRequest"
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+	// MaxPod is the ceiling of requests and limits of all containers of the pod
+	MaxPod *ResourceList `json:"maxPod,omitempty"`
+}
+
+// DefaultResourcePolicy returns resource policy used when neither organization nor application set the rule:
+// limits which aren't set are equal to requests
+func DefaultResourcePolicy() *ResourcePolicy {
+	return &ResourcePolicy{
+		CPULimit:    LimitFromRequest,
+		MemoryLimit: LimitFromRequest,
+	}
+}
+
+// ApplyResourcePolicy sets organization resource policy of all applications, nil policy keeps DefaultResourcePolicy
+func ApplyResourcePolicy(apps []*Configuration, policy *ResourcePolicy) {
+	for _, app := range apps {
+		if app != nil {
+			app.OrganizationResourcePolicy = policy
+		}
+	}
+}
+
+// PodResourcePolicy returns resource policy of the application: organization policy overrides DefaultResourcePolicy
+// field by field, policy of the application sets only limit rules which organization doesn't set
+// and may lower, but not raise, maximum of the pod
+func (c *Configuration) PodResourcePolicy() *ResourcePolicy {
+	policy := DefaultResourcePolicy()
+	organization := c.OrganizationResourcePolicy
+	if organization == nil {
+		organization = &ResourcePolicy{}
+	}
+
+	policy.CPULimit = mergeString(policy.CPULimit, organization.CPULimit)
+	policy.MemoryLimit = mergeString(policy.MemoryLimit, organization.MemoryLimit)
+	policy.MaxPod = mergeResourceList(organization.MaxPod, nil)
+	if c.ResourcePolicy == nil {
+		return policy
+	}
+
+	if organization.CPULimit == "" {
+		policy.CPULimit = mergeString(policy.CPULimit, c.ResourcePolicy.CPULimit)
+	}
+	if organization.MemoryLimit == "" {
+		policy.MemoryLimit = mergeString(policy.MemoryLimit, c.ResourcePolicy.MemoryLimit)
+	}
+	policy.MaxPod = lowerResourceList(policy.MaxPod, c.ResourcePolicy.MaxPod)
+
+	return policy
+}
+
+// CheckRules returns error of unknown limit rule or invalid maximum quantity of the policy
+func (p *ResourcePolicy) CheckRules() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.CPULimit != "" && p.CPULimit != LimitFromRequest && p.CPULimit != LimitNone {
+		return fmt.Errorf("unknown cpuLimit %q, expected %q or %q", p.CPULimit, LimitFromRequest, LimitNone)
+	}
+	if p.MemoryLimit != "" && p.MemoryLimit != LimitFromRequest && p.MemoryLimit != LimitEqualRequest {
+		return fmt.Errorf("unknown memoryLimit %q, expected %q or %q", p.MemoryLimit, LimitFromRequest, LimitEqualRequest)
+	}
+	if err := p.MaxPod.CheckQuantities(); err != nil {
+		return fmt.Errorf("maxPod: %w", err)
+	}
+
+	return nil
+}
+
+// Limits returns limits of the container resources after the policy is applied: limits default to requests
+// when not set, CPU limit is removed or memory limit is replaced by request when the policy says so
+func (p *ResourcePolicy) Limits(resources *ResourceRequirements) *ResourceList {
+	if resources == nil {
+		return nil
+	}
+
+	source := resources.Limits
+	if source == nil {
+		source = resources.Requests
+	}
+	if source == nil {
+		return nil
+	}
+
+	limits := *source
+	limits.Extended = maps.Clone(source.Extended)
+	if p.CPULimit == LimitNone {
+		limits.CPU = ""
+	}
+	if p.MemoryLimit == LimitEqualRequest && resources.Requests != nil && resources.Requests.Memory != "" {
+		limits.Memory = resources.Requests.Memory
+	}
+
+	return &limits
+}
+
+// Quantities returns quantities of the list which are set by kubernetes resource name
+func (l *ResourceList) Quantities() map[string]string {
+	quantities := map[string]string{}
+	if l == nil {
+		return quantities
+	}
+
+	for name, value := range map[string]string{"cpu": l.CPU, "memory": l.Memory, "ephemeral-storage": l.EphemeralStorage} {
+		if value != "" {
+			quantities[name] = value
+		}
+	}
+	for name, value := range l.Extended {
+		if value != "" {
+			quantities[name] = value
+		}
+	}
+
+	return quantities
+}
+
+// CheckQuantities returns error of the first invalid quantity of requests or limits
+func (r *ResourceRequirements) CheckQuantities() error {
+	if r == nil {
+		return nil
+	}
+
+	for _, list := range []*ResourceList{r.Requests, r.Limits} {
+		if err := list.CheckQuantities(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckQuantities returns error of the first invalid quantity of the list
+func (l *ResourceList) CheckQuantities() error {
+	quantities := l.Quantities()
+	for _, name := range slices.Sorted(maps.Keys(quantities)) {
+		if _, err := resource.ParseQuantity(quantities[name]); err != nil {
+			return fmt.Errorf("invalid %s quantity %q: %v", name, quantities[name], err)
+		}
+	}
+
+	return nil
+}
+
+// lowerResourceList returns copy of base list where quantities are replaced by lower quantities of override,
+// invalid quantities of override are ignored
+func lowerResourceList(base, override *ResourceList) *ResourceList {
+	if base == nil || override == nil {
+		return mergeResourceList(base, override)
+	}
+
+	lower := &ResourceList{
+		CPU:              lowerQuantity(base.CPU, override.CPU),
+		Memory:           lowerQuantity(base.Memory, override.Memory),
+		EphemeralStorage: lowerQuantity(base.EphemeralStorage, override.EphemeralStorage),
+	}
+	for name := range mergeExtended(base.Extended, override.Extended) {
+		if lower.Extended == nil {
+			lower.Extended = map[string]string{}
+		}
+		lower.Extended[name] = lowerQuantity(base.Extended[name], override.Extended[name])
+	}
+
+	return lower
+}
+
+// lowerQuantity returns override quantity when base isn't set or override is lower
+func lowerQuantity(base, override string) string {
+	if base == "" || override == "" {
+		return mergeString(base, override)
+	}
+
+	baseQuantity, err := resource.ParseQuantity(base)
+	if err != nil {
+		return override
+	}
+	overrideQuantity, err := resource.ParseQuantity(override)
+	if err != nil || overrideQuantity.Cmp(baseQuantity) >= 0 {
+		return base
+	}
+
+	return override
+}
+
+// mergeExtended returns extended resources of base overridden by resources of override with the same name
+func mergeExtended(base, override map[string]string) map[string]string {
+	if base == nil && override == nil {
+		return nil
+	}
+
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, override)
+
+	return merged
+}
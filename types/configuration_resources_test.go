@@ -0,0 +1,104 @@
+package types
+
+import "testing"
+
+func TestResourcePolicyLimits(t *testing.T) {
+	resources := &ResourceRequirements{
+		Requests: &ResourceList{CPU: "500m", Memory: "512Mi", EphemeralStorage: "1Gi", Extended: map[string]string{"nvidia.com/gpu": "1"}},
+	}
+
+	tests := []struct {
+		name      string
+		policy    *ResourcePolicy
+		resources *ResourceRequirements
+		expected  *ResourceList
+	}{
+		{
+			name:      "default policy copies requests",
+			policy:    DefaultResourcePolicy(),
+			resources: resources,
+			expected:  &ResourceList{CPU: "500m", Memory: "512Mi", EphemeralStorage: "1Gi"},
+		},
+		{
+			name:      "no cpu limits",
+			policy:    &ResourcePolicy{CPULimit: LimitNone, MemoryLimit: LimitFromRequest},
+			resources: resources,
+			expected:  &ResourceList{Memory: "512Mi", EphemeralStorage: "1Gi"},
+		},
+		{
+			name:   "memory limit equal to request",
+			policy: &ResourcePolicy{CPULimit: LimitFromRequest, MemoryLimit: LimitEqualRequest},
+			resources: &ResourceRequirements{
+				Requests: &ResourceList{CPU: "500m", Memory: "512Mi"},
+				Limits:   &ResourceList{CPU: "1", Memory: "1Gi"},
+			},
+			expected: &ResourceList{CPU: "1", Memory: "512Mi"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := tt.policy.Limits(tt.resources)
+			if limits.CPU != tt.expected.CPU || limits.Memory != tt.expected.Memory || limits.EphemeralStorage != tt.expected.EphemeralStorage {
+				t.Errorf("Expected limits %+v, got %+v", tt.expected, limits)
+			}
+		})
+	}
+
+	limits := DefaultResourcePolicy().Limits(resources)
+	limits.Extended["nvidia.com/gpu"] = "2"
+	if resources.Requests.Extended["nvidia.com/gpu"] != "1" {
+		t.Errorf("Expected requests not to be modified by limits")
+	}
+}
+
+func TestPodResourcePolicy(t *testing.T) {
+	config := &Configuration{ResourcePolicy: &ResourcePolicy{CPULimit: LimitNone, MaxPod: &ResourceList{CPU: "4"}}}
+
+	policy := config.PodResourcePolicy()
+	if policy.CPULimit != LimitNone || policy.MemoryLimit != LimitFromRequest || policy.MaxPod.CPU != "4" {
+		t.Errorf("Expected application policy merged over default, got %+v", policy)
+	}
+}
+
+func TestPodResourcePolicyOrganization(t *testing.T) {
+	config := &Configuration{
+		ResourcePolicy: &ResourcePolicy{CPULimit: LimitFromRequest, MemoryLimit: LimitEqualRequest, MaxPod: &ResourceList{CPU: "8", Memory: "2Gi"}},
+		OrganizationResourcePolicy: &ResourcePolicy{
+			CPULimit: LimitNone,
+			MaxPod:   &ResourceList{CPU: "4", Memory: "8Gi", Extended: map[string]string{"nvidia.com/gpu": "1"}},
+		},
+	}
+
+	policy := config.PodResourcePolicy()
+	if policy.CPULimit != LimitNone || policy.MemoryLimit != LimitEqualRequest {
+		t.Errorf("Expected organization cpu rule and application memory rule, got %+v", policy)
+	}
+	if policy.MaxPod.CPU != "4" || policy.MaxPod.Memory != "2Gi" || policy.MaxPod.Extended["nvidia.com/gpu"] != "1" {
+		t.Errorf("Expected the lowest maximum of organization and application, got %+v", policy.MaxPod)
+	}
+}
+
+func TestResourcePolicyCheckRules(t *testing.T) {
+	if err := (&ResourcePolicy{CPULimit: LimitNone, MaxPod: &ResourceList{CPU: "4"}}).CheckRules(); err != nil {
+		t.Errorf("Expected valid policy, got %v", err)
+	}
+	if err := (&ResourcePolicy{CPULimit: LimitEqualRequest}).CheckRules(); err == nil {
+		t.Errorf("Expected unknown cpuLimit error")
+	}
+	if err := (&ResourcePolicy{MaxPod: &ResourceList{Memory: "8 gigs"}}).CheckRules(); err == nil {
+		t.Errorf("Expected invalid maxPod quantity error")
+	}
+}
+
+func TestCheckQuantities(t *testing.T) {
+	valid := &ResourceRequirements{Requests: &ResourceList{CPU: "1", Memory: "1Gi", Extended: map[string]string{"nvidia.com/gpu": "1"}}}
+	if err := valid.CheckQuantities(); err != nil {
+		t.Errorf("Expected valid quantities, got %v", err)
+	}
+
+	invalid := &ResourceRequirements{Limits: &ResourceList{Memory: "1 gig"}}
+	if err := invalid.CheckQuantities(); err == nil {
+		t.Errorf("Expected invalid memory quantity error")
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+const (
+	// AffinityRequired rejects scheduling which breaks the rule
+	AffinityRequired = "required"
+	// AffinityPreferred allows scheduling which breaks the rule when there is no better node
+	AffinityPreferred = "preferred"
+	// AffinityNone disables the rule
+	AffinityNone = "none"
+
+	defaultNodeSelectorKey         = "dedicated"
+	defaultAntiAffinityTopologyKey = "kubernetes.io/hostname"
+	defaultSpreadTopologyKey       = "topology.kubernetes.io/zone"
+	defaultWhenUnsatisfiable       = "ScheduleAnyway"
+)
+
+// Scheduling represents placement of application pods, by default pods require nodes of the node pool
+// labeled and tainted with "dedicated" key and require different hosts for every pod
+type Scheduling struct {
+	// NodeSelectorKey is the node label and taint key with node pool name, "dedicated" by default
+	NodeSelectorKey string `json:"nodeSelectorKey,omitempty"`
+	// PodAntiAffinity is "required" (default), "preferred" or "none"
+	PodAntiAffinity string `json:"podAntiAffinity,omitempty"`
+	// AntiAffinityTopologyKey is the node label of pod anti-affinity domain, "kubernetes.io/hostname" by default
+	AntiAffinityTopologyKey string `json:"antiAffinityTopologyKey,omitempty"`
+	// TopologySpreadConstraints spread pods across topology domains, e.g. zones
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Tolerations are added to tolerations of node pool and unavailable nodes
+	Tolerations []Toleration `json:"tolerations,omitempty"`
+}
+
+// TopologySpreadConstraint represents even spread of application pods across topology domains
+type TopologySpreadConstraint struct {
+	// TopologyKey is the node label of topology domain, "topology.kubernetes.io/zone" by default
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// MaxSkew is the maximum difference of pod count between domains, 1 by default
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+	// WhenUnsatisfiable is "ScheduleAnyway" (default) or "DoNotSchedule"
+	WhenUnsatisfiable string `json:"whenUnsatisfiable,omitempty"`
+}
+
+// Toleration represents toleration of node taint
+type Toleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+	// TolerationSeconds limits time of NoExecute taint toleration
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
+
+// mergeScheduling returns scheduling settings with fields of override set over base ones,
+// topologySpreadConstraints and tolerations are replaced as a whole list
+func mergeScheduling(base, override *Scheduling) *Scheduling {
+	if base == nil && override == nil {
+		return nil
+	}
+	if base == nil {
+		base = &Scheduling{}
+	}
+	if override == nil {
+		override = &Scheduling{}
+	}
+
+	merged := &Scheduling{
+		NodeSelectorKey:           mergeString(base.NodeSelectorKey, override.NodeSelectorKey),
+		PodAntiAffinity:           mergeString(base.PodAntiAffinity, override.PodAntiAffinity),
+		AntiAffinityTopologyKey:   mergeString(base.AntiAffinityTopologyKey, override.AntiAffinityTopologyKey),
+		TopologySpreadConstraints: base.TopologySpreadConstraints,
+		Tolerations:               base.Tolerations,
+	}
+	if override.TopologySpreadConstraints != nil {
+		merged.TopologySpreadConstraints = override.TopologySpreadConstraints
+	}
+	if override.Tolerations != nil {
+		merged.Tolerations = override.Tolerations
+	}
+
+	return merged
+}
@@ -0,0 +1,24 @@
+package types
+
+import "testing"
+
+func TestMergeScheduling(t *testing.T) {
+	if mergeScheduling(nil, nil) != nil {
+		t.Errorf("Expected nil scheduling when no level sets it")
+	}
+
+	base := &Scheduling{
+		NodeSelectorKey:           "pool",
+		PodAntiAffinity:           AffinityRequired,
+		TopologySpreadConstraints: []TopologySpreadConstraint{{}},
+		Tolerations:               []Toleration{{Key: "spot", Operator: "Exists"}},
+	}
+	merged := mergeScheduling(base, &Scheduling{PodAntiAffinity: AffinityPreferred, Tolerations: []Toleration{}})
+
+	if merged.NodeSelectorKey != "pool" || merged.PodAntiAffinity != AffinityPreferred {
+		t.Errorf("Expected scalar fields merged, got %+v", merged)
+	}
+	if len(merged.TopologySpreadConstraints) != 1 || len(merged.Tolerations) != 0 {
+		t.Errorf("Expected lists replaced as a whole, got %+v", merged)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+const (
+	// SeccompProfileRuntimeDefault is the default seccomp profile of container runtime
+	SeccompProfileRuntimeDefault = "RuntimeDefault"
+	// SeccompProfileUnconfined disables seccomp filtering
+	SeccompProfileUnconfined = "Unconfined"
+)
+
+// SecurityContext represents pod and container security settings, unset fields are inherited from
+// DefaultSecurityContext which satisfies Pod Security Admission "restricted" level
+type SecurityContext struct {
+	RunAsNonRoot *bool  `json:"runAsNonRoot,omitempty"`
+	RunAsUser    *int64 `json:"runAsUser,omitempty"`
+	RunAsGroup   *int64 `json:"runAsGroup,omitempty"`
+	// FSGroup owns mounted volumes, it is applied to the pod only
+	FSGroup                  *int64        `json:"fsGroup,omitempty"`
+	ReadOnlyRootFilesystem   *bool         `json:"readOnlyRootFilesystem,omitempty"`
+	AllowPrivilegeEscalation *bool         `json:"allowPrivilegeEscalation,omitempty"`
+	Privileged               *bool         `json:"privileged,omitempty"`
+	Capabilities             *Capabilities `json:"capabilities,omitempty"`
+	// SeccompProfile is "RuntimeDefault" or "Unconfined"
+	SeccompProfile string `json:"seccompProfile,omitempty"`
+}
+
+// Capabilities represents linux capabilities added to and dropped from container
+type Capabilities struct {
+	Add  []string `json:"add,omitempty"`
+	Drop []string `json:"drop,omitempty"`
+}
+
+// DefaultSecurityContext returns organization-wide security settings of all applications: non-root user,
+// no privilege escalation, all capabilities dropped and runtime default seccomp profile
+func DefaultSecurityContext() *SecurityContext {
+	return &SecurityContext{
+		RunAsNonRoot:             boolPtr(true),
+		AllowPrivilegeEscalation: boolPtr(false),
+		Capabilities:             &Capabilities{Drop: []string{"ALL"}},
+		SeccompProfile:           SeccompProfileRuntimeDefault,
+	}
+}
+
+// PodSecurityContext returns security settings of the application pod, settings of the application
+// override DefaultSecurityContext field by field
+func (c *Configuration) PodSecurityContext() *SecurityContext {
+	return mergeSecurityContext(DefaultSecurityContext(), c.SecurityContext)
+}
+
+// ContainerSecurityContext returns security settings of sidecar or init container, settings of the container
+// override PodSecurityContext field by field
+func (c *Configuration) ContainerSecurityContext(container Container) *SecurityContext {
+	return mergeSecurityContext(c.PodSecurityContext(), container.SecurityContext)
+}
+
+// mergeSecurityContext returns copy of base settings overridden by fields set in override settings,
+// capabilities are replaced as a whole
+func mergeSecurityContext(base, override *SecurityContext) *SecurityContext {
+	if base == nil {
+		base = &SecurityContext{}
+	}
+	if override == nil {
+		override = &SecurityContext{}
+	}
+
+	merged := *base
+	if override.RunAsNonRoot != nil {
+		merged.RunAsNonRoot = override.RunAsNonRoot
+	}
+	if override.RunAsUser != nil {
+		merged.RunAsUser = override.RunAsUser
+	}
+	if override.RunAsGroup != nil {
+		merged.RunAsGroup = override.RunAsGroup
+	}
+	if override.FSGroup != nil {
+		merged.FSGroup = override.FSGroup
+	}
+	if override.ReadOnlyRootFilesystem != nil {
+		merged.ReadOnlyRootFilesystem = override.ReadOnlyRootFilesystem
+	}
+	if override.AllowPrivilegeEscalation != nil {
+		merged.AllowPrivilegeEscalation = override.AllowPrivilegeEscalation
+	}
+	if override.Privileged != nil {
+		merged.Privileged = override.Privileged
+	}
+	if override.Capabilities != nil {
+		merged.Capabilities = override.Capabilities
+	}
+	merged.SeccompProfile = mergeString(base.SeccompProfile, override.SeccompProfile)
+
+	return &merged
+}
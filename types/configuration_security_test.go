@@ -0,0 +1,34 @@
+package types
+
+import "testing"
+
+func TestPodSecurityContext(t *testing.T) {
+	config := &Configuration{Application: "myapp"}
+
+	settings := config.PodSecurityContext()
+	if !*settings.RunAsNonRoot || *settings.AllowPrivilegeEscalation || settings.Capabilities.Drop[0] != "ALL" ||
+		settings.SeccompProfile != SeccompProfileRuntimeDefault {
+		t.Errorf("Expected restricted default security context, got %+v", settings)
+	}
+
+	config.SecurityContext = &SecurityContext{
+		RunAsUser:              int64Ptr(1000),
+		ReadOnlyRootFilesystem: boolPtr(true),
+		Capabilities:           &Capabilities{Add: []string{"NET_BIND_SERVICE"}},
+	}
+	settings = config.PodSecurityContext()
+	if *settings.RunAsUser != 1000 || !*settings.ReadOnlyRootFilesystem || !*settings.RunAsNonRoot {
+		t.Errorf("Expected application settings merged over default, got %+v", settings)
+	}
+	if settings.Capabilities.Drop != nil || settings.Capabilities.Add[0] != "NET_BIND_SERVICE" {
+		t.Errorf("Expected capabilities replaced as a whole, got %+v", settings.Capabilities)
+	}
+	if DefaultSecurityContext().RunAsUser != nil {
+		t.Errorf("Expected default security context not to be modified")
+	}
+
+	container := config.ContainerSecurityContext(Container{Name: "proxy", SecurityContext: &SecurityContext{RunAsUser: int64Ptr(101)}})
+	if *container.RunAsUser != 101 || !*container.ReadOnlyRootFilesystem {
+		t.Errorf("Expected container settings merged over application settings, got %+v", container)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"strings"
+)
+
+const (
+	// TagOrderRegistry keeps order of tags returned by registry, most recently pushed first for Docker Hub
+	TagOrderRegistry = "registry"
+	// TagOrderSemver orders tags by semantic version, tags which aren't semantic versions are skipped
+	TagOrderSemver = "semver"
+	// TagOrderDate orders tags by date parsed with DateFormat, tags which can't be parsed are skipped
+	TagOrderDate = "date"
+
+	// DefaultTagFilter matches YY.MM.DD-HH.MM tags built by CI
+	DefaultTagFilter = `^\d{2}\.\d{2}\.\d{2}\-\d{2}\.\d{2}$`
+	// DefaultSemverTagFilter matches semantic version tags like 1.2.3, v1.2.3 or 1.2.3-rc.1, default filter of semver order
+	DefaultSemverTagFilter = `^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`
+	// DefaultTagDateFormat is Go time layout of DefaultTagFilter tags
+	DefaultTagDateFormat = "06.01.02-15.04"
+)
+
+// TagPolicy selects image tags used as default artifact version and matched by docker trigger
+type TagPolicy struct {
+	// Filter is regular expression tags must match, DefaultTagFilter by default or DefaultSemverTagFilter for semver order
+	Filter string `json:"filter,omitempty"`
+	// Exclude lists regular expressions of tags to skip, e.g. -rc
+	Exclude []string `json:"exclude,omitempty"`
+	// Order is one of TagOrderRegistry (default), TagOrderSemver or TagOrderDate
+	Order string `json:"order,omitempty"`
+	// DateFormat is Go time layout of tags ordered by date, DefaultTagDateFormat by default
+	DateFormat string `json:"dateFormat,omitempty"`
+}
+
+// DefaultTagPolicy returns policy matching YY.MM.DD-HH.MM tags in registry order
+func DefaultTagPolicy() *TagPolicy {
+	return &TagPolicy{
+		Filter:     DefaultTagFilter,
+		Exclude:    []string{},
+		Order:      TagOrderRegistry,
+		DateFormat: DefaultTagDateFormat,
+	}
+}
+
+// ImageTagPolicy returns tag policy of application image, unset fields are defaulted like DefaultTagPolicy does,
+// except filter of semver order which defaults to DefaultSemverTagFilter
+func (c *Configuration) ImageTagPolicy() *TagPolicy {
+	policy := DefaultTagPolicy()
+	if c.TagPolicy == nil {
+		return policy
+	}
+
+	if c.TagPolicy.Order == TagOrderSemver {
+		policy.Filter = DefaultSemverTagFilter
+	}
+	policy.Filter = mergeString(policy.Filter, c.TagPolicy.Filter)
+	policy.Exclude = mergeList(policy.Exclude, c.TagPolicy.Exclude)
+	policy.Order = mergeString(policy.Order, c.TagPolicy.Order)
+	policy.DateFormat = mergeString(policy.DateFormat, c.TagPolicy.DateFormat)
+
+	return policy
+}
+
+// TriggerRegex returns regular expression of docker trigger tag, excluded tags are rejected with negative lookahead
+// supported by Spinnaker
+func (p *TagPolicy) TriggerRegex() string {
+	if len(p.Exclude) == 0 {
+		return p.Filter
+	}
+
+	return "^(?!.*(?:" + strings.Join(p.Exclude, "|") + "))(?:" + p.Filter + ")"
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestImageTagPolicy(t *testing.T) {
+	if policy := (&Configuration{}).ImageTagPolicy(); !reflect.DeepEqual(policy, DefaultTagPolicy()) {
+		t.Errorf("Expected default policy, got %+v", policy)
+	}
+
+	policy := (&Configuration{TagPolicy: &TagPolicy{Order: TagOrderSemver, Filter: `^v?\d+\.\d+\.\d+$`}}).ImageTagPolicy()
+	expected := &TagPolicy{Filter: `^v?\d+\.\d+\.\d+$`, Exclude: []string{}, Order: TagOrderSemver, DateFormat: DefaultTagDateFormat}
+	if !reflect.DeepEqual(policy, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, policy)
+	}
+
+	policy = (&Configuration{TagPolicy: &TagPolicy{Order: TagOrderSemver}}).ImageTagPolicy()
+	if policy.Filter != DefaultSemverTagFilter {
+		t.Errorf("Expected semver filter for semver order, got %q", policy.Filter)
+	}
+	filter := regexp.MustCompile(policy.Filter)
+	for tag, matched := range map[string]bool{"1.2.3": true, "v1.2.3": true, "1.2.3-rc.1": true, "24.01.02-10.00": false, "latest": false} {
+		if filter.MatchString(tag) != matched {
+			t.Errorf("Expected semver filter match of %q to be %v", tag, matched)
+		}
+	}
+}
+
+func TestTriggerRegex(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *TagPolicy
+		expected string
+	}{
+		{
+			name:     "default policy",
+			policy:   DefaultTagPolicy(),
+			expected: `^\d{2}\.\d{2}\.\d{2}\-\d{2}\.\d{2}$`,
+		},
+		{
+			name:     "excluded tags",
+			policy:   &TagPolicy{Filter: `^\d+\.\d+\.\d+.*$`, Exclude: []string{"-rc", "-beta"}},
+			expected: `^(?!.*(?:-rc|-beta))(?:^\d+\.\d+\.\d+.*$)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.policy.TriggerRegex(); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "strings"
+
+// Volume represents volume of the application pod, exactly one source of the volume must be set
+type Volume struct {
+	Name string `json:"name"`
+	// MountPath of the volume in the application container, volume isn't mounted into the application container when empty
+	MountPath string `json:"mountPath,omitempty"`
+	SubPath   string `json:"subPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+
+	ConfigMap             *ConfigMapVolume             `json:"configMap,omitempty"`
+	Secret                *SecretVolume                `json:"secret,omitempty"`
+	EmptyDir              *EmptyDirVolume              `json:"emptyDir,omitempty"`
+	PersistentVolumeClaim *PersistentVolumeClaimVolume `json:"persistentVolumeClaim,omitempty"`
+	Projected             *ProjectedVolume             `json:"projected,omitempty"`
+	CSI                   *CSIVolume                   `json:"csi,omitempty"`
+}
+
+// ConfigMapVolume represents volume with keys of ConfigMap as files
+type ConfigMapVolume struct {
+	Name        string      `json:"name"`
+	Items       []KeyToPath `json:"items,omitempty"`
+	DefaultMode *int32      `json:"defaultMode,omitempty"`
+	Optional    bool        `json:"optional,omitempty"`
+}
+
+// SecretVolume represents volume with keys of Secret as files
+type SecretVolume struct {
+	SecretName  string      `json:"secretName"`
+	Items       []KeyToPath `json:"items,omitempty"`
+	DefaultMode *int32      `json:"defaultMode,omitempty"`
+	Optional    bool        `json:"optional,omitempty"`
+}
+
+// KeyToPath represents key of ConfigMap or Secret projected into file of the volume
+type KeyToPath struct {
+	Key  string `json:"key"`
+	Path string `json:"path"`
+}
+
+// EmptyDirVolume represents temporary directory sharing the pod lifetime
+type EmptyDirVolume struct {
+	// Medium is "" for node disk or "Memory" for tmpfs
+	Medium string `json:"medium,omitempty"`
+	// SizeLimit is the quantity of local storage, e.g. "1Gi"
+	SizeLimit string `json:"sizeLimit,omitempty"`
+}
+
+// PersistentVolumeClaimVolume represents volume of existing PersistentVolumeClaim
+type PersistentVolumeClaimVolume struct {
+	ClaimName string `json:"claimName"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// ProjectedVolume represents several volume sources projected into the same directory
+type ProjectedVolume struct {
+	Sources     []ProjectedSource `json:"sources"`
+	DefaultMode *int32            `json:"defaultMode,omitempty"`
+}
+
+// ProjectedSource represents single source of projected volume, exactly one field must be set
+type ProjectedSource struct {
+	ConfigMap           *ConfigMapVolume           `json:"configMap,omitempty"`
+	Secret              *SecretVolume              `json:"secret,omitempty"`
+	ServiceAccountToken *ServiceAccountTokenVolume `json:"serviceAccountToken,omitempty"`
+}
+
+// ServiceAccountTokenVolume represents token of the application service account with custom audience
+type ServiceAccountTokenVolume struct {
+	Audience          string `json:"audience,omitempty"`
+	ExpirationSeconds int64  `json:"expirationSeconds,omitempty"`
+	Path              string `json:"path"`
+}
+
+// CSIVolume represents ephemeral volume provided by CSI driver, e.g. secrets store
+type CSIVolume struct {
+	Driver           string            `json:"driver"`
+	ReadOnly         bool              `json:"readOnly,omitempty"`
+	FSType           string            `json:"fsType,omitempty"`
+	VolumeAttributes map[string]string `json:"volumeAttributes,omitempty"`
+	// NodePublishSecretName is the name of Secret passed to the driver
+	NodePublishSecretName string `json:"nodePublishSecretName,omitempty"`
+}
+
+// PodVolumes returns volumes of the application pod: volumes expanded from dependency conventions
+// followed by volumes of the application, which replace dependency volumes with the same name
+func (c *Configuration) PodVolumes() []Volume {
+	volumes := []Volume{}
+	index := map[string]int{}
+
+	for _, volume := range append(dependencyVolumes(c.Application, c.DependsOn), c.Volumes...) {
+		if i, ok := index[volume.Name]; ok {
+			volumes[i] = volume
+			continue
+		}
+		index[volume.Name] = len(volumes)
+		volumes = append(volumes, volume)
+	}
+
+	return volumes
+}
+
+// dependencyVolumes expands dependency conventions into volumes: "<name>-config" ConfigMap is mounted
+// as /app/conf/<name>.conf, "GoogleCloudStorage" credentials as /google-cloud-<application>
+// and "maxmind" GeoIP databases as /usr/share/GeoIP
+func dependencyVolumes(application string, dependencies []DependsOn) []Volume {
+	volumes := []Volume{}
+
+	for _, dependency := range dependencies {
+		if strings.HasSuffix(dependency.Name, "-config") {
+			name := strings.Replace(dependency.Name, "-config", "", 1)
+			volumes = append(volumes, Volume{
+				Name:      name,
+				MountPath: "/app/conf/" + name + ".conf",
+				SubPath:   name + ".conf",
+				ConfigMap: &ConfigMapVolume{Name: name},
+			})
+		}
+
+		if dependency.Name == "GoogleCloudStorage" {
+			volumes = append(volumes, Volume{
+				Name:      "google-cloud-" + application,
+				MountPath: "/google-cloud-" + application,
+				ReadOnly:  true,
+				Secret:    &SecretVolume{SecretName: "google-cloud-" + application},
+			})
+		}
+
+		if dependency.Name == "maxmind" {
+			volumes = append(volumes, Volume{
+				Name:      volumeNameGeoIPFiles,
+				MountPath: "/usr/share/GeoIP",
+				EmptyDir:  &EmptyDirVolume{},
+			})
+		}
+	}
+
+	return volumes
+}
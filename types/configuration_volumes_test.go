@@ -0,0 +1,50 @@
+package types
+
+import "testing"
+
+func TestPodVolumes(t *testing.T) {
+	config := &Configuration{
+		Application: "myapp",
+		DependsOn:   []DependsOn{{Name: "nginx-config"}, {Name: "GoogleCloudStorage"}, {Name: "maxmind"}, {Name: "redis"}},
+		Volumes: []Volume{
+			{Name: "cache", MountPath: "/cache", EmptyDir: &EmptyDirVolume{SizeLimit: "1Gi"}},
+			{Name: "nginx", MountPath: "/etc/nginx", ConfigMap: &ConfigMapVolume{Name: "nginx"}},
+		},
+	}
+
+	volumes := config.PodVolumes()
+	if len(volumes) != 4 {
+		t.Fatalf("Expected 4 volumes, got %+v", volumes)
+	}
+
+	nginx := volumes[0]
+	if nginx.Name != "nginx" || nginx.MountPath != "/etc/nginx" || nginx.SubPath != "" {
+		t.Errorf("Expected application volume to replace dependency volume, got %+v", nginx)
+	}
+
+	storage := volumes[1]
+	if storage.Name != "google-cloud-myapp" || !storage.ReadOnly || storage.Secret == nil || storage.Secret.SecretName != "google-cloud-myapp" {
+		t.Errorf("Unexpected GoogleCloudStorage volume %+v", storage)
+	}
+
+	geoip := volumes[2]
+	if geoip.Name != volumeNameGeoIPFiles || geoip.MountPath != "/usr/share/GeoIP" || geoip.EmptyDir == nil {
+		t.Errorf("Unexpected maxmind volume %+v", geoip)
+	}
+
+	if volumes[3].Name != "cache" {
+		t.Errorf("Expected application volume after dependency volumes, got %+v", volumes[3])
+	}
+}
+
+func TestDependencyVolumes(t *testing.T) {
+	volumes := dependencyVolumes("myapp", []DependsOn{{Name: "nginx-config"}})
+	if len(volumes) != 1 {
+		t.Fatalf("Expected 1 volume, got %d", len(volumes))
+	}
+
+	volume := volumes[0]
+	if volume.MountPath != "/app/conf/nginx.conf" || volume.SubPath != "nginx.conf" || volume.ConfigMap.Name != "nginx" {
+		t.Errorf("Unexpected configmap volume %+v", volume)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+const (
+	// WorkloadTypeService is Deployment exposed by Service
+	WorkloadTypeService = "service"
+	// WorkloadTypeStatefulSet is StatefulSet with headless Service and persistent volume claims
+	WorkloadTypeStatefulSet = "statefulset"
+	// WorkloadTypeCronJob is CronJob running the application by schedule
+	WorkloadTypeCronJob = "cronjob"
+	// WorkloadTypeJob is Job running the application once per deploy
+	WorkloadTypeJob = "job"
+)
+
+// CronJobSettings represents schedule of cronjob application
+type CronJobSettings struct {
+	// Schedule in cron format, e.g. "*/5 * * * *"
+	Schedule string `json:"schedule"`
+	// TimeZone of the schedule, e.g. "Etc/UTC", time zone of kube-controller-manager by default
+	TimeZone string `json:"timeZone,omitempty"`
+	// ConcurrencyPolicy is "Allow", "Forbid" (default) or "Replace"
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+	// StartingDeadlineSeconds is deadline for starting the job if it misses scheduled time
+	StartingDeadlineSeconds int64 `json:"startingDeadlineSeconds,omitempty"`
+	// SuccessfulJobsHistoryLimit is the number of successful finished jobs to keep
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit is the number of failed finished jobs to keep
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+	// Suspend stops scheduling of new jobs
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// JobSettings represents settings of jobs of job and cronjob applications
+type JobSettings struct {
+	// BackoffLimit is the number of retries before job is marked as failed, kubernetes default is 6
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds is the duration of the job after which it is terminated
+	ActiveDeadlineSeconds int64 `json:"activeDeadlineSeconds,omitempty"`
+	// TTLSecondsAfterFinished is the duration after which finished job is deleted
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// Completions is the number of successfully finished pods of the job
+	Completions int32 `json:"completions,omitempty"`
+	// Parallelism is the number of pods of the job running at the same time
+	Parallelism int32 `json:"parallelism,omitempty"`
+	// RestartPolicy of job pods is "OnFailure" (default) or "Never"
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+}
+
+// StatefulSetSettings represents settings of statefulset application
+type StatefulSetSettings struct {
+	// PodManagementPolicy is "OrderedReady" (default) or "Parallel"
+	PodManagementPolicy string `json:"podManagementPolicy,omitempty"`
+	// VolumeClaimTemplates are persistent volumes created for every pod and mounted to the application container
+	VolumeClaimTemplates []VolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+}
+
+// VolumeClaimTemplate represents persistent volume claim of statefulset pod
+type VolumeClaimTemplate struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	// Size of the volume, e.g. "10Gi"
+	Size string `json:"size"`
+	// StorageClassName of the volume, default storage class is used when it isn't set
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// AccessModes of the volume, "ReadWriteOnce" by default
+	AccessModes []string `json:"accessModes,omitempty"`
+}
+
+// WorkloadKind returns kind of kubernetes workload of the application, Deployment is used for service and worker applications
+func (c *Configuration) WorkloadKind() string {
+	switch c.Type {
+	case WorkloadTypeStatefulSet:
+		return "StatefulSet"
+	case WorkloadTypeCronJob:
+		return "CronJob"
+	case WorkloadTypeJob:
+		return "Job"
+	default:
+		return "Deployment"
+	}
+}
+
+// HasService checks if the application is exposed by Service
+func (c *Configuration) HasService() bool {
+	return c.Type == WorkloadTypeService || c.Type == WorkloadTypeStatefulSet
+}
+
+// IsBatch checks if the application runs to completion as Job or CronJob
+func (c *Configuration) IsBatch() bool {
+	return c.Type == WorkloadTypeCronJob || c.Type == WorkloadTypeJob
+}
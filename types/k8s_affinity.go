@@ -21,16 +21,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// newAffinity return k8s affinity object for deployment
-func newAffinity(application, nodepool string) *apiv1.Affinity {
-	return &apiv1.Affinity{
+// newAffinity return k8s affinity object for deployment, pods require nodes of the node pool and,
+// unless disabled by scheduling settings, different hosts for every pod
+func newAffinity(application, nodepool string, scheduling *Scheduling) *apiv1.Affinity {
+	if scheduling == nil {
+		scheduling = &Scheduling{}
+	}
+
+	affinity := &apiv1.Affinity{
 		NodeAffinity: &apiv1.NodeAffinity{
 			RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
 				NodeSelectorTerms: []apiv1.NodeSelectorTerm{
 					{
 						MatchExpressions: []apiv1.NodeSelectorRequirement{
 							{
-								Key:      "dedicated",
+								Key:      mergeString(defaultNodeSelectorKey, scheduling.NodeSelectorKey),
 								Operator: apiv1.NodeSelectorOperator("In"),
 								Values:   []string{nodepool},
 							},
@@ -39,21 +44,34 @@ func newAffinity(application, nodepool string) *apiv1.Affinity {
 				},
 			},
 		},
-		PodAntiAffinity: &apiv1.PodAntiAffinity{
-			RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{
+	}
+
+	podAffinityTerm := apiv1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
 				{
-					LabelSelector: &metav1.LabelSelector{
-						MatchExpressions: []metav1.LabelSelectorRequirement{
-							{
-								Key:      kubernetesLabelKeyApp,
-								Operator: metav1.LabelSelectorOperator("In"),
-								Values:   []string{application},
-							},
-						},
-					},
-					TopologyKey: "kubernetes.io/hostname",
+					Key:      kubernetesLabelKeyApp,
+					Operator: metav1.LabelSelectorOperator("In"),
+					Values:   []string{application},
 				},
 			},
 		},
+		TopologyKey: mergeString(defaultAntiAffinityTopologyKey, scheduling.AntiAffinityTopologyKey),
+	}
+
+	switch scheduling.PodAntiAffinity {
+	case AffinityNone:
+	case AffinityPreferred:
+		affinity.PodAntiAffinity = &apiv1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []apiv1.WeightedPodAffinityTerm{
+				{Weight: 100, PodAffinityTerm: podAffinityTerm},
+			},
+		}
+	default:
+		affinity.PodAntiAffinity = &apiv1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{podAffinityTerm},
+		}
 	}
+
+	return affinity
 }
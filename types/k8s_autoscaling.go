@@ -0,0 +1,190 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultTargetCPUUtilization is used when autoscaling doesn't define any metric
+	defaultTargetCPUUtilization = 80
+	// defaultScaleDownPeriodSeconds is the period of scale down policies
+	defaultScaleDownPeriodSeconds = 60
+)
+
+// NewHorizontalPodAutoscaler return k8s autoscaling/v2 HorizontalPodAutoscaler object scaling application workload,
+// nil is returned when autoscaling isn't set on the datacenter or application is a job
+func NewHorizontalPodAutoscaler(config *Configuration, tier *Datacenter, stage, organization string) *autoscalingv2.HorizontalPodAutoscaler {
+	if tier.Autoscaling == nil || config.IsBatch() {
+		return nil
+	}
+
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        application,
+			Namespace:   config.Namespace,
+			Annotations: defaultAnnotations(organization, stage, config.Owners),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       config.WorkloadKind(),
+				Name:       application,
+			},
+			MinReplicas: int32Ptr(tier.MinReplicas()),
+			MaxReplicas: tier.Autoscaling.MaxReplicas,
+			Metrics:     newAutoscalingMetrics(tier.Autoscaling),
+			Behavior:    newAutoscalingBehavior(tier.Autoscaling.ScaleDown),
+		},
+	}
+}
+
+// newAutoscalingMetrics return HPA metrics, CPU utilization is used when no metric is set
+func newAutoscalingMetrics(autoscaling *Autoscaling) []autoscalingv2.MetricSpec {
+	metrics := []autoscalingv2.MetricSpec{}
+
+	targetCPUUtilization := autoscaling.TargetCPUUtilization
+	if targetCPUUtilization == 0 && autoscaling.TargetMemoryUtilization == 0 && len(autoscaling.Metrics) == 0 {
+		targetCPUUtilization = defaultTargetCPUUtilization
+	}
+
+	if targetCPUUtilization != 0 {
+		metrics = append(metrics, newResourceMetric(apiv1.ResourceCPU, targetCPUUtilization))
+	}
+	if autoscaling.TargetMemoryUtilization != 0 {
+		metrics = append(metrics, newResourceMetric(apiv1.ResourceMemory, autoscaling.TargetMemoryUtilization))
+	}
+
+	for _, metric := range autoscaling.Metrics {
+		identifier := autoscalingv2.MetricIdentifier{Name: metric.Name}
+		if len(metric.Selector) != 0 {
+			identifier.Selector = &metav1.LabelSelector{MatchLabels: metric.Selector}
+		}
+
+		target := autoscalingv2.MetricTarget{}
+		if metric.TargetAverageValue != "" {
+			target.Type = autoscalingv2.AverageValueMetricType
+			target.AverageValue = quantityPtr(metric.TargetAverageValue)
+		} else {
+			target.Type = autoscalingv2.ValueMetricType
+			target.Value = quantityPtr(metric.TargetValue)
+		}
+
+		if metric.Type == "external" {
+			metrics = append(metrics, autoscalingv2.MetricSpec{
+				Type:     autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{Metric: identifier, Target: target},
+			})
+			continue
+		}
+
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{Metric: identifier, Target: target},
+		})
+	}
+
+	return metrics
+}
+
+// newResourceMetric return HPA metric targeting average utilization of the resource
+func newResourceMetric(name apiv1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: int32Ptr(utilization),
+			},
+		},
+	}
+}
+
+// newAutoscalingBehavior return HPA scale down behavior, nil is returned for default kubernetes behavior
+func newAutoscalingBehavior(scaleDown *ScaleDownBehavior) *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	if scaleDown == nil {
+		return nil
+	}
+
+	periodSeconds := scaleDown.PeriodSeconds
+	if periodSeconds == 0 {
+		periodSeconds = defaultScaleDownPeriodSeconds
+	}
+
+	rules := &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: scaleDown.StabilizationWindowSeconds,
+	}
+	if scaleDown.Percent != 0 {
+		rules.Policies = append(rules.Policies, autoscalingv2.HPAScalingPolicy{
+			Type:          autoscalingv2.PercentScalingPolicy,
+			Value:         scaleDown.Percent,
+			PeriodSeconds: periodSeconds,
+		})
+	}
+	if scaleDown.Pods != 0 {
+		rules.Policies = append(rules.Policies, autoscalingv2.HPAScalingPolicy{
+			Type:          autoscalingv2.PodsScalingPolicy,
+			Value:         scaleDown.Pods,
+			PeriodSeconds: periodSeconds,
+		})
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscalerBehavior{ScaleDown: rules}
+}
+
+// CheckQuantities returns error of the first invalid target value of custom metrics
+func (a *Autoscaling) CheckQuantities() error {
+	if a == nil {
+		return nil
+	}
+
+	for _, metric := range a.Metrics {
+		for _, value := range []string{metric.TargetAverageValue, metric.TargetValue} {
+			if value == "" {
+				continue
+			}
+			if _, err := resource.ParseQuantity(value); err != nil {
+				return fmt.Errorf("invalid target of metric %q %q: %v", metric.Name, value, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// quantityPtr return pointer to parsed quantity, it panics on invalid quantity,
+// so settings must be checked with Autoscaling.CheckQuantities first
+func quantityPtr(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+	return &quantity
+}
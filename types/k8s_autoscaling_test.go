@@ -0,0 +1,144 @@
+package types
+
+import (
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// newTestApplication returns application with single datacenter which can be rendered into manifests
+func newTestApplication() (*Configuration, *Datacenter) {
+	tier := &Datacenter{
+		TierName:      "gke1",
+		Replicas:      2,
+		Resources:     &ResourceRequirements{Requests: &ResourceList{CPU: "100m", Memory: "128Mi"}},
+		LivenessProbe: &Probe{Type: "http", Port: 8080},
+		ChaosMonkey:   &ChaosMonkey{},
+	}
+
+	return &Configuration{
+		Application: "myapp",
+		DockerImage: "myapp",
+		Namespace:   "default",
+		Type:        "worker",
+		Profiles:    &[]*Profile{{ProfileName: stageProduction, Datacenters: &[]*Datacenter{tier}}},
+	}, tier
+}
+
+func TestNewHorizontalPodAutoscaler(t *testing.T) {
+	window := int32(300)
+
+	tests := []struct {
+		name        string
+		stage       string
+		autoscaling *Autoscaling
+		validate    func(*testing.T, *autoscalingv2.HorizontalPodAutoscaler)
+	}{
+		{
+			name:        "cpu utilization by default",
+			stage:       stageProduction,
+			autoscaling: &Autoscaling{MaxReplicas: 10},
+			validate: func(t *testing.T, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				if hpa.Name != "myapp" || hpa.Spec.ScaleTargetRef.Name != "myapp" || hpa.Spec.ScaleTargetRef.Kind != "Deployment" {
+					t.Errorf("Unexpected name %q or target %+v", hpa.Name, hpa.Spec.ScaleTargetRef)
+				}
+				if hpa.Annotations["service.myorg.dev/generated"] != "spini/v1" || hpa.Annotations["moniker.spinnaker.io/stack"] != stageProduction {
+					t.Errorf("Expected default annotations, got %v", hpa.Annotations)
+				}
+				if *hpa.Spec.MinReplicas != 2 || hpa.Spec.MaxReplicas != 10 {
+					t.Errorf("Expected 2-10 replicas, got %d-%d", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+				}
+				if len(hpa.Spec.Metrics) != 1 || hpa.Spec.Metrics[0].Resource.Name != apiv1.ResourceCPU ||
+					*hpa.Spec.Metrics[0].Resource.Target.AverageUtilization != 80 {
+					t.Errorf("Expected 80%% cpu utilization metric, got %+v", hpa.Spec.Metrics)
+				}
+				if hpa.Spec.Behavior != nil {
+					t.Errorf("Expected default behavior, got %+v", hpa.Spec.Behavior)
+				}
+			},
+		},
+		{
+			name:  "memory and custom metrics with scale down behavior",
+			stage: "beta",
+			autoscaling: &Autoscaling{
+				MinReplicas:             3,
+				MaxReplicas:             6,
+				TargetMemoryUtilization: 70,
+				Metrics: []AutoscalingMetric{
+					{Type: "pods", Name: "requests_per_second", TargetAverageValue: "100"},
+					{Type: "external", Name: "queue_depth", Selector: map[string]string{"queue": "jobs"}, TargetValue: "30"},
+				},
+				ScaleDown: &ScaleDownBehavior{StabilizationWindowSeconds: &window, Percent: 10, Pods: 1},
+			},
+			validate: func(t *testing.T, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+				if hpa.Name != "myapp-beta" || *hpa.Spec.MinReplicas != 3 {
+					t.Errorf("Unexpected name %q or min replicas %d", hpa.Name, *hpa.Spec.MinReplicas)
+				}
+				if len(hpa.Spec.Metrics) != 3 {
+					t.Fatalf("Expected 3 metrics, got %d", len(hpa.Spec.Metrics))
+				}
+				if hpa.Spec.Metrics[0].Resource.Name != apiv1.ResourceMemory {
+					t.Errorf("Expected memory metric, got %+v", hpa.Spec.Metrics[0])
+				}
+				pods := hpa.Spec.Metrics[1].Pods
+				if pods == nil || pods.Target.Type != autoscalingv2.AverageValueMetricType ||
+					!pods.Target.AverageValue.Equal(resource.MustParse("100")) {
+					t.Errorf("Unexpected pods metric %+v", hpa.Spec.Metrics[1])
+				}
+				external := hpa.Spec.Metrics[2].External
+				if external == nil || external.Metric.Selector.MatchLabels["queue"] != "jobs" ||
+					external.Target.Type != autoscalingv2.ValueMetricType {
+					t.Errorf("Unexpected external metric %+v", hpa.Spec.Metrics[2])
+				}
+				scaleDown := hpa.Spec.Behavior.ScaleDown
+				if *scaleDown.StabilizationWindowSeconds != 300 || len(scaleDown.Policies) != 2 ||
+					scaleDown.Policies[0].PeriodSeconds != 60 {
+					t.Errorf("Unexpected scale down behavior %+v", scaleDown)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, tier := newTestApplication()
+			tier.Autoscaling = tt.autoscaling
+
+			hpa := NewHorizontalPodAutoscaler(config, tier, tt.stage, "myorg")
+			if hpa == nil {
+				t.Fatal("NewHorizontalPodAutoscaler returned nil")
+			}
+			tt.validate(t, hpa)
+		})
+	}
+}
+
+func TestDeploymentReplicasWithAutoscaling(t *testing.T) {
+	config, tier := newTestApplication()
+
+	if hpa := NewHorizontalPodAutoscaler(config, tier, stageProduction, "myorg"); hpa != nil {
+		t.Errorf("Expected no HorizontalPodAutoscaler without autoscaling, got %+v", hpa)
+	}
+	if replicas := NewDeployment(config, tier, stageProduction, "myorg").Spec.Replicas; replicas == nil || *replicas != 2 {
+		t.Errorf("Expected 2 replicas without autoscaling, got %v", replicas)
+	}
+
+	tier.Autoscaling = &Autoscaling{MaxReplicas: 4}
+	if replicas := NewDeployment(config, tier, stageProduction, "myorg").Spec.Replicas; replicas != nil {
+		t.Errorf("Expected replicas to be omitted with autoscaling, got %d", *replicas)
+	}
+}
+
+func TestAutoscalingCheckQuantities(t *testing.T) {
+	valid := &Autoscaling{Metrics: []AutoscalingMetric{{Name: "requests", TargetAverageValue: "500m"}, {Name: "queue", TargetValue: "30"}}}
+	if err := valid.CheckQuantities(); err != nil {
+		t.Errorf("Expected valid quantities, got %v", err)
+	}
+
+	invalid := &Autoscaling{Metrics: []AutoscalingMetric{{Name: "requests", TargetAverageValue: "100 rps"}}}
+	if err := invalid.CheckQuantities(); err == nil {
+		t.Errorf("Expected invalid target error")
+	}
+}
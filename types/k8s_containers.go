@@ -17,12 +17,17 @@ limitations under the License.
 package types
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// defaultResourceFieldDivisor is divisor of resource field reference, zero divisor isn't omitted from manifest
+const defaultResourceFieldDivisor = "1"
+
 // newContainer return set of k8s container objects
 func newContainer(config *Configuration, tier *Datacenter, organization, stage string) []apiv1.Container {
 	application := config.Application
@@ -31,8 +36,7 @@ func newContainer(config *Configuration, tier *Datacenter, organization, stage s
 	}
 
 	listContainers := []apiv1.Container{}
-	containerPorts := []apiv1.ContainerPort{}
-	containerEnvs := []apiv1.EnvVar{
+	envs := []EnvVar{
 		{
 			Name:  "API_NAME",
 			Value: application,
@@ -42,26 +46,10 @@ func newContainer(config *Configuration, tier *Datacenter, organization, stage s
 			Value: tier.TierName,
 		},
 	}
-	containerEnvsFrom := []apiv1.EnvFromSource{}
-
-	for _, port := range config.Ports {
-		containerPort := apiv1.ContainerPort{
-			Name:          port.Name,
-			ContainerPort: port.ContainerPort,
-		}
-		containerPorts = append(containerPorts, containerPort)
-	}
-
 	if tier.Env != nil {
-		for _, env := range *tier.Env {
-			containerEnv := apiv1.EnvVar{
-				Name:  env.Name,
-				Value: env.Value,
-			}
-			containerEnvs = append(containerEnvs, containerEnv)
-		}
+		envs = append(envs, *tier.Env...)
 	}
-	sort.Slice(containerEnvs, func(i, j int) bool { return containerEnvs[i].Name < containerEnvs[j].Name })
+	containerEnvsFrom := []apiv1.EnvFromSource{}
 
 	for _, envFromFile := range append(config.EnvFrom, tier.EnvFrom...) {
 		envFrom := strings.Replace(envFromFile, "-configmap", "", 1)
@@ -74,18 +62,20 @@ func newContainer(config *Configuration, tier *Datacenter, organization, stage s
 		}
 		containerEnvsFrom = append(containerEnvsFrom, containerEnvFrom)
 	}
+	containerEnvsFrom = append(containerEnvsFrom, newEnvFromSources(mergeEnvFromSources(config.EnvFromSources, tier.EnvFromSources))...)
 
 	listContainers = append(listContainers, apiv1.Container{
-		Name:          application,
-		Image:         dockerHubUrl + organization + "/" + config.DockerImage,
-		Ports:         containerPorts,
-		Env:           containerEnvs,
-		Resources:     newResourceRequirements(tier),
-		VolumeMounts:  newVolumeMount(config.Application, config.DependsOn),
-		LivenessProbe: newLivenessProbe(tier),
+		Name:            application,
+		Image:           config.ImageRegistry(organization).ImageName(config.DockerImage),
+		Ports:           newContainerPorts(config.Ports),
+		Env:             newEnvVars(envs),
+		Resources:       newResourceRequirements(tier.Resources, config.PodResourcePolicy()),
+		VolumeMounts:    newVolumeMount(config.PodVolumes()),
+		LivenessProbe:   newLivenessProbe(tier),
+		SecurityContext: newContainerSecurityContext(config.PodSecurityContext(), nil),
 	})
 
-	if config.Type == "service" {
+	if config.HasService() {
 		listContainers[len(listContainers)-1].ReadinessProbe = newReadinessProbe(tier)
 	}
 
@@ -103,3 +93,177 @@ func newContainer(config *Configuration, tier *Datacenter, organization, stage s
 
 	return listContainers
 }
+
+// newContainers return k8s container objects of sidecars and init containers, security settings of the container
+// override settings of the application pod
+func newContainers(containers []Container, registry *Registry, settings *SecurityContext, policy *ResourcePolicy) []apiv1.Container {
+	listContainers := []apiv1.Container{}
+
+	for _, container := range containers {
+		c := apiv1.Container{
+			Name:         container.Name,
+			Image:        container.ImageName(registry),
+			Command:      container.Command,
+			Args:         container.Args,
+			Ports:        newContainerPorts(container.Ports),
+			Env:          newEnvVars(container.Env),
+			Resources:    newResourceRequirements(container.Resources, policy),
+			VolumeMounts: newContainerVolumeMounts(container.VolumeMounts),
+			SecurityContext: newContainerSecurityContext(
+				mergeSecurityContext(settings, container.SecurityContext),
+				container.SecurityContext),
+		}
+
+		if container.RestartPolicy != "" {
+			restartPolicy := apiv1.ContainerRestartPolicy(container.RestartPolicy)
+			c.RestartPolicy = &restartPolicy
+		}
+
+		listContainers = append(listContainers, c)
+	}
+
+	return listContainers
+}
+
+// newContainerPorts return k8s container ports
+func newContainerPorts(ports []Port) []apiv1.ContainerPort {
+	containerPorts := []apiv1.ContainerPort{}
+
+	for _, port := range ports {
+		containerPorts = append(containerPorts, apiv1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      apiv1.Protocol(port.Protocol),
+		})
+	}
+
+	return containerPorts
+}
+
+// newEnvVars return k8s environment variables of container sorted by name, variables with valueFrom go first,
+// so literal values can reference them as $(NAME)
+func newEnvVars(envs []EnvVar) []apiv1.EnvVar {
+	containerEnvs := []apiv1.EnvVar{}
+
+	for _, env := range envs {
+		containerEnvs = append(containerEnvs, apiv1.EnvVar{
+			Name:      env.Name,
+			Value:     env.Value,
+			ValueFrom: newEnvVarSource(env.ValueFrom),
+		})
+	}
+	sort.Slice(containerEnvs, func(i, j int) bool {
+		if (containerEnvs[i].ValueFrom == nil) != (containerEnvs[j].ValueFrom == nil) {
+			return containerEnvs[i].ValueFrom != nil
+		}
+		return containerEnvs[i].Name < containerEnvs[j].Name
+	})
+
+	return containerEnvs
+}
+
+// CheckQuantities returns error of invalid divisor of resource field reference
+func (e EnvVar) CheckQuantities() error {
+	if e.ValueFrom == nil || e.ValueFrom.ResourceFieldRef == nil || e.ValueFrom.ResourceFieldRef.Divisor == "" {
+		return nil
+	}
+	if _, err := resource.ParseQuantity(e.ValueFrom.ResourceFieldRef.Divisor); err != nil {
+		return fmt.Errorf("invalid divisor of variable %q %q: %v", e.Name, e.ValueFrom.ResourceFieldRef.Divisor, err)
+	}
+
+	return nil
+}
+
+// newEnvVarSource return k8s source of environment variable value, nil is returned for literal values,
+// divisor of resource field reference is checked with EnvVar.CheckQuantities before generation
+func newEnvVarSource(source *EnvVarSource) *apiv1.EnvVarSource {
+	if source == nil {
+		return nil
+	}
+
+	envSource := &apiv1.EnvVarSource{}
+
+	if ref := source.SecretKeyRef; ref != nil {
+		envSource.SecretKeyRef = &apiv1.SecretKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
+		}
+		if ref.Optional {
+			envSource.SecretKeyRef.Optional = &ref.Optional
+		}
+	}
+
+	if ref := source.ConfigMapKeyRef; ref != nil {
+		envSource.ConfigMapKeyRef = &apiv1.ConfigMapKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
+		}
+		if ref.Optional {
+			envSource.ConfigMapKeyRef.Optional = &ref.Optional
+		}
+	}
+
+	if ref := source.FieldRef; ref != nil {
+		envSource.FieldRef = &apiv1.ObjectFieldSelector{FieldPath: ref.FieldPath}
+	}
+
+	if ref := source.ResourceFieldRef; ref != nil {
+		divisor := ref.Divisor
+		if divisor == "" {
+			divisor = defaultResourceFieldDivisor
+		}
+		envSource.ResourceFieldRef = &apiv1.ResourceFieldSelector{
+			ContainerName: ref.ContainerName,
+			Resource:      ref.Resource,
+			Divisor:       resource.MustParse(divisor),
+		}
+	}
+
+	return envSource
+}
+
+// newEnvFromSources return k8s sources of environment variables from secrets and configmaps
+func newEnvFromSources(sources []EnvFromSource) []apiv1.EnvFromSource {
+	envsFrom := []apiv1.EnvFromSource{}
+
+	for _, source := range sources {
+		envFrom := apiv1.EnvFromSource{Prefix: source.Prefix}
+
+		var optional *bool
+		if source.Optional {
+			optional = &source.Optional
+		}
+
+		if source.Secret != "" {
+			envFrom.SecretRef = &apiv1.SecretEnvSource{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: source.Secret},
+				Optional:             optional,
+			}
+		} else {
+			envFrom.ConfigMapRef = &apiv1.ConfigMapEnvSource{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: source.ConfigMap},
+				Optional:             optional,
+			}
+		}
+
+		envsFrom = append(envsFrom, envFrom)
+	}
+
+	return envsFrom
+}
+
+// newContainerVolumeMounts return k8s volume mounts of sidecar or init container
+func newContainerVolumeMounts(mounts []VolumeMount) []apiv1.VolumeMount {
+	volumeMounts := []apiv1.VolumeMount{}
+
+	for _, mount := range mounts {
+		volumeMounts = append(volumeMounts, apiv1.VolumeMount{
+			Name:      mount.Name,
+			MountPath: mount.MountPath,
+			SubPath:   mount.SubPath,
+			ReadOnly:  mount.ReadOnly,
+		})
+	}
+
+	return volumeMounts
+}
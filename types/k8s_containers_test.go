@@ -0,0 +1,144 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNewContainers(t *testing.T) {
+	containers := newContainers([]Container{
+		{
+			Name:          "agent",
+			Image:         "agent:1.0",
+			Args:          []string{"--port=9000"},
+			Env:           []EnvVar{{Name: "B", Value: "2"}, {Name: "A", Value: "1"}},
+			Ports:         []Port{{Name: "agent", ContainerPort: 9000, Protocol: "UDP"}},
+			Resources:     &ResourceRequirements{Requests: &ResourceList{CPU: "10m"}},
+			VolumeMounts:  []VolumeMount{{Name: "data", MountPath: "/data", ReadOnly: true}},
+			RestartPolicy: ContainerRestartPolicyAlways,
+		},
+		{Name: "proxy", Image: "docker.io/envoyproxy/envoy:v1.30.1"},
+	}, DefaultRegistry("myorg"), DefaultSecurityContext(), DefaultResourcePolicy())
+
+	if len(containers) != 2 {
+		t.Fatalf("Expected 2 containers, got %d", len(containers))
+	}
+
+	agent := containers[0]
+	if agent.Image != "index.docker.io/myorg/agent:1.0" || agent.Args[0] != "--port=9000" {
+		t.Errorf("Unexpected image %q and args %v", agent.Image, agent.Args)
+	}
+	if agent.Env[0].Name != "A" || agent.Env[1].Name != "B" {
+		t.Errorf("Expected env sorted by name, got %v", agent.Env)
+	}
+	if agent.Ports[0].ContainerPort != 9000 || agent.Ports[0].Protocol != apiv1.ProtocolUDP {
+		t.Errorf("Unexpected ports %v", agent.Ports)
+	}
+	if agent.Resources.Limits[apiv1.ResourceCPU] != resource.MustParse("10m") {
+		t.Errorf("Expected CPU limit from requests, got %v", agent.Resources.Limits)
+	}
+	if _, ok := agent.Resources.Limits[apiv1.ResourceMemory]; ok {
+		t.Errorf("Expected no memory limit, got %v", agent.Resources.Limits)
+	}
+	if !agent.VolumeMounts[0].ReadOnly || agent.VolumeMounts[0].MountPath != "/data" {
+		t.Errorf("Unexpected volume mounts %v", agent.VolumeMounts)
+	}
+	if agent.RestartPolicy == nil || *agent.RestartPolicy != apiv1.ContainerRestartPolicyAlways {
+		t.Errorf("Expected native sidecar restart policy, got %v", agent.RestartPolicy)
+	}
+
+	proxy := containers[1]
+	if proxy.Image != "docker.io/envoyproxy/envoy:v1.30.1" || proxy.RestartPolicy != nil || proxy.Resources.Limits != nil {
+		t.Errorf("Unexpected sidecar %+v", proxy)
+	}
+}
+
+func TestNewPodTemplateContainers(t *testing.T) {
+	config, tier := newTestApplication()
+	config.DependsOn = []DependsOn{{Name: "maxmind"}}
+	tier.Sidecars = []Container{{Name: "proxy", Image: "envoy:1.0"}}
+
+	spec := newPodTemplate(config, tier, stageProduction, "myorg").Spec
+	if len(spec.Containers) != 2 || spec.Containers[0].Name != "myapp" || spec.Containers[1].Name != "proxy" {
+		t.Errorf("Expected application container followed by sidecar, got %v", spec.Containers)
+	}
+	if len(spec.InitContainers) != 1 || spec.InitContainers[0].Image != "index.docker.io/myorg/"+MaxmindImage {
+		t.Errorf("Expected maxmind init container, got %v", spec.InitContainers)
+	}
+}
+
+func TestNewEnvVars(t *testing.T) {
+	envs := newEnvVars([]EnvVar{
+		{Name: "JAVA_OPTS", Value: "-Ddb.password=$(DB_PASSWORD)"},
+		{Name: "POD_IP", ValueFrom: &EnvVarSource{FieldRef: &FieldSelector{FieldPath: "status.podIP"}}},
+		{Name: "DB_PASSWORD", ValueFrom: &EnvVarSource{SecretKeyRef: &KeySelector{Name: "db", Key: "password", Optional: true}}},
+		{Name: "API_NAME", Value: "myapp"},
+		{Name: "LOG_LEVEL", ValueFrom: &EnvVarSource{ConfigMapKeyRef: &KeySelector{Name: "logging", Key: "level"}}},
+		{Name: "MEMORY_MB", ValueFrom: &EnvVarSource{ResourceFieldRef: &ResourceFieldSelector{Resource: "limits.memory", Divisor: "1Mi"}}},
+		{Name: "CPU", ValueFrom: &EnvVarSource{ResourceFieldRef: &ResourceFieldSelector{Resource: "limits.cpu"}}},
+	})
+
+	names := []string{}
+	for _, env := range envs {
+		names = append(names, env.Name)
+	}
+	expected := []string{"CPU", "DB_PASSWORD", "LOG_LEVEL", "MEMORY_MB", "POD_IP", "API_NAME", "JAVA_OPTS"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected valueFrom variables before literal values, got %v", names)
+	}
+
+	if cpu := envs[0].ValueFrom.ResourceFieldRef; cpu.Divisor != resource.MustParse("1") {
+		t.Errorf("Expected default divisor 1, got %v", cpu.Divisor)
+	}
+	if secret := envs[1].ValueFrom.SecretKeyRef; secret.Name != "db" || secret.Key != "password" || !*secret.Optional {
+		t.Errorf("Unexpected secret key ref %+v", secret)
+	}
+	if configMap := envs[2].ValueFrom.ConfigMapKeyRef; configMap.Name != "logging" || configMap.Optional != nil {
+		t.Errorf("Unexpected configmap key ref %+v", configMap)
+	}
+	if memory := envs[3].ValueFrom.ResourceFieldRef; memory.Resource != "limits.memory" || memory.Divisor != resource.MustParse("1Mi") {
+		t.Errorf("Unexpected resource field ref %+v", memory)
+	}
+	if field := envs[4].ValueFrom.FieldRef; field.FieldPath != "status.podIP" {
+		t.Errorf("Unexpected field ref %+v", field)
+	}
+	if envs[6].ValueFrom != nil || envs[6].Value != "-Ddb.password=$(DB_PASSWORD)" {
+		t.Errorf("Unexpected literal variable %+v", envs[6])
+	}
+}
+
+func TestNewContainerEnvFrom(t *testing.T) {
+	config, tier := newTestApplication()
+	config.EnvFrom = []string{"common-configmap"}
+	config.EnvFromSources = []EnvFromSource{{Secret: "db", Prefix: "APP_"}}
+	tier.EnvFromSources = []EnvFromSource{{Secret: "db", Prefix: "DB_", Optional: true}, {ConfigMap: "features"}}
+
+	envFrom := newContainer(config, tier, "myorg", stageProduction)[0].EnvFrom
+	if len(envFrom) != 3 {
+		t.Fatalf("Expected 3 envFrom sources, got %+v", envFrom)
+	}
+	if envFrom[0].ConfigMapRef == nil || envFrom[0].ConfigMapRef.Name != "common" {
+		t.Errorf("Expected configmap from envFrom list, got %+v", envFrom[0])
+	}
+	if envFrom[1].SecretRef == nil || envFrom[1].SecretRef.Name != "db" || envFrom[1].Prefix != "DB_" || !*envFrom[1].SecretRef.Optional {
+		t.Errorf("Expected datacenter secret source to replace application source, got %+v", envFrom[1])
+	}
+	if envFrom[2].ConfigMapRef == nil || envFrom[2].ConfigMapRef.Name != "features" || envFrom[2].ConfigMapRef.Optional != nil {
+		t.Errorf("Unexpected configmap source %+v", envFrom[2])
+	}
+}
+
+func TestEnvVarCheckQuantities(t *testing.T) {
+	valid := EnvVar{Name: "MEMORY_MB", ValueFrom: &EnvVarSource{ResourceFieldRef: &ResourceFieldSelector{Resource: "limits.memory", Divisor: "1Mi"}}}
+	if err := valid.CheckQuantities(); err != nil {
+		t.Errorf("Expected valid divisor, got %v", err)
+	}
+
+	invalid := EnvVar{Name: "MEMORY_MB", ValueFrom: &EnvVarSource{ResourceFieldRef: &ResourceFieldSelector{Resource: "limits.memory", Divisor: "1 MiB"}}}
+	if err := invalid.CheckQuantities(); err == nil {
+		t.Errorf("Expected invalid divisor error")
+	}
+}
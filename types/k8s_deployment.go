@@ -18,7 +18,6 @@ package types
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
-	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -46,53 +45,22 @@ func NewDeployment(config *Configuration, tier *Datacenter, stage, organization
 					kubernetesLabelKeyApp: application,
 				},
 			},
-			Template: apiv1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						kubernetesLabelKeyApp: application,
-					},
-				},
-				Spec: apiv1.PodSpec{
-					ServiceAccountName:            application,
-					Containers:                    newContainer(config, tier, organization, stage),
-					Affinity:                      newAffinity(application, config.NodePool),
-					Tolerations:                   newToleration(config.NodePool),
-					Volumes:                       newVolume(config.Application, config.DependsOn),
-					TerminationGracePeriodSeconds: int64Ptr(20),
-					PriorityClassName:             tier.PodPriority,
-				},
-			},
+			Template: newPodTemplate(config, tier, stage, organization),
 			Strategy: NewDeploymentStrategy(config.Strategy),
 		},
 	}
 
-	if tier.ProgressDeadline != 0 {
-		deployment.Spec.ProgressDeadlineSeconds = int32Ptr(tier.ProgressDeadline)
-	}
-
-	if tier.PodPriority == "high-priority" {
-		deployment.Spec.Template.Spec.TerminationGracePeriodSeconds = int64Ptr(60)
+	// replicas are managed by HorizontalPodAutoscaler, so redeploy must not reset them
+	if tier.Autoscaling != nil {
+		deployment.Spec.Replicas = nil
 	}
 
-	if dependencyContains(config.DependsOn, "maxmind") {
-		deployment.Spec.Template.Spec.InitContainers = []apiv1.Container{
-			{
-				Name:    "data-container",
-				Image:   dockerHubUrl + organization + "/maxmind-geoip",
-				Command: []string{"cp", "-a", "/usr/share/GeoIP/.", "/tmp"},
-				VolumeMounts: []apiv1.VolumeMount{
-					{
-						Name:      volumeNameGeoIPFiles,
-						MountPath: "/tmp",
-					},
-				},
-			},
-		}
+	if tier.ProgressDeadline != 0 {
+		deployment.Spec.ProgressDeadlineSeconds = int32Ptr(tier.ProgressDeadline)
 	}
 
 	if tier.ChaosMonkey.Enabled {
 		deployment.Labels = newMetadataLabels(application, tier.ChaosMonkey)
-		deployment.Spec.Template.Labels = newTemplateLabels(application)
 	}
 
 	return deployment
@@ -101,3 +69,5 @@ func NewDeployment(config *Configuration, tier *Datacenter, stage, organization
 func int32Ptr(i int32) *int32 { return &i }
 
 func int64Ptr(i int64) *int64 { return &i }
+
+func boolPtr(b bool) *bool { return &b }
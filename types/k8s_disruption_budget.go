@@ -0,0 +1,93 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// defaultMaxUnavailable is maxUnavailable of PodDisruptionBudget when neither datacenter nor strategy define it
+const defaultMaxUnavailable = "1"
+
+// NewPodDisruptionBudget return k8s policy/v1 PodDisruptionBudget object protecting application pods from eviction,
+// nil is returned when budget is disabled, application is a job or datacenter has single replica without explicit budget
+func NewPodDisruptionBudget(config *Configuration, tier *Datacenter, stage, organization string) *policyv1.PodDisruptionBudget {
+	if config.IsBatch() {
+		return nil
+	}
+
+	budget := tier.DisruptionBudget
+	if budget == nil {
+		if tier.MinReplicas() <= 1 {
+			return nil
+		}
+		budget = &DisruptionBudget{MaxUnavailable: defaultBudgetMaxUnavailable(config.Strategy)}
+	}
+	if budget.Disabled {
+		return nil
+	}
+
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        application,
+			Namespace:   config.Namespace,
+			Annotations: defaultAnnotations(organization, stage, config.Owners),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					kubernetesLabelKeyApp: application,
+				},
+			},
+		},
+	}
+
+	if budget.MinAvailable != "" {
+		minAvailable := intstr.Parse(budget.MinAvailable)
+		pdb.Spec.MinAvailable = &minAvailable
+	} else {
+		maxUnavailable := intstr.Parse(mergeString(defaultBudgetMaxUnavailable(config.Strategy), budget.MaxUnavailable))
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+
+	return pdb
+}
+
+// defaultBudgetMaxUnavailable returns maxUnavailable of rolling update strategy, or 1 when it isn't set or is zero
+func defaultBudgetMaxUnavailable(strategy *DeployStrategy) string {
+	if strategy == nil || strategy.RollingUpdate == nil {
+		return defaultMaxUnavailable
+	}
+
+	switch strategy.RollingUpdate.MaxUnavailable {
+	case "", "0", "0%":
+		return defaultMaxUnavailable
+	default:
+		return strategy.RollingUpdate.MaxUnavailable
+	}
+}
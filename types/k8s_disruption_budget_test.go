@@ -0,0 +1,91 @@
+package types
+
+import "testing"
+
+func TestNewPodDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		name           string
+		replicas       int32
+		autoscaling    *Autoscaling
+		strategy       *DeployStrategy
+		budget         *DisruptionBudget
+		expectNil      bool
+		minAvailable   string
+		maxUnavailable string
+	}{
+		{
+			name:           "max unavailable 1 for multiple replicas",
+			replicas:       2,
+			maxUnavailable: "1",
+		},
+		{
+			name:      "no budget for single replica",
+			replicas:  1,
+			expectNil: true,
+		},
+		{
+			name:           "autoscaling min replicas",
+			replicas:       1,
+			autoscaling:    &Autoscaling{MinReplicas: 2, MaxReplicas: 4},
+			maxUnavailable: "1",
+		},
+		{
+			name:           "max unavailable from rolling update strategy",
+			replicas:       4,
+			strategy:       &DeployStrategy{Type: "RollingUpdate", RollingUpdate: &RollingUpdateDeployment{MaxUnavailable: "25%"}},
+			maxUnavailable: "25%",
+		},
+		{
+			name:           "zero max unavailable of strategy falls back to 1",
+			replicas:       4,
+			strategy:       &DeployStrategy{Type: "RollingUpdate", RollingUpdate: &RollingUpdateDeployment{MaxUnavailable: "0"}},
+			maxUnavailable: "1",
+		},
+		{
+			name:         "min available override for single replica",
+			replicas:     1,
+			budget:       &DisruptionBudget{MinAvailable: "50%"},
+			minAvailable: "50%",
+		},
+		{
+			name:      "disabled",
+			replicas:  3,
+			budget:    &DisruptionBudget{Disabled: true},
+			expectNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, tier := newTestApplication()
+			config.Strategy = tt.strategy
+			tier.Replicas = tt.replicas
+			tier.Autoscaling = tt.autoscaling
+			tier.DisruptionBudget = tt.budget
+
+			pdb := NewPodDisruptionBudget(config, tier, "beta", "myorg")
+			if tt.expectNil {
+				if pdb != nil {
+					t.Errorf("Expected no budget, got %+v", pdb.Spec)
+				}
+				return
+			}
+			if pdb == nil {
+				t.Fatal("Expected budget, got nil")
+			}
+
+			if pdb.Name != "myapp-beta" || pdb.Spec.Selector.MatchLabels[kubernetesLabelKeyApp] != "myapp-beta" {
+				t.Errorf("Unexpected name %q or selector %+v", pdb.Name, pdb.Spec.Selector)
+			}
+			if pdb.Annotations["service.myorg.dev/generated"] != "spini/v1" || pdb.Annotations["moniker.spinnaker.io/stack"] != "beta" {
+				t.Errorf("Expected default annotations, got %v", pdb.Annotations)
+			}
+			if tt.minAvailable != "" && (pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.String() != tt.minAvailable) {
+				t.Errorf("Expected minAvailable %s, got %v", tt.minAvailable, pdb.Spec.MinAvailable)
+			}
+			if tt.maxUnavailable != "" && (pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.String() != tt.maxUnavailable) {
+				t.Errorf("Expected maxUnavailable %s, got %v", tt.maxUnavailable, pdb.Spec.MaxUnavailable)
+			}
+		})
+	}
+}
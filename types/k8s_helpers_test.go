@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"testing"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -64,7 +65,7 @@ func TestNewToleration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := newToleration(tt.nodepool)
+			result := newToleration(tt.nodepool, nil)
 			if result == nil {
 				t.Fatal("newToleration returned nil")
 			}
@@ -185,7 +186,7 @@ func TestNewVolumeMount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := newVolumeMount(tt.application, tt.dependencies)
+			result := newVolumeMount(dependencyVolumes(tt.application, tt.dependencies))
 			if result == nil {
 				t.Fatal("newVolumeMount returned nil")
 			}
@@ -296,7 +297,7 @@ func TestNewVolume(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := newVolume(tt.application, tt.dependencies)
+			result := newVolume(dependencyVolumes(tt.application, tt.dependencies))
 			if result == nil {
 				t.Fatal("newVolume returned nil")
 			}
@@ -370,7 +371,7 @@ func TestNewResourceRequirements(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := newResourceRequirements(tt.tier)
+			result := newResourceRequirements(tt.tier.Resources, DefaultResourcePolicy())
 			tt.validate(t, result)
 		})
 	}
@@ -560,7 +561,7 @@ func TestNewAffinity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := newAffinity(tt.application, tt.nodepool)
+			result := newAffinity(tt.application, tt.nodepool, nil)
 			if result == nil {
 				t.Fatal("newAffinity returned nil")
 			}
@@ -639,3 +640,50 @@ func TestDefaultAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResourceRequirementsExtended(t *testing.T) {
+	requirements := newResourceRequirements(&ResourceRequirements{
+		Requests: &ResourceList{CPU: "1", Memory: "1Gi", EphemeralStorage: "2Gi", Extended: map[string]string{"nvidia.com/gpu": "1"}},
+		Limits:   &ResourceList{Memory: "1Gi", Extended: map[string]string{"nvidia.com/gpu": "1"}},
+	}, DefaultResourcePolicy())
+
+	if requirements.Requests[apiv1.ResourceEphemeralStorage] != resource.MustParse("2Gi") {
+		t.Errorf("Expected ephemeral-storage request, got %v", requirements.Requests)
+	}
+	if requirements.Requests["nvidia.com/gpu"] != resource.MustParse("1") || requirements.Limits["nvidia.com/gpu"] != resource.MustParse("1") {
+		t.Errorf("Expected extended resource request and limit, got %+v", requirements)
+	}
+}
+
+func TestCheckPodResources(t *testing.T) {
+	config, tier := newTestApplication()
+	if err := config.CheckPodResources(tier); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	config.Sidecars = []Container{{Name: "proxy", Resources: &ResourceRequirements{Limits: &ResourceList{Memory: "1 gig"}}}}
+	if err := config.CheckPodResources(tier); err == nil || !strings.Contains(err.Error(), `container "proxy"`) {
+		t.Errorf("Expected error of invalid sidecar limit, got %v", err)
+	}
+}
+
+func TestPodResources(t *testing.T) {
+	config, tier := newTestApplication()
+	config.Sidecars = []Container{{Name: "proxy", Resources: &ResourceRequirements{Requests: &ResourceList{CPU: "50m", Memory: "64Mi"}}}}
+	config.InitContainers = []Container{
+		{Name: "migrate", Resources: &ResourceRequirements{Requests: &ResourceList{CPU: "1", Memory: "64Mi"}}},
+		{Name: "agent", RestartPolicy: ContainerRestartPolicyAlways, Resources: &ResourceRequirements{Requests: &ResourceList{CPU: "10m"}}},
+	}
+	config.ResourcePolicy = &ResourcePolicy{CPULimit: LimitNone}
+
+	pod := config.PodResources(tier)
+	if cpu := pod.Requests[apiv1.ResourceCPU]; cpu.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("Expected cpu request of the largest init container, got %s", cpu.String())
+	}
+	if memory := pod.Requests[apiv1.ResourceMemory]; memory.Cmp(resource.MustParse("192Mi")) != 0 {
+		t.Errorf("Expected memory request of all containers, got %s", memory.String())
+	}
+	if _, ok := pod.Limits[apiv1.ResourceCPU]; ok {
+		t.Errorf("Expected no cpu limits by policy, got %v", pod.Limits)
+	}
+}
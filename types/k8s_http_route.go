@@ -0,0 +1,142 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// HTTPRoute represents subset of Gateway API gateway.networking.k8s.io/v1 HTTPRoute used by spini
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HTTPRouteSpec `json:"spec"`
+}
+
+// HTTPRouteSpec represents HTTPRoute spec
+type HTTPRouteSpec struct {
+	ParentRefs []HTTPRouteParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string                   `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule            `json:"rules,omitempty"`
+}
+
+// HTTPRouteParentReference represents reference to the gateway HTTPRoute is attached to
+type HTTPRouteParentReference struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// HTTPRouteRule represents HTTPRoute rule
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch `json:"matches,omitempty"`
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+// HTTPRouteMatch represents HTTPRoute request match
+type HTTPRouteMatch struct {
+	Path *HTTPPathMatch `json:"path,omitempty"`
+}
+
+// HTTPPathMatch represents HTTPRoute path match
+type HTTPPathMatch struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// HTTPBackendRef represents service backend of HTTPRoute rule
+type HTTPBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+// gatewayPathTypes maps Ingress path types to HTTPRoute path match types
+var gatewayPathTypes = map[string]string{
+	"Prefix":                 "PathPrefix",
+	"Exact":                  "Exact",
+	"ImplementationSpecific": "RegularExpression",
+}
+
+// DeepCopyObject implements runtime.Object
+func (r *HTTPRoute) DeepCopyObject() runtime.Object {
+	out := &HTTPRoute{TypeMeta: r.TypeMeta}
+	r.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.ParentRefs = append([]HTTPRouteParentReference(nil), r.Spec.ParentRefs...)
+	out.Spec.Hostnames = append([]string(nil), r.Spec.Hostnames...)
+	for _, rule := range r.Spec.Rules {
+		copied := HTTPRouteRule{BackendRefs: append([]HTTPBackendRef(nil), rule.BackendRefs...)}
+		for _, match := range rule.Matches {
+			if match.Path != nil {
+				path := *match.Path
+				match.Path = &path
+			}
+			copied.Matches = append(copied.Matches, match)
+		}
+		out.Spec.Rules = append(out.Spec.Rules, copied)
+	}
+
+	return out
+}
+
+// NewHTTPRoute return Gateway API HTTPRoute object routing hosts and paths to the application service
+func NewHTTPRoute(config *Configuration, stage string) *HTTPRoute {
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	rules := []HTTPRouteRule{}
+	for _, path := range config.Ingress.RoutedPaths(config.Ports) {
+		var port int32
+		if p := FindPort(config.Ports, path.Port); p != nil {
+			port = p.ExposedPort()
+		}
+
+		rules = append(rules, HTTPRouteRule{
+			Matches:     []HTTPRouteMatch{{Path: &HTTPPathMatch{Type: gatewayPathTypes[path.PathType], Value: path.Path}}},
+			BackendRefs: []HTTPBackendRef{{Name: application, Port: port}},
+		})
+	}
+
+	route := &HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HTTPRoute",
+			APIVersion: "gateway.networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        application,
+			Namespace:   config.Namespace,
+			Annotations: config.Ingress.Annotations,
+		},
+		Spec: HTTPRouteSpec{
+			Hostnames: config.Ingress.StageHosts(stage),
+			Rules:     rules,
+		},
+	}
+
+	if gateway := config.Ingress.Gateway; gateway != nil {
+		route.Spec.ParentRefs = []HTTPRouteParentReference{{
+			Name:        gateway.Name,
+			Namespace:   gateway.Namespace,
+			SectionName: gateway.SectionName,
+		}}
+	}
+
+	return route
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewIngressRoute return k8s object routing external traffic to the application service,
+// Ingress or HTTPRoute is returned depending on ingress kind
+func NewIngressRoute(config *Configuration, stage string) runtime.Object {
+	if config.Ingress.IngressKind() == IngressKindHTTPRoute {
+		return NewHTTPRoute(config, stage)
+	}
+
+	return NewIngress(config, stage)
+}
+
+// NewIngress return k8s networking/v1 Ingress object routing hosts and paths to the application service
+func NewIngress(config *Configuration, stage string) *networkingv1.Ingress {
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	hosts := config.Ingress.StageHosts(stage)
+	paths := []networkingv1.HTTPIngressPath{}
+	for _, path := range config.Ingress.RoutedPaths(config.Ports) {
+		pathType := networkingv1.PathType(path.PathType)
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path:     path.Path,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: application,
+					Port: networkingv1.ServiceBackendPort{Name: path.Port},
+				},
+			},
+		})
+	}
+
+	rules := []networkingv1.IngressRule{}
+	for _, host := range hosts {
+		rules = append(rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+			},
+		})
+	}
+
+	ingress := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        application,
+			Namespace:   config.Namespace,
+			Annotations: config.Ingress.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: rules,
+		},
+	}
+
+	if config.Ingress.ClassName != "" {
+		ingress.Spec.IngressClassName = &config.Ingress.ClassName
+	}
+
+	if config.Ingress.TLSSecret != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: hosts, SecretName: config.Ingress.TLSSecret}}
+	}
+
+	return ingress
+}
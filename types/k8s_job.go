@@ -0,0 +1,137 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// annotationRecreate makes spinnaker delete existing object before deploy, job template is immutable
+	annotationRecreate = "strategy.spinnaker.io/recreate"
+
+	defaultConcurrencyPolicy = batchv1.ForbidConcurrent
+)
+
+// NewJob return k8s job object which is recreated on every deploy
+func NewJob(config *Configuration, tier *Datacenter, stage, organization string) *batchv1.Job {
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	annotations := defaultAnnotations(organization, stage, config.Owners)
+	annotations[annotationRecreate] = "true"
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        application,
+			Namespace:   config.Namespace,
+			Annotations: annotations,
+		},
+		Spec: newJobSpec(config, tier, stage, organization),
+	}
+}
+
+// NewCronJob return k8s cronjob object running the application job by schedule
+func NewCronJob(config *Configuration, tier *Datacenter, stage, organization string) *batchv1.CronJob {
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	settings := config.CronJob
+	if settings == nil {
+		settings = &CronJobSettings{}
+	}
+
+	concurrencyPolicy := batchv1.ConcurrencyPolicy(settings.ConcurrencyPolicy)
+	if concurrencyPolicy == "" {
+		concurrencyPolicy = defaultConcurrencyPolicy
+	}
+
+	cronJob := &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        application,
+			Namespace:   config.Namespace,
+			Annotations: defaultAnnotations(organization, stage, config.Owners),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   settings.Schedule,
+			ConcurrencyPolicy:          concurrencyPolicy,
+			SuccessfulJobsHistoryLimit: settings.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     settings.FailedJobsHistoryLimit,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: newJobSpec(config, tier, stage, organization),
+			},
+		},
+	}
+
+	if settings.TimeZone != "" {
+		cronJob.Spec.TimeZone = &settings.TimeZone
+	}
+	if settings.StartingDeadlineSeconds != 0 {
+		cronJob.Spec.StartingDeadlineSeconds = int64Ptr(settings.StartingDeadlineSeconds)
+	}
+	if settings.Suspend {
+		cronJob.Spec.Suspend = &settings.Suspend
+	}
+
+	return cronJob
+}
+
+// newJobSpec return k8s job spec of job and cronjob applications
+func newJobSpec(config *Configuration, tier *Datacenter, stage, organization string) batchv1.JobSpec {
+	settings := config.Job
+	if settings == nil {
+		settings = &JobSettings{}
+	}
+
+	template := newPodTemplate(config, tier, stage, organization)
+	template.Spec.RestartPolicy = apiv1.RestartPolicy(settings.RestartPolicy)
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = apiv1.RestartPolicyOnFailure
+	}
+
+	spec := batchv1.JobSpec{
+		BackoffLimit:            settings.BackoffLimit,
+		TTLSecondsAfterFinished: settings.TTLSecondsAfterFinished,
+		Template:                template,
+	}
+
+	if settings.ActiveDeadlineSeconds != 0 {
+		spec.ActiveDeadlineSeconds = int64Ptr(settings.ActiveDeadlineSeconds)
+	}
+	if settings.Completions != 0 {
+		spec.Completions = int32Ptr(settings.Completions)
+	}
+	if settings.Parallelism != 0 {
+		spec.Parallelism = int32Ptr(settings.Parallelism)
+	}
+
+	return spec
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestNewJob(t *testing.T) {
+	backoffLimit := int32(3)
+
+	tests := []struct {
+		name     string
+		settings *JobSettings
+		validate func(*testing.T, *batchv1.Job)
+	}{
+		{
+			name: "default settings",
+			validate: func(t *testing.T, job *batchv1.Job) {
+				if job.Kind != "Job" || job.Name != "myapp" {
+					t.Errorf("Unexpected job %s/%s", job.Kind, job.Name)
+				}
+				if job.Annotations[annotationRecreate] != "true" {
+					t.Errorf("Expected recreate annotation, got %v", job.Annotations)
+				}
+				if job.Spec.Template.Spec.RestartPolicy != apiv1.RestartPolicyOnFailure {
+					t.Errorf("Expected OnFailure restart policy, got %q", job.Spec.Template.Spec.RestartPolicy)
+				}
+				if job.Spec.BackoffLimit != nil || job.Spec.ActiveDeadlineSeconds != nil || job.Spec.Completions != nil {
+					t.Error("Expected kubernetes defaults of job spec")
+				}
+				if job.Spec.Template.Spec.Containers[0].ReadinessProbe != nil {
+					t.Error("Expected no readiness probe for job")
+				}
+				if len(job.Labels) != 0 || len(job.Spec.Template.Labels) != 1 {
+					t.Errorf("Expected no chaosMonkey labels, got %v and %v", job.Labels, job.Spec.Template.Labels)
+				}
+			},
+		},
+		{
+			name: "custom settings",
+			settings: &JobSettings{
+				BackoffLimit:          &backoffLimit,
+				ActiveDeadlineSeconds: 600,
+				Completions:           5,
+				Parallelism:           2,
+				RestartPolicy:         "Never",
+			},
+			validate: func(t *testing.T, job *batchv1.Job) {
+				spec := job.Spec
+				if spec.BackoffLimit == nil || *spec.BackoffLimit != 3 {
+					t.Errorf("Expected backoffLimit 3, got %v", spec.BackoffLimit)
+				}
+				if spec.ActiveDeadlineSeconds == nil || *spec.ActiveDeadlineSeconds != 600 {
+					t.Errorf("Expected activeDeadlineSeconds 600, got %v", spec.ActiveDeadlineSeconds)
+				}
+				if *spec.Completions != 5 || *spec.Parallelism != 2 {
+					t.Errorf("Expected completions 5 and parallelism 2, got %d and %d", *spec.Completions, *spec.Parallelism)
+				}
+				if spec.Template.Spec.RestartPolicy != apiv1.RestartPolicyNever {
+					t.Errorf("Expected Never restart policy, got %q", spec.Template.Spec.RestartPolicy)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, tier := newTestApplication()
+			config.Type = WorkloadTypeJob
+			config.Job = tt.settings
+			tier.ChaosMonkey = &ChaosMonkey{Enabled: true}
+			tt.validate(t, NewJob(config, tier, stageProduction, "myorg"))
+		})
+	}
+}
+
+func TestNewCronJob(t *testing.T) {
+	historyLimit := int32(1)
+
+	tests := []struct {
+		name     string
+		settings *CronJobSettings
+		job      *JobSettings
+		validate func(*testing.T, *batchv1.CronJob)
+	}{
+		{
+			name:     "forbid concurrency by default",
+			settings: &CronJobSettings{Schedule: "*/5 * * * *"},
+			validate: func(t *testing.T, cronJob *batchv1.CronJob) {
+				if cronJob.Kind != "CronJob" || cronJob.Spec.Schedule != "*/5 * * * *" {
+					t.Errorf("Unexpected cronjob %s with schedule %q", cronJob.Kind, cronJob.Spec.Schedule)
+				}
+				if cronJob.Spec.ConcurrencyPolicy != batchv1.ForbidConcurrent {
+					t.Errorf("Expected Forbid policy, got %q", cronJob.Spec.ConcurrencyPolicy)
+				}
+				if cronJob.Spec.TimeZone != nil || cronJob.Spec.Suspend != nil || cronJob.Spec.StartingDeadlineSeconds != nil {
+					t.Error("Expected kubernetes defaults of cronjob spec")
+				}
+				if _, ok := cronJob.Annotations[annotationRecreate]; ok {
+					t.Error("Expected cronjob to be updated in place")
+				}
+			},
+		},
+		{
+			name: "custom settings with job template",
+			settings: &CronJobSettings{
+				Schedule:                   "0 3 * * *",
+				TimeZone:                   "Europe/Kyiv",
+				ConcurrencyPolicy:          "Replace",
+				StartingDeadlineSeconds:    120,
+				SuccessfulJobsHistoryLimit: &historyLimit,
+				Suspend:                    true,
+			},
+			job: &JobSettings{ActiveDeadlineSeconds: 3600},
+			validate: func(t *testing.T, cronJob *batchv1.CronJob) {
+				spec := cronJob.Spec
+				if spec.ConcurrencyPolicy != batchv1.ReplaceConcurrent {
+					t.Errorf("Expected Replace policy, got %q", spec.ConcurrencyPolicy)
+				}
+				if spec.TimeZone == nil || *spec.TimeZone != "Europe/Kyiv" {
+					t.Errorf("Expected time zone, got %v", spec.TimeZone)
+				}
+				if *spec.StartingDeadlineSeconds != 120 || *spec.SuccessfulJobsHistoryLimit != 1 || !*spec.Suspend {
+					t.Error("Expected custom deadline, history limit and suspend")
+				}
+				if deadline := spec.JobTemplate.Spec.ActiveDeadlineSeconds; deadline == nil || *deadline != 3600 {
+					t.Errorf("Expected job deadline 3600, got %v", deadline)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, tier := newTestApplication()
+			config.Type = WorkloadTypeCronJob
+			config.CronJob = tt.settings
+			config.Job = tt.job
+			tt.validate(t, NewCronJob(config, tier, stageProduction, "myorg"))
+		})
+	}
+}
+
+func TestNewWorkload(t *testing.T) {
+	tests := []struct {
+		appType string
+		kind    string
+	}{
+		{appType: "service", kind: "Deployment"},
+		{appType: "worker", kind: "Deployment"},
+		{appType: WorkloadTypeStatefulSet, kind: "StatefulSet"},
+		{appType: WorkloadTypeCronJob, kind: "CronJob"},
+		{appType: WorkloadTypeJob, kind: "Job"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.appType, func(t *testing.T) {
+			config, tier := newTestApplication()
+			config.Type = tt.appType
+
+			if kind := NewWorkload(config, tier, stageProduction, "myorg").GetObjectKind().GroupVersionKind().Kind; kind != tt.kind {
+				t.Errorf("Expected %s, got %s", tt.kind, kind)
+			}
+			if kind := config.WorkloadKind(); kind != tt.kind {
+				t.Errorf("Expected workload kind %s, got %s", tt.kind, kind)
+			}
+		})
+	}
+}
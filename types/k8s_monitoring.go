@@ -0,0 +1,146 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Monitor represents subset of Prometheus operator monitoring.coreos.com/v1 ServiceMonitor and PodMonitor used by spini
+type Monitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MonitorSpec `json:"spec"`
+}
+
+// MonitorSpec represents spec of ServiceMonitor (with endpoints) or PodMonitor (with podMetricsEndpoints)
+type MonitorSpec struct {
+	Selector            metav1.LabelSelector `json:"selector"`
+	Endpoints           []MonitorEndpoint    `json:"endpoints,omitempty"`
+	PodMetricsEndpoints []MonitorEndpoint    `json:"podMetricsEndpoints,omitempty"`
+}
+
+// MonitorEndpoint represents scraped endpoint of the monitor
+type MonitorEndpoint struct {
+	Port              string       `json:"port"`
+	Path              string       `json:"path,omitempty"`
+	Interval          string       `json:"interval,omitempty"`
+	ScrapeTimeout     string       `json:"scrapeTimeout,omitempty"`
+	Relabelings       []Relabeling `json:"relabelings,omitempty"`
+	MetricRelabelings []Relabeling `json:"metricRelabelings,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object
+func (m *Monitor) DeepCopyObject() runtime.Object {
+	out := &Monitor{TypeMeta: m.TypeMeta}
+	m.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	m.Spec.Selector.DeepCopyInto(&out.Spec.Selector)
+	out.Spec.Endpoints = copyMonitorEndpoints(m.Spec.Endpoints)
+	out.Spec.PodMetricsEndpoints = copyMonitorEndpoints(m.Spec.PodMetricsEndpoints)
+
+	return out
+}
+
+// copyMonitorEndpoints returns deep copy of monitor endpoints
+func copyMonitorEndpoints(endpoints []MonitorEndpoint) []MonitorEndpoint {
+	if endpoints == nil {
+		return nil
+	}
+
+	copied := make([]MonitorEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		endpoint.Relabelings = copyRelabelings(endpoint.Relabelings)
+		endpoint.MetricRelabelings = copyRelabelings(endpoint.MetricRelabelings)
+		copied = append(copied, endpoint)
+	}
+
+	return copied
+}
+
+// copyRelabelings returns deep copy of relabel configs
+func copyRelabelings(relabelings []Relabeling) []Relabeling {
+	if relabelings == nil {
+		return nil
+	}
+
+	copied := make([]Relabeling, 0, len(relabelings))
+	for _, relabeling := range relabelings {
+		relabeling.SourceLabels = append([]string(nil), relabeling.SourceLabels...)
+		copied = append(copied, relabeling)
+	}
+
+	return copied
+}
+
+// NewMonitor return Prometheus operator ServiceMonitor or PodMonitor object scraping metrics port of the application,
+// nil is returned when application doesn't have metrics port or monitoring is disabled
+func NewMonitor(config *Configuration, stage string) *Monitor {
+	settings := config.Monitoring
+	if settings == nil {
+		settings = &Monitoring{}
+	}
+	if settings.Disabled || FindPort(config.Ports, config.MetricsPort()) == nil {
+		return nil
+	}
+
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	endpoint := MonitorEndpoint{
+		Port:              config.MetricsPort(),
+		Path:              settings.Path,
+		Interval:          settings.Interval,
+		ScrapeTimeout:     settings.ScrapeTimeout,
+		Relabelings:       settings.Relabelings,
+		MetricRelabelings: settings.MetricRelabelings,
+	}
+	if endpoint.Path == "" {
+		endpoint.Path = defaultMetricsPath
+	}
+
+	monitor := &Monitor{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "monitoring.coreos.com/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      application,
+			Namespace: config.Namespace,
+			Labels:    settings.Labels,
+		},
+		Spec: MonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					kubernetesLabelKeyApp: application,
+				},
+			},
+		},
+	}
+
+	if config.MonitorKind() == MonitorKindServiceMonitor {
+		monitor.Kind = "ServiceMonitor"
+		monitor.Spec.Endpoints = []MonitorEndpoint{endpoint}
+	} else {
+		monitor.Kind = "PodMonitor"
+		monitor.Spec.PodMetricsEndpoints = []MonitorEndpoint{endpoint}
+	}
+
+	return monitor
+}
@@ -0,0 +1,92 @@
+package types
+
+import "testing"
+
+func TestNewMonitor(t *testing.T) {
+	tests := []struct {
+		name       string
+		appType    string
+		ports      []Port
+		monitoring *Monitoring
+		validate   func(*testing.T, *Monitor)
+	}{
+		{
+			name:    "service monitor for service application",
+			appType: "service",
+			ports:   []Port{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9113}},
+			validate: func(t *testing.T, monitor *Monitor) {
+				if monitor.Kind != "ServiceMonitor" || monitor.APIVersion != "monitoring.coreos.com/v1" || monitor.Name != "myapp-beta" {
+					t.Errorf("Unexpected monitor %s %s %s", monitor.APIVersion, monitor.Kind, monitor.Name)
+				}
+				if monitor.Spec.Selector.MatchLabels[kubernetesLabelKeyApp] != "myapp-beta" {
+					t.Errorf("Unexpected selector %+v", monitor.Spec.Selector)
+				}
+				if len(monitor.Spec.Endpoints) != 1 || monitor.Spec.Endpoints[0].Port != "metrics" || monitor.Spec.Endpoints[0].Path != "/metrics" {
+					t.Errorf("Unexpected endpoints %+v", monitor.Spec.Endpoints)
+				}
+				if monitor.Spec.PodMetricsEndpoints != nil {
+					t.Errorf("Expected no pod metrics endpoints, got %+v", monitor.Spec.PodMetricsEndpoints)
+				}
+			},
+		},
+		{
+			name:    "pod monitor for worker with custom port",
+			appType: "worker",
+			ports:   []Port{{Name: "prometheus", ContainerPort: 9090}},
+			monitoring: &Monitoring{
+				Port:        "prometheus",
+				Path:        "/stats",
+				Interval:    "15s",
+				Labels:      map[string]string{"release": "prometheus"},
+				Relabelings: []Relabeling{{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}, TargetLabel: "node"}},
+			},
+			validate: func(t *testing.T, monitor *Monitor) {
+				if monitor.Kind != "PodMonitor" || monitor.Labels["release"] != "prometheus" {
+					t.Errorf("Unexpected kind %s or labels %v", monitor.Kind, monitor.Labels)
+				}
+				if len(monitor.Spec.PodMetricsEndpoints) != 1 {
+					t.Fatalf("Expected single pod metrics endpoint, got %+v", monitor.Spec.PodMetricsEndpoints)
+				}
+				endpoint := monitor.Spec.PodMetricsEndpoints[0]
+				if endpoint.Port != "prometheus" || endpoint.Path != "/stats" || endpoint.Interval != "15s" || len(endpoint.Relabelings) != 1 {
+					t.Errorf("Unexpected endpoint %+v", endpoint)
+				}
+
+				copied := monitor.DeepCopyObject().(*Monitor)
+				copied.Spec.PodMetricsEndpoints[0].Relabelings[0].SourceLabels[0] = "changed"
+				if endpoint.Relabelings[0].SourceLabels[0] == "changed" {
+					t.Error("Expected deep copy of relabelings")
+				}
+			},
+		},
+		{
+			name:    "no metrics port",
+			appType: "service",
+			ports:   []Port{{Name: "http", ContainerPort: 8080}},
+		},
+		{
+			name:       "disabled",
+			appType:    "service",
+			ports:      []Port{{Name: "metrics", ContainerPort: 9113}},
+			monitoring: &Monitoring{Disabled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := newTestApplication()
+			config.Type = tt.appType
+			config.Ports = tt.ports
+			config.Monitoring = tt.monitoring
+
+			monitor := NewMonitor(config, "beta")
+			if tt.validate == nil {
+				if monitor != nil {
+					t.Errorf("Expected no monitor, got %+v", monitor)
+				}
+				return
+			}
+			tt.validate(t, monitor)
+		})
+	}
+}
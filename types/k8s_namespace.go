@@ -0,0 +1,109 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewNamespace return k8s namespace object with labels of namespace settings
+func NewNamespace(namespace, organization string, settings *NamespaceSettings) *apiv1.Namespace {
+	return &apiv1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: settings.Labels,
+			Annotations: map[string]string{
+				"service." + organization + ".dev/generated": "spini/v1",
+			},
+		},
+	}
+}
+
+// NewResourceQuota return k8s resource quota object of the namespace, nil is returned when quota isn't set
+func NewResourceQuota(namespace string, settings *NamespaceSettings) *apiv1.ResourceQuota {
+	quota := settings.ResourceQuota
+	if quota == nil {
+		return nil
+	}
+
+	hard := apiv1.ResourceList{}
+	addResources(hard, "requests.", quota.Requests)
+	addResources(hard, "limits.", quota.Limits)
+	if quota.Pods != 0 {
+		hard[apiv1.ResourcePods] = *resource.NewQuantity(int64(quota.Pods), resource.DecimalSI)
+	}
+
+	return &apiv1.ResourceQuota{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ResourceQuota",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespace,
+			Namespace: namespace,
+		},
+		Spec: apiv1.ResourceQuotaSpec{
+			Hard: hard,
+		},
+	}
+}
+
+// NewLimitRange return k8s limit range object for containers of the namespace, nil is returned when limit range isn't set
+func NewLimitRange(namespace string, settings *NamespaceSettings) *apiv1.LimitRange {
+	limits := settings.LimitRange
+	if limits == nil {
+		return nil
+	}
+
+	item := apiv1.LimitRangeItem{
+		Type:           apiv1.LimitTypeContainer,
+		Default:        apiv1.ResourceList{},
+		DefaultRequest: apiv1.ResourceList{},
+		Max:            apiv1.ResourceList{},
+	}
+	addResources(item.Default, "", limits.Default)
+	addResources(item.DefaultRequest, "", limits.DefaultRequest)
+	addResources(item.Max, "", limits.Max)
+
+	return &apiv1.LimitRange{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "LimitRange",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespace,
+			Namespace: namespace,
+		},
+		Spec: apiv1.LimitRangeSpec{
+			Limits: []apiv1.LimitRangeItem{item},
+		},
+	}
+}
+
+// addResources adds resources of the list which are set to k8s resource list with name prefix,
+// quantities should be checked with CheckQuantities before
+func addResources(resources apiv1.ResourceList, prefix string, list *ResourceList) {
+	for name, value := range list.Quantities() {
+		resources[apiv1.ResourceName(prefix+name)] = resource.MustParse(value)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"slices"
+
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// kubernetesLabelKeyNamespace is the label set by kubernetes on every namespace with its name
+const kubernetesLabelKeyNamespace = "kubernetes.io/metadata.name"
+
+// NewNetworkPolicy return k8s networking/v1 NetworkPolicy object which denies ingress traffic of application pods
+// except declared ports, ports are open only to ingress sources, dependent applications and Prometheus when
+// any sources or dependents exist, nil is returned when network policy is disabled
+func NewNetworkPolicy(config *Configuration, stage string) *networkingv1.NetworkPolicy {
+	settings := config.NetworkPolicy
+	if settings == nil {
+		settings = &NetworkPolicy{}
+	}
+	if settings.Disabled {
+		return nil
+	}
+
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	dependents := newDependentPeers(config, stage)
+	rules := []networkingv1.NetworkPolicyIngressRule{}
+	switch {
+	case len(config.Ports) == 0:
+		// pods without ports don't accept any traffic
+	case len(settings.IngressFrom) == 0 && len(dependents) == 0:
+		// applications without sources and dependents accept traffic to declared ports from anywhere
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{Ports: newNetworkPolicyPorts(config.Ports, nil)})
+	default:
+		for _, source := range settings.IngressFrom {
+			rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+				From:  []networkingv1.NetworkPolicyPeer{newNetworkPolicyPeer(source)},
+				Ports: newNetworkPolicyPorts(config.Ports, source.Ports),
+			})
+		}
+		if len(dependents) > 0 {
+			rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+				From:  dependents,
+				Ports: newNetworkPolicyPorts(config.Ports, nil),
+			})
+		}
+		// metrics port stays open to Prometheus scraping generated monitor
+		if NewMonitor(config, stage) != nil {
+			rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+				From:  []networkingv1.NetworkPolicyPeer{newNetworkPolicyPeer(NetworkPolicySource{Namespace: config.PrometheusNamespace()})},
+				Ports: newNetworkPolicyPorts(config.Ports, []string{config.MetricsPort()}),
+			})
+		}
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      application,
+			Namespace: config.Namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					kubernetesLabelKeyApp: application,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+}
+
+// newNetworkPolicyPorts return NetworkPolicy ports of application ports with provided names, all ports are used when names aren't set
+func newNetworkPolicyPorts(ports []Port, names []string) []networkingv1.NetworkPolicyPort {
+	policyPorts := []networkingv1.NetworkPolicyPort{}
+	for _, port := range ports {
+		if len(names) > 0 && !slices.Contains(names, port.Name) {
+			continue
+		}
+
+		number := intstr.FromInt32(port.ContainerPort)
+		policyPort := networkingv1.NetworkPolicyPort{Port: &number}
+		if port.Protocol != "" {
+			protocol := apiv1.Protocol(port.Protocol)
+			policyPort.Protocol = &protocol
+		}
+		policyPorts = append(policyPorts, policyPort)
+	}
+
+	return policyPorts
+}
+
+// newNetworkPolicyPeer return NetworkPolicy peer of traffic source
+func newNetworkPolicyPeer(source NetworkPolicySource) networkingv1.NetworkPolicyPeer {
+	if source.CIDR != "" {
+		return networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: source.CIDR}}
+	}
+
+	peer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{MatchLabels: source.PodLabels},
+	}
+	if source.Namespace != "" {
+		peer.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{kubernetesLabelKeyNamespace: source.Namespace},
+		}
+	}
+
+	return peer
+}
+
+// newDependentPeers return NetworkPolicy peers of dependent applications deployed to the same stage
+func newDependentPeers(config *Configuration, stage string) []networkingv1.NetworkPolicyPeer {
+	peers := []networkingv1.NetworkPolicyPeer{}
+	for _, dependent := range config.Dependents {
+		if !slices.Contains(dependent.Stages, stage) {
+			continue
+		}
+
+		application := dependent.Application
+		if stage != stageProduction {
+			application = dependent.Application + "-" + stage
+		}
+
+		source := NetworkPolicySource{PodLabels: map[string]string{kubernetesLabelKeyApp: application}}
+		if dependent.Namespace != config.Namespace {
+			source.Namespace = dependent.Namespace
+		}
+		peers = append(peers, newNetworkPolicyPeer(source))
+	}
+
+	return peers
+}
@@ -0,0 +1,146 @@
+package types
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestNewNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		stage      string
+		ports      []Port
+		policy     *NetworkPolicy
+		monitoring *Monitoring
+		validate   func(*testing.T, *networkingv1.NetworkPolicy)
+	}{
+		{
+			name:  "declared ports open to all sources without dependents",
+			stage: "alpha",
+			ports: []Port{{Name: "http", ContainerPort: 8080}, {Name: "dns", ContainerPort: 5353, Protocol: "UDP"}},
+			validate: func(t *testing.T, policy *networkingv1.NetworkPolicy) {
+				if policy.Name != "myapp-alpha" || policy.Spec.PodSelector.MatchLabels[kubernetesLabelKeyApp] != "myapp-alpha" {
+					t.Errorf("Unexpected name %q or pod selector %+v", policy.Name, policy.Spec.PodSelector)
+				}
+				if len(policy.Spec.Ingress) != 1 || len(policy.Spec.Ingress[0].From) != 0 || len(policy.Spec.Ingress[0].Ports) != 2 {
+					t.Fatalf("Expected single rule with 2 ports from any source, got %+v", policy.Spec.Ingress)
+				}
+				if dns := policy.Spec.Ingress[0].Ports[1]; dns.Port.IntValue() != 5353 || *dns.Protocol != apiv1.ProtocolUDP {
+					t.Errorf("Unexpected dns port %+v", dns)
+				}
+			},
+		},
+		{
+			name:  "declared ports open only to dependents and prometheus",
+			stage: stageProduction,
+			ports: []Port{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9090}},
+			validate: func(t *testing.T, policy *networkingv1.NetworkPolicy) {
+				if len(policy.Spec.Ingress) != 2 {
+					t.Fatalf("Expected dependents and prometheus rules, got %+v", policy.Spec.Ingress)
+				}
+				dependents := policy.Spec.Ingress[0]
+				if len(dependents.From) != 2 || len(dependents.Ports) != 2 ||
+					dependents.From[0].PodSelector.MatchLabels[kubernetesLabelKeyApp] != "worker" ||
+					dependents.From[1].PodSelector.MatchLabels[kubernetesLabelKeyApp] != "legacy" {
+					t.Errorf("Unexpected dependents rule %+v", dependents)
+				}
+				prometheus := policy.Spec.Ingress[1]
+				if prometheus.From[0].NamespaceSelector.MatchLabels[kubernetesLabelKeyNamespace] != defaultPrometheusNamespace ||
+					len(prometheus.Ports) != 1 || prometheus.Ports[0].Port.IntValue() != 9090 {
+					t.Errorf("Unexpected prometheus rule %+v", prometheus)
+				}
+			},
+		},
+		{
+			name:       "metrics port closed when monitoring disabled",
+			stage:      stageProduction,
+			ports:      []Port{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9090}},
+			monitoring: &Monitoring{Disabled: true},
+			validate: func(t *testing.T, policy *networkingv1.NetworkPolicy) {
+				if len(policy.Spec.Ingress) != 1 || len(policy.Spec.Ingress[0].From) != 2 {
+					t.Errorf("Expected only dependents rule, got %+v", policy.Spec.Ingress)
+				}
+			},
+		},
+		{
+			name:  "deny all without ports",
+			stage: stageProduction,
+			validate: func(t *testing.T, policy *networkingv1.NetworkPolicy) {
+				if len(policy.Spec.Ingress) != 0 || policy.Spec.PolicyTypes[0] != networkingv1.PolicyTypeIngress {
+					t.Errorf("Expected deny all ingress policy, got %+v", policy.Spec)
+				}
+			},
+		},
+		{
+			name:       "ingress from sources, dependents and prometheus",
+			stage:      "beta",
+			ports:      []Port{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9090}},
+			monitoring: &Monitoring{PrometheusNamespace: "observability"},
+			policy: &NetworkPolicy{IngressFrom: []NetworkPolicySource{
+				{Namespace: "ingress-nginx", Ports: []string{"http"}},
+				{CIDR: "10.0.0.0/8"},
+			}},
+			validate: func(t *testing.T, policy *networkingv1.NetworkPolicy) {
+				if len(policy.Spec.Ingress) != 4 {
+					t.Fatalf("Expected 4 rules, got %+v", policy.Spec.Ingress)
+				}
+				ingress := policy.Spec.Ingress[0]
+				if ingress.From[0].NamespaceSelector.MatchLabels[kubernetesLabelKeyNamespace] != "ingress-nginx" ||
+					len(ingress.Ports) != 1 || ingress.Ports[0].Port.IntValue() != 8080 {
+					t.Errorf("Unexpected ingress controller rule %+v", ingress)
+				}
+				if cidr := policy.Spec.Ingress[1]; cidr.From[0].IPBlock.CIDR != "10.0.0.0/8" || len(cidr.Ports) != 2 {
+					t.Errorf("Unexpected cidr rule %+v", cidr)
+				}
+				if prometheus := policy.Spec.Ingress[3]; prometheus.From[0].NamespaceSelector.MatchLabels[kubernetesLabelKeyNamespace] != "observability" ||
+					len(prometheus.Ports) != 1 || prometheus.Ports[0].Port.IntValue() != 9090 {
+					t.Errorf("Unexpected prometheus rule %+v", prometheus)
+				}
+				dependents := policy.Spec.Ingress[2].From
+				if len(dependents) != 2 {
+					t.Fatalf("Expected 2 dependents of beta stage, got %+v", dependents)
+				}
+				if dependents[0].PodSelector.MatchLabels[kubernetesLabelKeyApp] != "api-beta" || dependents[0].NamespaceSelector != nil {
+					t.Errorf("Unexpected dependent in the same namespace %+v", dependents[0])
+				}
+				if dependents[1].PodSelector.MatchLabels[kubernetesLabelKeyApp] != "worker-beta" ||
+					dependents[1].NamespaceSelector.MatchLabels[kubernetesLabelKeyNamespace] != "jobs" {
+					t.Errorf("Unexpected dependent in other namespace %+v", dependents[1])
+				}
+			},
+		},
+		{
+			name:   "disabled",
+			stage:  stageProduction,
+			ports:  []Port{{Name: "http", ContainerPort: 8080}},
+			policy: &NetworkPolicy{Disabled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := newTestApplication()
+			config.Ports = tt.ports
+			config.NetworkPolicy = tt.policy
+			config.Monitoring = tt.monitoring
+			api := &Configuration{Application: "api", Namespace: "default", DependsOn: []DependsOn{{Name: "myapp"}},
+				Profiles: &[]*Profile{{ProfileName: "beta"}}}
+			worker := &Configuration{Application: "worker", Namespace: "jobs", DependsOn: []DependsOn{{Name: "myapp"}, {Name: "kafka-config"}},
+				Profiles: &[]*Profile{{ProfileName: "beta"}, {ProfileName: stageProduction}}}
+			legacy := &Configuration{Application: "legacy", Namespace: "default", DependsOn: []DependsOn{{Name: "myapp"}},
+				Profiles: &[]*Profile{{ProfileName: stageProduction}}}
+			ResolveDependents([]*Configuration{config, api, worker, legacy})
+
+			policy := NewNetworkPolicy(config, tt.stage)
+			if tt.validate == nil {
+				if policy != nil {
+					t.Errorf("Expected no policy, got %+v", policy.Spec)
+				}
+				return
+			}
+			tt.validate(t, policy)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newPodTemplate return k8s pod template of the application workload
+func newPodTemplate(config *Configuration, tier *Datacenter, stage, organization string) apiv1.PodTemplateSpec {
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	registry := config.ImageRegistry(organization)
+	security := config.PodSecurityContext()
+	policy := config.PodResourcePolicy()
+
+	template := apiv1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				kubernetesLabelKeyApp: application,
+			},
+		},
+		Spec: apiv1.PodSpec{
+			ServiceAccountName:            application,
+			SecurityContext:               newPodSecurityContext(security),
+			InitContainers:                newContainers(config.PodInitContainers(tier), registry, security, policy),
+			Containers:                    append(newContainer(config, tier, organization, stage), newContainers(config.PodSidecars(tier), registry, security, policy)...),
+			Affinity:                      newAffinity(application, config.NodePool, tier.Scheduling),
+			TopologySpreadConstraints:     newTopologySpreadConstraints(application, tier.Scheduling),
+			Tolerations:                   newToleration(config.NodePool, tier.Scheduling),
+			Volumes:                       newVolume(config.PodVolumes()),
+			TerminationGracePeriodSeconds: int64Ptr(20),
+			PriorityClassName:             tier.PodPriority,
+		},
+	}
+
+	if tier.PodPriority == "high-priority" {
+		template.Spec.TerminationGracePeriodSeconds = int64Ptr(60)
+	}
+
+	// ChaosMonkey terminates instances of long running workloads only
+	if tier.ChaosMonkey.Enabled && !config.IsBatch() {
+		template.Labels = newTemplateLabels(application)
+	}
+
+	return template
+}
@@ -23,107 +23,97 @@ import (
 
 const (
 	probeTypeFile   = "file"
+	probeTypeHTTP   = "http"
+	probeTypeGRPC   = "grpc"
+	probeTypeTCP    = "tcp"
+	probeTypeExec   = "exec"
 	probePathHealth = "/health"
+	probePort       = 8080
 )
 
-// newLivenessProbe return k8s liveness probe object
-func newLivenessProbe(tier *Datacenter) *apiv1.Probe { //nolint:dupl // similar structure to newStartupProbe is acceptable
-	var probeHandler apiv1.ProbeHandler
-
-	if tier.LivenessProbe.Type == probeTypeFile {
-		probeHandler = apiv1.ProbeHandler{
-			Exec: &apiv1.ExecAction{
-				Command: []string{containerProbeExecCat, "/tmp/live"},
-			},
-		}
-	} else {
-		if tier.LivenessProbe.Path == "" {
-			tier.LivenessProbe.Path = probePathHealth
-		}
-		probeHandler = apiv1.ProbeHandler{
-			HTTPGet: &apiv1.HTTPGetAction{
-				Port: intstr.FromInt(getIntOrDefault(tier.LivenessProbe.Port, 8080)),
-				Path: tier.LivenessProbe.Path,
-			},
-		}
-	}
-
-	return &apiv1.Probe{
-		ProbeHandler:        probeHandler,
-		FailureThreshold:    tier.LivenessProbe.FailureThreshold,
-		InitialDelaySeconds: tier.LivenessProbe.Delay,
-		PeriodSeconds:       tier.LivenessProbe.PeriodSeconds,
-		SuccessThreshold:    tier.LivenessProbe.SuccessThreshold,
-		TimeoutSeconds:      tier.LivenessProbe.TimeoutSeconds,
-	}
+// newLivenessProbe return k8s liveness probe object, nil is returned when datacenter has no liveness probe
+func newLivenessProbe(tier *Datacenter) *apiv1.Probe {
+	return newProbe(tier.LivenessProbe, "/tmp/live")
 }
 
-// newReadinessProbe return k8s readiness probe object
+// newReadinessProbe return k8s readiness probe object, liveness probe settings are used when datacenter
+// has no readiness probe
 func newReadinessProbe(tier *Datacenter) *apiv1.Probe {
 	if tier.ReadinessProbe == nil {
-		tier.ReadinessProbe = tier.LivenessProbe
+		return newProbe(tier.LivenessProbe, "/tmp/ready")
 	}
 
-	var probeHandler apiv1.ProbeHandler
+	return newProbe(tier.ReadinessProbe, "/tmp/ready")
+}
 
-	if tier.LivenessProbe.Type == probeTypeFile {
-		probeHandler = apiv1.ProbeHandler{
-			Exec: &apiv1.ExecAction{
-				Command: []string{containerProbeExecCat, "/tmp/ready"},
-			},
-		}
-	} else {
-		if tier.ReadinessProbe.Path == "" {
-			tier.ReadinessProbe.Path = probePathHealth
-		}
-		probeHandler = apiv1.ProbeHandler{
-			HTTPGet: &apiv1.HTTPGetAction{
-				Port: intstr.FromInt(getIntOrDefault(tier.ReadinessProbe.Port, 8080)),
-				Path: tier.ReadinessProbe.Path,
-			},
-		}
+// newStartupProbe return k8s startup probe object
+func newStartupProbe(tier *Datacenter) *apiv1.Probe {
+	return newProbe(tier.StartupProbe, "/tmp/started")
+}
+
+// newProbe return k8s probe object with handler of the probe type, file probe checks existence of the file
+func newProbe(probe *Probe, file string) *apiv1.Probe {
+	if probe == nil {
+		return nil
 	}
 
 	return &apiv1.Probe{
-		ProbeHandler:        probeHandler,
-		FailureThreshold:    tier.ReadinessProbe.FailureThreshold,
-		InitialDelaySeconds: tier.ReadinessProbe.Delay,
-		PeriodSeconds:       tier.ReadinessProbe.PeriodSeconds,
-		SuccessThreshold:    tier.ReadinessProbe.SuccessThreshold,
-		TimeoutSeconds:      tier.ReadinessProbe.TimeoutSeconds,
+		ProbeHandler:        newProbeHandler(probe, file),
+		FailureThreshold:    probe.FailureThreshold,
+		InitialDelaySeconds: probe.Delay,
+		PeriodSeconds:       probe.PeriodSeconds,
+		SuccessThreshold:    probe.SuccessThreshold,
+		TimeoutSeconds:      probe.TimeoutSeconds,
 	}
 }
 
-// newStartupProbe return k8s startup probe object
-func newStartupProbe(tier *Datacenter) *apiv1.Probe { //nolint:dupl // similar structure to newLivenessProbe is acceptable
-	var probeHandler apiv1.ProbeHandler
+// newProbeHandler return k8s probe handler, HTTP GET is used for "http" and unknown probe types
+func newProbeHandler(probe *Probe, file string) apiv1.ProbeHandler {
+	port := getIntOrDefault(probe.Port, probePort)
 
-	if tier.StartupProbe.Type == probeTypeFile {
-		probeHandler = apiv1.ProbeHandler{
+	switch probe.Type {
+	case probeTypeFile:
+		return apiv1.ProbeHandler{
 			Exec: &apiv1.ExecAction{
-				Command: []string{containerProbeExecCat, "/tmp/started"},
+				Command: []string{containerProbeExecCat, file},
 			},
 		}
-	} else {
-		if tier.StartupProbe.Path == "" {
-			tier.StartupProbe.Path = probePathHealth
+	case probeTypeExec:
+		return apiv1.ProbeHandler{
+			Exec: &apiv1.ExecAction{
+				Command: probe.Command,
+			},
+		}
+	case probeTypeGRPC:
+		handler := apiv1.ProbeHandler{
+			GRPC: &apiv1.GRPCAction{
+				Port: int32(port), //nolint:gosec // port range is checked by JSON Schema
+			},
 		}
-		probeHandler = apiv1.ProbeHandler{
-			HTTPGet: &apiv1.HTTPGetAction{
-				Port: intstr.FromInt(getIntOrDefault(tier.StartupProbe.Port, 8080)),
-				Path: tier.StartupProbe.Path,
+		if probe.Service != "" {
+			handler.GRPC.Service = &probe.Service
+		}
+		return handler
+	case probeTypeTCP:
+		return apiv1.ProbeHandler{
+			TCPSocket: &apiv1.TCPSocketAction{
+				Port: intstr.FromInt(port),
 			},
 		}
 	}
 
-	return &apiv1.Probe{
-		ProbeHandler:        probeHandler,
-		FailureThreshold:    tier.StartupProbe.FailureThreshold,
-		InitialDelaySeconds: tier.StartupProbe.Delay,
-		PeriodSeconds:       tier.StartupProbe.PeriodSeconds,
-		SuccessThreshold:    tier.StartupProbe.SuccessThreshold,
-		TimeoutSeconds:      tier.StartupProbe.TimeoutSeconds,
+	handler := apiv1.ProbeHandler{
+		HTTPGet: &apiv1.HTTPGetAction{
+			Port:   intstr.FromInt(port),
+			Path:   mergeString(probePathHealth, probe.Path),
+			Scheme: apiv1.URIScheme(probe.Scheme),
+		},
 	}
+	for _, header := range probe.Headers {
+		handler.HTTPGet.HTTPHeaders = append(handler.HTTPGet.HTTPHeaders, apiv1.HTTPHeader{Name: header.Name, Value: header.Value})
+	}
+
+	return handler
 }
 
 func getIntOrDefault(value, defaultValue int) int {
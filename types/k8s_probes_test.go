@@ -226,6 +226,21 @@ func TestNewReadinessProbe(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "readiness probe uses its own type",
+			tier: &Datacenter{
+				LivenessProbe:  &Probe{Type: probeTypeFile},
+				ReadinessProbe: &Probe{Type: probeTypeTCP, Port: 9090},
+			},
+			validate: func(t *testing.T, probe *apiv1.Probe) {
+				if probe.ProbeHandler.Exec != nil || probe.ProbeHandler.TCPSocket == nil {
+					t.Fatalf("Expected TCPSocket probe handler, got %+v", probe.ProbeHandler)
+				}
+				if probe.ProbeHandler.TCPSocket.Port != intstr.FromInt(9090) {
+					t.Errorf("Expected port 9090, got %v", probe.ProbeHandler.TCPSocket.Port)
+				}
+			},
+		},
 		{
 			name: "readiness probe without path uses default",
 			tier: &Datacenter{
@@ -343,3 +358,70 @@ func TestNewStartupProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestNewProbeHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		probe    *Probe
+		validate func(*testing.T, apiv1.ProbeHandler)
+	}{
+		{
+			name:  "exec probe",
+			probe: &Probe{Type: probeTypeExec, Command: []string{"pg_isready", "-h", "localhost"}},
+			validate: func(t *testing.T, handler apiv1.ProbeHandler) {
+				if handler.Exec == nil || len(handler.Exec.Command) != 3 || handler.Exec.Command[0] != "pg_isready" {
+					t.Errorf("Expected exec command, got %+v", handler.Exec)
+				}
+			},
+		},
+		{
+			name:  "grpc probe with service",
+			probe: &Probe{Type: probeTypeGRPC, Port: 9000, Service: "liveness"},
+			validate: func(t *testing.T, handler apiv1.ProbeHandler) {
+				if handler.GRPC == nil || handler.GRPC.Port != 9000 || *handler.GRPC.Service != "liveness" {
+					t.Errorf("Expected grpc action, got %+v", handler.GRPC)
+				}
+			},
+		},
+		{
+			name:  "grpc probe without service",
+			probe: &Probe{Type: probeTypeGRPC},
+			validate: func(t *testing.T, handler apiv1.ProbeHandler) {
+				if handler.GRPC == nil || handler.GRPC.Port != 8080 || handler.GRPC.Service != nil {
+					t.Errorf("Expected grpc action on default port, got %+v", handler.GRPC)
+				}
+			},
+		},
+		{
+			name: "https probe with headers",
+			probe: &Probe{
+				Type:    probeTypeHTTP,
+				Path:    "/ready",
+				Scheme:  "HTTPS",
+				Headers: []HTTPHeader{{Name: "Host", Value: "myapp.example.com"}},
+			},
+			validate: func(t *testing.T, handler apiv1.ProbeHandler) {
+				if handler.HTTPGet == nil || handler.HTTPGet.Scheme != apiv1.URISchemeHTTPS || handler.HTTPGet.Path != "/ready" {
+					t.Fatalf("Expected https action, got %+v", handler.HTTPGet)
+				}
+				if len(handler.HTTPGet.HTTPHeaders) != 1 || handler.HTTPGet.HTTPHeaders[0].Value != "myapp.example.com" {
+					t.Errorf("Expected Host header, got %v", handler.HTTPGet.HTTPHeaders)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.validate(t, newProbeHandler(tt.probe, "/tmp/live"))
+		})
+	}
+}
+
+func TestNewProbeWithoutSettings(t *testing.T) {
+	tier := &Datacenter{}
+
+	if newLivenessProbe(tier) != nil || newReadinessProbe(tier) != nil || newStartupProbe(tier) != nil {
+		t.Errorf("Expected no probes without settings")
+	}
+}
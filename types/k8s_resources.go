@@ -17,24 +17,128 @@ limitations under the License.
 package types
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
-// newResourceRequirements return k8s resource requirements object
-func newResourceRequirements(tier *Datacenter) apiv1.ResourceRequirements {
-	if tier.Resources.Limits == nil {
-		tier.Resources.Limits = tier.Resources.Requests
+// newResourceRequirements return k8s resource requirements object with limits of the resource policy
+func newResourceRequirements(resources *ResourceRequirements, policy *ResourcePolicy) apiv1.ResourceRequirements {
+	requirements := apiv1.ResourceRequirements{}
+	if resources == nil {
+		return requirements
+	}
+
+	if limits := policy.Limits(resources); limits != nil {
+		requirements.Limits = apiv1.ResourceList{}
+		addResources(requirements.Limits, "", limits)
+	}
+	if resources.Requests != nil {
+		requirements.Requests = apiv1.ResourceList{}
+		addResources(requirements.Requests, "", resources.Requests)
+	}
+
+	return requirements
+}
+
+// CheckPodResources returns error of the first invalid resource quantity of containers of the application pod in the datacenter
+func (c *Configuration) CheckPodResources(tier *Datacenter) error {
+	if err := tier.Resources.CheckQuantities(); err != nil {
+		return fmt.Errorf("resources of datacenter %q: %w", tier.TierName, err)
+	}
+	for _, container := range append(c.PodSidecars(tier), c.PodInitContainers(tier)...) {
+		if err := container.Resources.CheckQuantities(); err != nil {
+			return fmt.Errorf("resources of container %q: %w", container.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// PodResources returns requests and limits of the application pod in the datacenter which are used for scheduling:
+// sum of application container and sidecars, or the largest init container when it is greater,
+// quantities should be checked with CheckPodResources before
+func (c *Configuration) PodResources(tier *Datacenter) apiv1.ResourceRequirements {
+	policy := c.PodResourcePolicy()
+	pod := newResourceRequirements(tier.Resources, policy)
+	initContainers := apiv1.ResourceRequirements{}
+
+	for _, container := range c.PodSidecars(tier) {
+		addRequirements(&pod, newResourceRequirements(container.Resources, policy))
+	}
+	for _, container := range c.PodInitContainers(tier) {
+		requirements := newResourceRequirements(container.Resources, policy)
+		if container.RestartPolicy == ContainerRestartPolicyAlways {
+			addRequirements(&pod, requirements)
+			continue
+		}
+		maxRequirements(&initContainers, requirements)
+	}
+	maxRequirements(&pod, initContainers)
+
+	return pod
+}
+
+// ExceededResources returns messages of pod requests and limits which exceed maximum of the resource policy
+func (p *ResourcePolicy) ExceededResources(pod apiv1.ResourceRequirements) []string {
+	exceeded := []string{}
+	quantities := p.MaxPod.Quantities()
+
+	for _, name := range slices.Sorted(maps.Keys(quantities)) {
+		ceiling, err := resource.ParseQuantity(quantities[name])
+		if err != nil {
+			continue
+		}
+
+		if quantity, ok := pod.Requests[apiv1.ResourceName(name)]; ok && quantity.Cmp(ceiling) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("pod %s requests %s exceed resource policy maximum %s", name, quantity.String(), ceiling.String()))
+		}
+		if quantity, ok := pod.Limits[apiv1.ResourceName(name)]; ok && quantity.Cmp(ceiling) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("pod %s limits %s exceed resource policy maximum %s", name, quantity.String(), ceiling.String()))
+		}
+	}
+
+	return exceeded
+}
+
+// addRequirements adds requests and limits of the container to requests and limits of the pod
+func addRequirements(pod *apiv1.ResourceRequirements, container apiv1.ResourceRequirements) {
+	pod.Requests = combineResources(pod.Requests, container.Requests, func(sum, quantity *resource.Quantity) { sum.Add(*quantity) })
+	pod.Limits = combineResources(pod.Limits, container.Limits, func(sum, quantity *resource.Quantity) { sum.Add(*quantity) })
+}
+
+// maxRequirements sets requests and limits of the pod to the greatest of pod and container quantities
+func maxRequirements(pod *apiv1.ResourceRequirements, container apiv1.ResourceRequirements) {
+	greatest := func(current, quantity *resource.Quantity) {
+		if quantity.Cmp(*current) > 0 {
+			*current = quantity.DeepCopy()
+		}
+	}
+	pod.Requests = combineResources(pod.Requests, container.Requests, greatest)
+	pod.Limits = combineResources(pod.Limits, container.Limits, greatest)
+}
+
+// combineResources combines quantities of the same resource, resources missing in the list are copied
+func combineResources(list, other apiv1.ResourceList, combine func(current, quantity *resource.Quantity)) apiv1.ResourceList {
+	if len(other) == 0 {
+		return list
+	}
+	if list == nil {
+		list = apiv1.ResourceList{}
 	}
 
-	return apiv1.ResourceRequirements{
-		Limits: apiv1.ResourceList{
-			apiv1.ResourceCPU:    resource.MustParse(tier.Resources.Limits.CPU),
-			apiv1.ResourceMemory: resource.MustParse(tier.Resources.Limits.Memory),
-		},
-		Requests: apiv1.ResourceList{
-			apiv1.ResourceCPU:    resource.MustParse(tier.Resources.Requests.CPU),
-			apiv1.ResourceMemory: resource.MustParse(tier.Resources.Requests.Memory),
-		},
+	for name, quantity := range other {
+		current, ok := list[name]
+		if !ok {
+			list[name] = quantity.DeepCopy()
+			continue
+		}
+		combine(&current, &quantity)
+		list[name] = current
 	}
+
+	return list
 }
@@ -0,0 +1,72 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// newPodSecurityContext return k8s pod security context with user, group, fsGroup and seccomp profile of all containers
+func newPodSecurityContext(settings *SecurityContext) *apiv1.PodSecurityContext {
+	podSecurityContext := &apiv1.PodSecurityContext{
+		RunAsNonRoot: settings.RunAsNonRoot,
+		RunAsUser:    settings.RunAsUser,
+		RunAsGroup:   settings.RunAsGroup,
+		FSGroup:      settings.FSGroup,
+	}
+
+	if settings.SeccompProfile != "" {
+		podSecurityContext.SeccompProfile = &apiv1.SeccompProfile{
+			Type: apiv1.SeccompProfileType(settings.SeccompProfile),
+		}
+	}
+
+	return podSecurityContext
+}
+
+// newContainerSecurityContext return k8s container security context, user and group are set only
+// when the container overrides pod settings
+func newContainerSecurityContext(settings, override *SecurityContext) *apiv1.SecurityContext {
+	securityContext := &apiv1.SecurityContext{
+		ReadOnlyRootFilesystem:   settings.ReadOnlyRootFilesystem,
+		AllowPrivilegeEscalation: settings.AllowPrivilegeEscalation,
+		Privileged:               settings.Privileged,
+	}
+
+	if settings.Capabilities != nil {
+		securityContext.Capabilities = &apiv1.Capabilities{}
+		for _, capability := range settings.Capabilities.Add {
+			securityContext.Capabilities.Add = append(securityContext.Capabilities.Add, apiv1.Capability(capability))
+		}
+		for _, capability := range settings.Capabilities.Drop {
+			securityContext.Capabilities.Drop = append(securityContext.Capabilities.Drop, apiv1.Capability(capability))
+		}
+	}
+
+	if override != nil {
+		securityContext.RunAsNonRoot = override.RunAsNonRoot
+		securityContext.RunAsUser = override.RunAsUser
+		securityContext.RunAsGroup = override.RunAsGroup
+		if override.SeccompProfile != "" {
+			securityContext.SeccompProfile = &apiv1.SeccompProfile{
+				Type: apiv1.SeccompProfileType(override.SeccompProfile),
+			}
+		}
+	}
+
+	return securityContext
+}
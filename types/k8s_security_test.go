@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestNewPodTemplateSecurityContext(t *testing.T) {
+	config, tier := newTestApplication()
+	config.SecurityContext = &SecurityContext{RunAsUser: int64Ptr(1000), FSGroup: int64Ptr(2000)}
+	tier.Sidecars = []Container{{Name: "proxy", Image: "envoy:1.0", SecurityContext: &SecurityContext{RunAsUser: int64Ptr(101)}}}
+
+	spec := newPodTemplate(config, tier, stageProduction, "myorg").Spec
+
+	pod := spec.SecurityContext
+	if !*pod.RunAsNonRoot || *pod.RunAsUser != 1000 || *pod.FSGroup != 2000 || pod.SeccompProfile.Type != apiv1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("Unexpected pod security context %+v", pod)
+	}
+
+	application := spec.Containers[0].SecurityContext
+	if *application.AllowPrivilegeEscalation || application.Capabilities.Drop[0] != "ALL" || application.RunAsUser != nil {
+		t.Errorf("Expected container settings without pod settings, got %+v", application)
+	}
+
+	proxy := spec.Containers[1].SecurityContext
+	if *proxy.RunAsUser != 101 || *proxy.AllowPrivilegeEscalation || proxy.SeccompProfile != nil {
+		t.Errorf("Expected sidecar to override pod user, got %+v", proxy)
+	}
+}
@@ -19,10 +19,14 @@ package types
 import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-// NewService return k8s service object
-func NewService(application, stage, namespace string, ports []Port) *apiv1.Service {
+// ServiceTypeHeadless is the default type of application Service, ClusterIP "None" is set for it
+const ServiceTypeHeadless = "Headless"
+
+// NewService return k8s service object, headless service is generated when service settings aren't set
+func NewService(application, stage, namespace string, ports []Port, settings *ServiceSettings) *apiv1.Service {
 	servicePorts := []apiv1.ServicePort{}
 
 	if stage != "production" {
@@ -31,13 +35,22 @@ func NewService(application, stage, namespace string, ports []Port) *apiv1.Servi
 
 	for _, port := range ports {
 		servicePort := apiv1.ServicePort{
-			Name: port.Name,
-			Port: port.ContainerPort,
+			Name:       port.Name,
+			Protocol:   apiv1.Protocol(port.Protocol),
+			Port:       port.ExposedPort(),
+			TargetPort: intstr.FromInt32(port.ContainerPort),
+		}
+		if port.AppProtocol != "" {
+			servicePort.AppProtocol = &port.AppProtocol
 		}
 		servicePorts = append(servicePorts, servicePort)
 	}
 
-	return &apiv1.Service{
+	if settings == nil {
+		settings = &ServiceSettings{}
+	}
+
+	service := &apiv1.Service{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Service",
 			APIVersion: "v1",
@@ -45,13 +58,25 @@ func NewService(application, stage, namespace string, ports []Port) *apiv1.Servi
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      application,
 			Namespace: namespace,
+			Labels: map[string]string{
+				kubernetesLabelKeyApp: application,
+			},
+			Annotations: settings.Annotations,
 		},
 		Spec: apiv1.ServiceSpec{
-			ClusterIP: "None",
-			Ports:     servicePorts,
+			Ports: servicePorts,
 			Selector: map[string]string{
 				kubernetesLabelKeyApp: application,
 			},
 		},
 	}
+
+	switch settings.Type {
+	case "", ServiceTypeHeadless:
+		service.Spec.ClusterIP = apiv1.ClusterIPNone
+	default:
+		service.Spec.Type = apiv1.ServiceType(settings.Type)
+	}
+
+	return service
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 )
 
 func TestNewService(t *testing.T) {
@@ -13,6 +14,7 @@ func TestNewService(t *testing.T) {
 		stage       string
 		namespace   string
 		ports       []Port
+		settings    *ServiceSettings
 		validate    func(*testing.T, *apiv1.Service)
 	}{
 		{
@@ -40,6 +42,9 @@ func TestNewService(t *testing.T) {
 				if svc.Spec.Selector["app"] != "myapp" {
 					t.Errorf("Expected Selector['app'] 'myapp', got %q", svc.Spec.Selector["app"])
 				}
+				if svc.Labels["app"] != "myapp" {
+					t.Errorf("Expected Labels['app'] 'myapp' selected by ServiceMonitor, got %q", svc.Labels["app"])
+				}
 			},
 		},
 		{
@@ -83,6 +88,33 @@ func TestNewService(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:        "load balancer with service port and protocol",
+			application: "myapp",
+			stage:       "production",
+			namespace:   "default",
+			ports: []Port{
+				{Name: "grpc", ContainerPort: 8080, ServicePort: 443, AppProtocol: "kubernetes.io/h2c"},
+				{Name: "dns", ContainerPort: 5353, Protocol: "UDP"},
+			},
+			settings: &ServiceSettings{Type: "LoadBalancer", Annotations: map[string]string{"cloud.google.com/l4-rbs": "enabled"}},
+			validate: func(t *testing.T, svc *apiv1.Service) {
+				if svc.Spec.Type != apiv1.ServiceTypeLoadBalancer || svc.Spec.ClusterIP != "" {
+					t.Errorf("Expected LoadBalancer service without ClusterIP, got %q %q", svc.Spec.Type, svc.Spec.ClusterIP)
+				}
+				if svc.Annotations["cloud.google.com/l4-rbs"] != "enabled" {
+					t.Errorf("Expected service annotations, got %v", svc.Annotations)
+				}
+				grpc := svc.Spec.Ports[0]
+				if grpc.Port != 443 || grpc.TargetPort.IntValue() != 8080 || *grpc.AppProtocol != "kubernetes.io/h2c" {
+					t.Errorf("Unexpected grpc port %+v", grpc)
+				}
+				dns := svc.Spec.Ports[1]
+				if dns.Port != 5353 || dns.TargetPort.IntValue() != 5353 || dns.Protocol != apiv1.ProtocolUDP || dns.AppProtocol != nil {
+					t.Errorf("Unexpected dns port %+v", dns)
+				}
+			},
+		},
 		{
 			name:        "empty application name",
 			application: "",
@@ -99,7 +131,7 @@ func TestNewService(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := NewService(tt.application, tt.stage, tt.namespace, tt.ports)
+			result := NewService(tt.application, tt.stage, tt.namespace, tt.ports, tt.settings)
 			if result == nil {
 				t.Fatal("NewService returned nil")
 			}
@@ -107,3 +139,55 @@ func TestNewService(t *testing.T) {
 		})
 	}
 }
+
+func TestNewIngressRoute(t *testing.T) {
+	config, _ := newTestApplication()
+	config.Type = "service"
+	config.Ports = []Port{{Name: "http", ContainerPort: 8080}, {Name: "admin", ContainerPort: 8081}}
+	config.Ingress = &Ingress{
+		ClassName:   "nginx",
+		Hosts:       []string{"api.example.com"},
+		Paths:       []IngressPath{{Path: "/"}, {Path: "/admin", PathType: "Exact", Port: "admin"}},
+		TLSSecret:   "api-tls",
+		Annotations: map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "8m"},
+	}
+
+	ingress, ok := NewIngressRoute(config, "beta").(*networkingv1.Ingress)
+	if !ok {
+		t.Fatal("Expected Ingress by default")
+	}
+	if ingress.Name != "myapp-beta" || *ingress.Spec.IngressClassName != "nginx" || len(ingress.Annotations) != 1 {
+		t.Errorf("Unexpected ingress metadata %+v or class %q", ingress.ObjectMeta, *ingress.Spec.IngressClassName)
+	}
+	if len(ingress.Spec.Rules) != 1 || ingress.Spec.Rules[0].Host != "beta-api.example.com" {
+		t.Fatalf("Expected rule of beta-api.example.com host, got %+v", ingress.Spec.Rules)
+	}
+	paths := ingress.Spec.Rules[0].HTTP.Paths
+	if len(paths) != 2 || *paths[0].PathType != networkingv1.PathTypePrefix || paths[0].Backend.Service.Port.Name != "http" ||
+		paths[1].Backend.Service.Port.Name != "admin" || paths[1].Backend.Service.Name != "myapp-beta" {
+		t.Errorf("Unexpected paths %+v", paths)
+	}
+	if len(ingress.Spec.TLS) != 1 || ingress.Spec.TLS[0].SecretName != "api-tls" || ingress.Spec.TLS[0].Hosts[0] != "beta-api.example.com" {
+		t.Errorf("Unexpected TLS %+v", ingress.Spec.TLS)
+	}
+
+	config.Ingress.Kind = IngressKindHTTPRoute
+	config.Ingress.Gateway = &Gateway{Name: "public", Namespace: "gateways"}
+	route, ok := NewIngressRoute(config, stageProduction).(*HTTPRoute)
+	if !ok {
+		t.Fatal("Expected HTTPRoute for httpRoute kind")
+	}
+	if route.APIVersion != "gateway.networking.k8s.io/v1" || route.Spec.Hostnames[0] != "api.example.com" {
+		t.Errorf("Unexpected api version %q or hostnames %v", route.APIVersion, route.Spec.Hostnames)
+	}
+	if len(route.Spec.ParentRefs) != 1 || route.Spec.ParentRefs[0].Name != "public" || route.Spec.ParentRefs[0].Namespace != "gateways" {
+		t.Errorf("Unexpected parent refs %+v", route.Spec.ParentRefs)
+	}
+	if len(route.Spec.Rules) != 2 || route.Spec.Rules[0].Matches[0].Path.Type != "PathPrefix" ||
+		route.Spec.Rules[1].Matches[0].Path.Type != "Exact" || route.Spec.Rules[1].BackendRefs[0].Port != 8081 {
+		t.Errorf("Unexpected rules %+v", route.Spec.Rules)
+	}
+	if copied := route.DeepCopyObject().(*HTTPRoute); copied.Spec.Rules[0].Matches[0].Path == route.Spec.Rules[0].Matches[0].Path {
+		t.Error("Expected deep copy of path match")
+	}
+}
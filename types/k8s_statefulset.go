@@ -0,0 +1,133 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewStatefulSet return k8s statefulset object, persistent volume claims are mounted to the application container
+func NewStatefulSet(config *Configuration, tier *Datacenter, stage, organization string) *appsv1.StatefulSet {
+	application := config.Application
+	if stage != stageProduction {
+		application = config.Application + "-" + stage
+	}
+
+	settings := config.StatefulSet
+	if settings == nil {
+		settings = &StatefulSetSettings{}
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        application,
+			Namespace:   config.Namespace,
+			Annotations: defaultAnnotations(organization, stage, config.Owners),
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: int32Ptr(tier.Replicas),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					kubernetesLabelKeyApp: application,
+				},
+			},
+			Template:            newPodTemplate(config, tier, stage, organization),
+			ServiceName:         application,
+			PodManagementPolicy: appsv1.PodManagementPolicyType(settings.PodManagementPolicy),
+		},
+	}
+
+	container := &statefulSet.Spec.Template.Spec.Containers[0]
+	for _, claim := range settings.VolumeClaimTemplates {
+		statefulSet.Spec.VolumeClaimTemplates = append(statefulSet.Spec.VolumeClaimTemplates, newPersistentVolumeClaim(claim))
+		container.VolumeMounts = append(container.VolumeMounts, apiv1.VolumeMount{
+			Name:      claim.Name,
+			MountPath: claim.MountPath,
+		})
+	}
+
+	// replicas are managed by HorizontalPodAutoscaler, so redeploy must not reset them
+	if tier.Autoscaling != nil {
+		statefulSet.Spec.Replicas = nil
+	}
+
+	if tier.ChaosMonkey.Enabled {
+		statefulSet.Labels = newMetadataLabels(application, tier.ChaosMonkey)
+	}
+
+	return statefulSet
+}
+
+// CheckQuantities returns error of the first invalid size of volume claim templates
+func (s *StatefulSetSettings) CheckQuantities() error {
+	if s == nil {
+		return nil
+	}
+
+	for _, claim := range s.VolumeClaimTemplates {
+		if _, err := resource.ParseQuantity(claim.Size); err != nil {
+			return fmt.Errorf("invalid size of volume claim template %q %q: %v", claim.Name, claim.Size, err)
+		}
+	}
+
+	return nil
+}
+
+// newPersistentVolumeClaim return k8s persistent volume claim template of statefulset,
+// size is checked with StatefulSetSettings.CheckQuantities before generation
+func newPersistentVolumeClaim(claim VolumeClaimTemplate) apiv1.PersistentVolumeClaim {
+	accessModes := []apiv1.PersistentVolumeAccessMode{}
+	for _, mode := range claim.AccessModes {
+		accessModes = append(accessModes, apiv1.PersistentVolumeAccessMode(mode))
+	}
+	if len(accessModes) == 0 {
+		accessModes = append(accessModes, apiv1.ReadWriteOnce)
+	}
+
+	pvc := apiv1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: claim.Name,
+		},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: apiv1.VolumeResourceRequirements{
+				Requests: apiv1.ResourceList{
+					apiv1.ResourceStorage: resource.MustParse(claim.Size),
+				},
+			},
+		},
+	}
+
+	if claim.StorageClassName != "" {
+		pvc.Spec.StorageClassName = &claim.StorageClassName
+	}
+
+	return pvc
+}
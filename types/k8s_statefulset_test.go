@@ -0,0 +1,105 @@
+package types
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestNewStatefulSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		stage       string
+		settings    *StatefulSetSettings
+		autoscaling *Autoscaling
+		validate    func(*testing.T, *appsv1.StatefulSet)
+	}{
+		{
+			name:  "default settings",
+			stage: stageProduction,
+			validate: func(t *testing.T, s *appsv1.StatefulSet) {
+				if s.Kind != "StatefulSet" || s.Name != "myapp" || s.Spec.ServiceName != "myapp" {
+					t.Errorf("Unexpected statefulset %s/%s with service %q", s.Kind, s.Name, s.Spec.ServiceName)
+				}
+				if s.Spec.Replicas == nil || *s.Spec.Replicas != 2 {
+					t.Errorf("Expected 2 replicas, got %v", s.Spec.Replicas)
+				}
+				if s.Spec.PodManagementPolicy != "" || len(s.Spec.VolumeClaimTemplates) != 0 {
+					t.Errorf("Expected no policy and claims, got %q and %d", s.Spec.PodManagementPolicy, len(s.Spec.VolumeClaimTemplates))
+				}
+				if s.Spec.Template.Spec.Containers[0].ReadinessProbe == nil {
+					t.Error("Expected readiness probe for statefulset")
+				}
+			},
+		},
+		{
+			name:  "volume claim templates",
+			stage: "staging",
+			settings: &StatefulSetSettings{
+				PodManagementPolicy: "Parallel",
+				VolumeClaimTemplates: []VolumeClaimTemplate{
+					{Name: "data", MountPath: "/data", Size: "10Gi", StorageClassName: "ssd"},
+					{Name: "shared", MountPath: "/shared", Size: "1Gi", AccessModes: []string{"ReadWriteMany"}},
+				},
+			},
+			validate: func(t *testing.T, s *appsv1.StatefulSet) {
+				if s.Name != "myapp-staging" || s.Spec.ServiceName != "myapp-staging" {
+					t.Errorf("Expected stage name, got %q with service %q", s.Name, s.Spec.ServiceName)
+				}
+				if s.Spec.PodManagementPolicy != appsv1.ParallelPodManagement {
+					t.Errorf("Expected Parallel policy, got %q", s.Spec.PodManagementPolicy)
+				}
+				claims := s.Spec.VolumeClaimTemplates
+				if len(claims) != 2 {
+					t.Fatalf("Expected 2 claims, got %d", len(claims))
+				}
+				if claims[0].Spec.StorageClassName == nil || *claims[0].Spec.StorageClassName != "ssd" {
+					t.Errorf("Expected storage class ssd, got %v", claims[0].Spec.StorageClassName)
+				}
+				if claims[0].Spec.AccessModes[0] != apiv1.ReadWriteOnce || claims[1].Spec.AccessModes[0] != apiv1.ReadWriteMany {
+					t.Errorf("Unexpected access modes %v and %v", claims[0].Spec.AccessModes, claims[1].Spec.AccessModes)
+				}
+				if size := claims[0].Spec.Resources.Requests[apiv1.ResourceStorage]; size.String() != "10Gi" {
+					t.Errorf("Expected size 10Gi, got %s", size.String())
+				}
+				mounts := s.Spec.Template.Spec.Containers[0].VolumeMounts
+				if len(mounts) != 2 || mounts[1].Name != "shared" || mounts[1].MountPath != "/shared" {
+					t.Errorf("Unexpected volume mounts %v", mounts)
+				}
+			},
+		},
+		{
+			name:        "replicas managed by autoscaling",
+			stage:       stageProduction,
+			autoscaling: &Autoscaling{MinReplicas: 2, MaxReplicas: 4},
+			validate: func(t *testing.T, s *appsv1.StatefulSet) {
+				if s.Spec.Replicas != nil {
+					t.Errorf("Expected nil replicas, got %d", *s.Spec.Replicas)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, tier := newTestApplication()
+			config.Type = WorkloadTypeStatefulSet
+			config.StatefulSet = tt.settings
+			tier.Autoscaling = tt.autoscaling
+			tt.validate(t, NewStatefulSet(config, tier, tt.stage, "myorg"))
+		})
+	}
+}
+
+func TestStatefulSetCheckQuantities(t *testing.T) {
+	valid := &StatefulSetSettings{VolumeClaimTemplates: []VolumeClaimTemplate{{Name: "data", Size: "10Gi"}}}
+	if err := valid.CheckQuantities(); err != nil {
+		t.Errorf("Expected valid size, got %v", err)
+	}
+
+	invalid := &StatefulSetSettings{VolumeClaimTemplates: []VolumeClaimTemplate{{Name: "data", Size: "10 gigs"}}}
+	if err := invalid.CheckQuantities(); err == nil {
+		t.Errorf("Expected invalid size error")
+	}
+}
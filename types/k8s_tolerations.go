@@ -21,7 +21,11 @@ import (
 )
 
 // newToleration return k8s toleration objects list
-func newToleration(nodepool string) []apiv1.Toleration {
+func newToleration(nodepool string, scheduling *Scheduling) []apiv1.Toleration {
+	if scheduling == nil {
+		scheduling = &Scheduling{}
+	}
+
 	listTolerations := []apiv1.Toleration{
 		{
 			Effect:            apiv1.TaintEffect("NoExecute"),
@@ -39,10 +43,20 @@ func newToleration(nodepool string) []apiv1.Toleration {
 
 	listTolerations = append(listTolerations, apiv1.Toleration{
 		Effect:   apiv1.TaintEffect("NoExecute"),
-		Key:      "dedicated",
+		Key:      mergeString(defaultNodeSelectorKey, scheduling.NodeSelectorKey),
 		Operator: apiv1.TolerationOperator("Equal"),
 		Value:    nodepool,
 	})
 
+	for _, toleration := range scheduling.Tolerations {
+		listTolerations = append(listTolerations, apiv1.Toleration{
+			Effect:            apiv1.TaintEffect(toleration.Effect),
+			Key:               toleration.Key,
+			Operator:          apiv1.TolerationOperator(mergeString("Equal", toleration.Operator)),
+			Value:             toleration.Value,
+			TolerationSeconds: toleration.TolerationSeconds,
+		})
+	}
+
 	return listTolerations
 }
@@ -0,0 +1,48 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTopologySpreadConstraints return k8s topology spread constraints of application pods
+func newTopologySpreadConstraints(application string, scheduling *Scheduling) []apiv1.TopologySpreadConstraint {
+	if scheduling == nil {
+		return nil
+	}
+
+	var constraints []apiv1.TopologySpreadConstraint
+	for _, constraint := range scheduling.TopologySpreadConstraints {
+		maxSkew := constraint.MaxSkew
+		if maxSkew == 0 {
+			maxSkew = 1
+		}
+
+		constraints = append(constraints, apiv1.TopologySpreadConstraint{
+			MaxSkew:           maxSkew,
+			TopologyKey:       mergeString(defaultSpreadTopologyKey, constraint.TopologyKey),
+			WhenUnsatisfiable: apiv1.UnsatisfiableConstraintAction(mergeString(defaultWhenUnsatisfiable, constraint.WhenUnsatisfiable)),
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{kubernetesLabelKeyApp: application},
+			},
+		})
+	}
+
+	return constraints
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestNewPodTemplateScheduling(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduling *Scheduling
+		validate   func(*testing.T, apiv1.PodSpec)
+	}{
+		{
+			name: "default scheduling",
+			validate: func(t *testing.T, spec apiv1.PodSpec) {
+				if len(spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) != 1 {
+					t.Errorf("Expected required pod anti-affinity, got %+v", spec.Affinity.PodAntiAffinity)
+				}
+				if spec.TopologySpreadConstraints != nil || len(spec.Tolerations) != 3 {
+					t.Errorf("Unexpected topology spread %v and tolerations %v", spec.TopologySpreadConstraints, spec.Tolerations)
+				}
+			},
+		},
+		{
+			name: "preferred anti-affinity spread across zones",
+			scheduling: &Scheduling{
+				NodeSelectorKey:           "cloud.google.com/gke-nodepool",
+				PodAntiAffinity:           AffinityPreferred,
+				TopologySpreadConstraints: []TopologySpreadConstraint{{}, {TopologyKey: "kubernetes.io/hostname", MaxSkew: 2, WhenUnsatisfiable: "DoNotSchedule"}},
+				Tolerations:               []Toleration{{Key: "spot", Operator: "Exists", Effect: "NoSchedule"}, {Key: "gpu", Value: "true"}},
+			},
+			validate: func(t *testing.T, spec apiv1.PodSpec) {
+				nodeSelector := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
+				if nodeSelector.MatchExpressions[0].Key != "cloud.google.com/gke-nodepool" || spec.Tolerations[2].Key != "cloud.google.com/gke-nodepool" {
+					t.Errorf("Expected custom node selector key, got %v and %v", nodeSelector, spec.Tolerations[2])
+				}
+
+				antiAffinity := spec.Affinity.PodAntiAffinity
+				if antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil ||
+					antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight != 100 {
+					t.Errorf("Expected preferred pod anti-affinity, got %+v", antiAffinity)
+				}
+
+				zone := spec.TopologySpreadConstraints[0]
+				if zone.TopologyKey != "topology.kubernetes.io/zone" || zone.MaxSkew != 1 || zone.WhenUnsatisfiable != apiv1.ScheduleAnyway ||
+					zone.LabelSelector.MatchLabels[kubernetesLabelKeyApp] != "myapp" {
+					t.Errorf("Unexpected default topology spread constraint %+v", zone)
+				}
+				if host := spec.TopologySpreadConstraints[1]; host.MaxSkew != 2 || host.WhenUnsatisfiable != apiv1.DoNotSchedule {
+					t.Errorf("Unexpected topology spread constraint %+v", host)
+				}
+
+				if len(spec.Tolerations) != 5 || spec.Tolerations[3].Operator != apiv1.TolerationOpExists || spec.Tolerations[4].Operator != apiv1.TolerationOpEqual {
+					t.Errorf("Expected extra tolerations after default ones, got %v", spec.Tolerations)
+				}
+			},
+		},
+		{
+			name:       "anti-affinity disabled",
+			scheduling: &Scheduling{PodAntiAffinity: AffinityNone},
+			validate: func(t *testing.T, spec apiv1.PodSpec) {
+				if spec.Affinity.PodAntiAffinity != nil || spec.Affinity.NodeAffinity == nil {
+					t.Errorf("Expected node affinity only, got %+v", spec.Affinity)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, tier := newTestApplication()
+			tier.Scheduling = tt.scheduling
+			tt.validate(t, newPodTemplate(config, tier, stageProduction, "myorg").Spec)
+		})
+	}
+}
@@ -18,39 +18,25 @@ package types
 
 import (
 	"sort"
-	"strings"
 
 	apiv1 "k8s.io/api/core/v1"
 )
 
-// newVolumeMount return k8s volume mount objects list
-func newVolumeMount(application string, dependencies []DependsOn) []apiv1.VolumeMount {
+// newVolumeMount return k8s volume mount objects list of the application container
+func newVolumeMount(volumes []Volume) []apiv1.VolumeMount {
 	listVolumeMounts := []apiv1.VolumeMount{}
 
-	for _, dependency := range dependencies {
-		if strings.HasSuffix(dependency.Name, "-config") {
-			dependency := strings.Replace(dependency.Name, "-config", "", 1)
-			listVolumeMounts = append(listVolumeMounts, apiv1.VolumeMount{
-				Name:      dependency,
-				MountPath: "/app/conf/" + dependency + ".conf",
-				SubPath:   dependency + ".conf",
-			})
+	for _, volume := range volumes {
+		if volume.MountPath == "" {
+			continue
 		}
 
-		if dependency.Name == "GoogleCloudStorage" {
-			listVolumeMounts = append(listVolumeMounts, apiv1.VolumeMount{
-				Name:      "google-cloud-" + application,
-				ReadOnly:  true,
-				MountPath: "/google-cloud-" + application,
-			})
-		}
-
-		if dependency.Name == "maxmind" {
-			listVolumeMounts = append(listVolumeMounts, apiv1.VolumeMount{
-				Name:      volumeNameGeoIPFiles,
-				MountPath: "/usr/share/GeoIP",
-			})
-		}
+		listVolumeMounts = append(listVolumeMounts, apiv1.VolumeMount{
+			Name:      volume.Name,
+			ReadOnly:  volume.ReadOnly,
+			MountPath: volume.MountPath,
+			SubPath:   volume.SubPath,
+		})
 	}
 
 	sort.Slice(listVolumeMounts, func(i, j int) bool { return listVolumeMounts[i].Name < listVolumeMounts[j].Name })
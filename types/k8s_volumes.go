@@ -17,53 +17,162 @@ limitations under the License.
 package types
 
 import (
+	"fmt"
 	"sort"
-	"strings"
 
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// CheckQuantities returns error of invalid size limit of emptyDir volume
+func (v Volume) CheckQuantities() error {
+	if v.EmptyDir == nil || v.EmptyDir.SizeLimit == "" {
+		return nil
+	}
+	if _, err := resource.ParseQuantity(v.EmptyDir.SizeLimit); err != nil {
+		return fmt.Errorf("invalid emptyDir sizeLimit %q: %v", v.EmptyDir.SizeLimit, err)
+	}
+
+	return nil
+}
+
 // newVolume return k8s volume objects list
-func newVolume(application string, dependencies []DependsOn) []apiv1.Volume {
+func newVolume(volumes []Volume) []apiv1.Volume {
 	listVolumes := []apiv1.Volume{}
 
-	for _, dependency := range dependencies {
-		if strings.HasSuffix(dependency.Name, "-config") {
-			dependency := strings.Replace(dependency.Name, "-config", "", 1)
-			listVolumes = append(listVolumes, apiv1.Volume{
-				Name: dependency,
-				VolumeSource: apiv1.VolumeSource{
-					ConfigMap: &apiv1.ConfigMapVolumeSource{
-						LocalObjectReference: apiv1.LocalObjectReference{
-							Name: dependency,
-						},
-					},
-				},
-			})
-		}
+	for _, volume := range volumes {
+		listVolumes = append(listVolumes, apiv1.Volume{
+			Name:         volume.Name,
+			VolumeSource: newVolumeSource(volume),
+		})
+	}
 
-		if dependency.Name == "GoogleCloudStorage" {
-			listVolumes = append(listVolumes, apiv1.Volume{
-				Name: "google-cloud-" + application,
-				VolumeSource: apiv1.VolumeSource{
-					Secret: &apiv1.SecretVolumeSource{
-						SecretName: "google-cloud-" + application,
-					},
-				},
-			})
+	sort.Slice(listVolumes, func(i, j int) bool { return listVolumes[i].Name < listVolumes[j].Name })
+
+	return listVolumes
+}
+
+// newVolumeSource return k8s volume source of the volume
+func newVolumeSource(volume Volume) apiv1.VolumeSource {
+	source := apiv1.VolumeSource{}
+
+	switch {
+	case volume.ConfigMap != nil:
+		source.ConfigMap = newConfigMapVolumeSource(volume.ConfigMap)
+	case volume.Secret != nil:
+		source.Secret = &apiv1.SecretVolumeSource{
+			SecretName:  volume.Secret.SecretName,
+			Items:       newKeyToPaths(volume.Secret.Items),
+			DefaultMode: volume.Secret.DefaultMode,
+		}
+		if volume.Secret.Optional {
+			source.Secret.Optional = &volume.Secret.Optional
+		}
+	case volume.EmptyDir != nil:
+		source.EmptyDir = &apiv1.EmptyDirVolumeSource{
+			Medium: apiv1.StorageMedium(volume.EmptyDir.Medium),
+		}
+		if volume.EmptyDir.SizeLimit != "" {
+			// size limit is checked with Volume.CheckQuantities before generation
+			sizeLimit := resource.MustParse(volume.EmptyDir.SizeLimit)
+			source.EmptyDir.SizeLimit = &sizeLimit
+		}
+	case volume.PersistentVolumeClaim != nil:
+		source.PersistentVolumeClaim = &apiv1.PersistentVolumeClaimVolumeSource{
+			ClaimName: volume.PersistentVolumeClaim.ClaimName,
+			ReadOnly:  volume.PersistentVolumeClaim.ReadOnly,
+		}
+	case volume.Projected != nil:
+		source.Projected = &apiv1.ProjectedVolumeSource{
+			Sources:     newVolumeProjections(volume.Projected.Sources),
+			DefaultMode: volume.Projected.DefaultMode,
+		}
+	case volume.CSI != nil:
+		source.CSI = &apiv1.CSIVolumeSource{
+			Driver:           volume.CSI.Driver,
+			VolumeAttributes: volume.CSI.VolumeAttributes,
+		}
+		if volume.CSI.ReadOnly {
+			source.CSI.ReadOnly = &volume.CSI.ReadOnly
 		}
+		if volume.CSI.FSType != "" {
+			source.CSI.FSType = &volume.CSI.FSType
+		}
+		if volume.CSI.NodePublishSecretName != "" {
+			source.CSI.NodePublishSecretRef = &apiv1.LocalObjectReference{Name: volume.CSI.NodePublishSecretName}
+		}
+	}
+
+	return source
+}
+
+// newConfigMapVolumeSource return k8s volume source of ConfigMap
+func newConfigMapVolumeSource(configMap *ConfigMapVolume) *apiv1.ConfigMapVolumeSource {
+	source := &apiv1.ConfigMapVolumeSource{
+		LocalObjectReference: apiv1.LocalObjectReference{
+			Name: configMap.Name,
+		},
+		Items:       newKeyToPaths(configMap.Items),
+		DefaultMode: configMap.DefaultMode,
+	}
+	if configMap.Optional {
+		source.Optional = &configMap.Optional
+	}
+
+	return source
+}
+
+// newVolumeProjections return k8s sources of projected volume
+func newVolumeProjections(sources []ProjectedSource) []apiv1.VolumeProjection {
+	projections := []apiv1.VolumeProjection{}
 
-		if dependency.Name == "maxmind" {
-			listVolumes = append(listVolumes, apiv1.Volume{
-				Name: volumeNameGeoIPFiles,
-				VolumeSource: apiv1.VolumeSource{
-					EmptyDir: &apiv1.EmptyDirVolumeSource{},
+	for _, source := range sources {
+		projection := apiv1.VolumeProjection{}
+
+		switch {
+		case source.ConfigMap != nil:
+			projection.ConfigMap = &apiv1.ConfigMapProjection{
+				LocalObjectReference: apiv1.LocalObjectReference{
+					Name: source.ConfigMap.Name,
+				},
+				Items: newKeyToPaths(source.ConfigMap.Items),
+			}
+		case source.Secret != nil:
+			projection.Secret = &apiv1.SecretProjection{
+				LocalObjectReference: apiv1.LocalObjectReference{
+					Name: source.Secret.SecretName,
 				},
-			})
+				Items: newKeyToPaths(source.Secret.Items),
+			}
+		case source.ServiceAccountToken != nil:
+			projection.ServiceAccountToken = &apiv1.ServiceAccountTokenProjection{
+				Audience: source.ServiceAccountToken.Audience,
+				Path:     source.ServiceAccountToken.Path,
+			}
+			if source.ServiceAccountToken.ExpirationSeconds != 0 {
+				projection.ServiceAccountToken.ExpirationSeconds = int64Ptr(source.ServiceAccountToken.ExpirationSeconds)
+			}
 		}
+
+		projections = append(projections, projection)
 	}
 
-	sort.Slice(listVolumes, func(i, j int) bool { return listVolumes[i].Name < listVolumes[j].Name })
+	return projections
+}
 
-	return listVolumes
+// newKeyToPaths return k8s items of ConfigMap or Secret volume
+func newKeyToPaths(items []KeyToPath) []apiv1.KeyToPath {
+	if len(items) == 0 {
+		return nil
+	}
+
+	paths := []apiv1.KeyToPath{}
+	for _, item := range items {
+		paths = append(paths, apiv1.KeyToPath{
+			Key:  item.Key,
+			Path: item.Path,
+		})
+	}
+
+	return paths
 }
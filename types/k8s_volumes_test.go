@@ -0,0 +1,129 @@
+package types
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestNewVolumeSource(t *testing.T) {
+	mode := int32(0o400)
+
+	tests := []struct {
+		name     string
+		volume   Volume
+		validate func(*testing.T, apiv1.VolumeSource)
+	}{
+		{
+			name: "configMap items",
+			volume: Volume{ConfigMap: &ConfigMapVolume{
+				Name: "nginx", Items: []KeyToPath{{Key: "nginx.conf", Path: "default.conf"}}, Optional: true,
+			}},
+			validate: func(t *testing.T, source apiv1.VolumeSource) {
+				if source.ConfigMap == nil || source.ConfigMap.Name != "nginx" || source.ConfigMap.Items[0].Path != "default.conf" {
+					t.Errorf("Unexpected configMap source %+v", source.ConfigMap)
+				}
+				if source.ConfigMap.Optional == nil || !*source.ConfigMap.Optional {
+					t.Error("Expected optional configMap")
+				}
+			},
+		},
+		{
+			name:   "secret default mode",
+			volume: Volume{Secret: &SecretVolume{SecretName: "tls", DefaultMode: &mode}},
+			validate: func(t *testing.T, source apiv1.VolumeSource) {
+				if source.Secret == nil || source.Secret.SecretName != "tls" || *source.Secret.DefaultMode != 0o400 {
+					t.Errorf("Unexpected secret source %+v", source.Secret)
+				}
+				if source.Secret.Optional != nil || source.Secret.Items != nil {
+					t.Error("Expected kubernetes defaults of secret source")
+				}
+			},
+		},
+		{
+			name:   "emptyDir size limit",
+			volume: Volume{EmptyDir: &EmptyDirVolume{Medium: "Memory", SizeLimit: "256Mi"}},
+			validate: func(t *testing.T, source apiv1.VolumeSource) {
+				if source.EmptyDir == nil || source.EmptyDir.Medium != apiv1.StorageMediumMemory {
+					t.Fatalf("Unexpected emptyDir source %+v", source.EmptyDir)
+				}
+				if source.EmptyDir.SizeLimit == nil || source.EmptyDir.SizeLimit.String() != "256Mi" {
+					t.Errorf("Expected size limit 256Mi, got %v", source.EmptyDir.SizeLimit)
+				}
+			},
+		},
+		{
+			name:   "persistentVolumeClaim",
+			volume: Volume{PersistentVolumeClaim: &PersistentVolumeClaimVolume{ClaimName: "shared", ReadOnly: true}},
+			validate: func(t *testing.T, source apiv1.VolumeSource) {
+				if source.PersistentVolumeClaim == nil || source.PersistentVolumeClaim.ClaimName != "shared" || !source.PersistentVolumeClaim.ReadOnly {
+					t.Errorf("Unexpected persistentVolumeClaim source %+v", source.PersistentVolumeClaim)
+				}
+			},
+		},
+		{
+			name: "projected sources",
+			volume: Volume{Projected: &ProjectedVolume{Sources: []ProjectedSource{
+				{ConfigMap: &ConfigMapVolume{Name: "ca"}},
+				{Secret: &SecretVolume{SecretName: "tls"}},
+				{ServiceAccountToken: &ServiceAccountTokenVolume{Audience: "vault", ExpirationSeconds: 3600, Path: "token"}},
+			}}},
+			validate: func(t *testing.T, source apiv1.VolumeSource) {
+				if source.Projected == nil || len(source.Projected.Sources) != 3 {
+					t.Fatalf("Unexpected projected source %+v", source.Projected)
+				}
+				sources := source.Projected.Sources
+				if sources[0].ConfigMap.Name != "ca" || sources[1].Secret.Name != "tls" {
+					t.Errorf("Unexpected configMap and secret projections %+v", sources)
+				}
+				if token := sources[2].ServiceAccountToken; token.Audience != "vault" || *token.ExpirationSeconds != 3600 || token.Path != "token" {
+					t.Errorf("Unexpected token projection %+v", token)
+				}
+			},
+		},
+		{
+			name: "csi driver",
+			volume: Volume{CSI: &CSIVolume{
+				Driver: "secrets-store.csi.k8s.io", ReadOnly: true,
+				VolumeAttributes: map[string]string{"secretProviderClass": "vault"}, NodePublishSecretName: "creds",
+			}},
+			validate: func(t *testing.T, source apiv1.VolumeSource) {
+				if source.CSI == nil || source.CSI.Driver != "secrets-store.csi.k8s.io" || !*source.CSI.ReadOnly {
+					t.Fatalf("Unexpected csi source %+v", source.CSI)
+				}
+				if source.CSI.FSType != nil || source.CSI.VolumeAttributes["secretProviderClass"] != "vault" {
+					t.Errorf("Unexpected csi attributes %+v", source.CSI)
+				}
+				if source.CSI.NodePublishSecretRef == nil || source.CSI.NodePublishSecretRef.Name != "creds" {
+					t.Errorf("Expected node publish secret, got %v", source.CSI.NodePublishSecretRef)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.validate(t, newVolumeSource(tt.volume))
+		})
+	}
+}
+
+func TestNewVolumeMountSkipsUnmounted(t *testing.T) {
+	mounts := newVolumeMount([]Volume{
+		{Name: "shared", EmptyDir: &EmptyDirVolume{}},
+		{Name: "cache", MountPath: "/cache", SubPath: "app", ReadOnly: true, EmptyDir: &EmptyDirVolume{}},
+	})
+
+	if len(mounts) != 1 || mounts[0].Name != "cache" || mounts[0].SubPath != "app" || !mounts[0].ReadOnly {
+		t.Errorf("Expected only mounted volume, got %+v", mounts)
+	}
+}
+
+func TestVolumeCheckQuantities(t *testing.T) {
+	if err := (Volume{Name: "cache", EmptyDir: &EmptyDirVolume{SizeLimit: "1Gi"}}).CheckQuantities(); err != nil {
+		t.Errorf("Expected valid size limit, got %v", err)
+	}
+	if err := (Volume{Name: "cache", EmptyDir: &EmptyDirVolume{SizeLimit: "1 gig"}}).CheckQuantities(); err == nil {
+		t.Errorf("Expected invalid size limit error")
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewWorkload return k8s workload object of the application type: StatefulSet, CronJob, Job or Deployment
+func NewWorkload(config *Configuration, tier *Datacenter, stage, organization string) runtime.Object {
+	switch config.Type {
+	case WorkloadTypeStatefulSet:
+		return NewStatefulSet(config, tier, stage, organization)
+	case WorkloadTypeCronJob:
+		return NewCronJob(config, tier, stage, organization)
+	case WorkloadTypeJob:
+		return NewJob(config, tier, stage, organization)
+	default:
+		return NewDeployment(config, tier, stage, organization)
+	}
+}
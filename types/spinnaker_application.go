@@ -86,7 +86,7 @@ func NewApplication(app *Configuration) *Application {
 	application := defaultApplication()
 
 	application.CustomBanners[len(application.CustomBanners)-1].Text = app.Application
-	if app.Type != "service" {
+	if !app.HasService() {
 		application.DataSources.Disabled = append(application.DataSources.Disabled, "loadBalancers")
 	}
 	application.Description = app.Application
@@ -18,11 +18,16 @@ package types
 
 import (
 	"strings"
+)
 
-	dha "github.com/ealebed/dha/pkg/dockerhub"
+const (
+	stageProduction = "production"
+	stageBeta       = "beta"
+	stageNightly    = "nightly"
 )
 
-const stageProduction = "production"
+// MaxmindImage is the image with GeoIP databases copied into applications depending on maxmind
+const MaxmindImage = "maxmind-geoip"
 
 // PipelineConfig represents full pipeline config - fields for the top level object of a spinnaker
 // pipeline. Mostly used for constructing JSON
@@ -46,6 +51,11 @@ type Pipeline struct {
 	UpdateTs             string                      `json:"updateTs,omitempty"`
 }
 
+// DeployPipelineName returns name of the pipeline deploying application to datacenter on the stage
+func DeployPipelineName(cluster, stage string) string {
+	return "deploy-" + cluster + "-dc(" + stage + ")"
+}
+
 // NewBuildPipeline return build pipeline with default values
 func NewBuildPipeline(pipe *Configuration) *Pipeline {
 	return &Pipeline{
@@ -83,14 +93,16 @@ func NewPromotePipeline(pipe *Configuration, pipeValues map[string]interface{})
 	}
 }
 
-// NewDeployPipeline return deploy to DC pipeline with default values
+// NewDeployPipeline return deploy to DC pipeline with default values, application version
+// and maxmind version (for applications depending on maxmind) must be resolved by the caller
 func NewDeployPipeline(pipe *Configuration, pipeValues map[string]interface{}) *Pipeline {
 	var organization = pipeValues["organization"].(string)
 	var githubRepositoryName = pipeValues["githubRepositoryName"].(string)
 	var githubContentUrl = "https://api.github.com/repos/" + organization + "/" + githubRepositoryName + "/contents/"
+	var registry = pipe.ImageRegistry(organization)
 
 	var fullListStageRefIds = []string{}
-	var requiredArtifactIds = []string{organization + "/" + pipe.DockerImage}
+	var requiredArtifactIds = []string{registry.Repository(pipe.DockerImage)}
 
 	var manifestPath string
 	var expectedArtifacts = []*PipelineExpectedArtifact{}
@@ -98,23 +110,17 @@ func NewDeployPipeline(pipe *Configuration, pipeValues map[string]interface{}) *
 	var triggers = []*Trigger{}
 	var expectedArtifactIds = []string{}
 
-	if pipe.Version == "" {
-		tags, _ := dha.NewClient(organization, "").ListTags(pipe.DockerImage)
-		pipe.Version = tags[0].Name
-	}
-
 	if dependencyContains(pipe.DependsOn, "maxmind") {
-		tags, _ := dha.NewClient(organization, "").ListTags("maxmind-geoip")
-		maxmindDefaultTag := tags[0].Name
-
 		expectedArtifacts = append(expectedArtifacts, newDockerPipelineExpectedArtifact(
-			organization,
-			"maxmind-geoip",
-			maxmindDefaultTag))
-		requiredArtifactIds = append(requiredArtifactIds, organization+"/maxmind-geoip")
+			registry,
+			MaxmindImage,
+			pipeValues["maxmindVersion"].(string)))
+		requiredArtifactIds = append(requiredArtifactIds, registry.Repository(MaxmindImage))
 		triggers = append(triggers, newDockerTrigger(
 			organization,
-			"maxmind-geoip",
+			registry,
+			MaxmindImage,
+			DefaultTagPolicy().TriggerRegex(),
 			pipe.Owners,
 			true))
 	}
@@ -173,20 +179,28 @@ func NewDeployPipeline(pipe *Configuration, pipeValues map[string]interface{}) *
 	}
 
 	expectedArtifacts = append(expectedArtifacts,
-		newDockerPipelineExpectedArtifact(organization, pipe.DockerImage, pipe.Version),
+		newDockerPipelineExpectedArtifact(registry, pipe.DockerImage, pipe.Version),
 		newManifestPipelineExpectedArtifact(githubContentUrl, manifestPath))
 	expectedArtifactIds = append(expectedArtifactIds,
 		manifestPath)
-	stages = append(stages, defaultDeployManifestStage(
+	deployStage := defaultDeployManifestStage(
 		pipeValues["cluster"].(string),
 		pipe.Application,
 		pipe.Namespace,
 		manifestPath,
 		fullListStageRefIds,
-		requiredArtifactIds))
+		requiredArtifactIds)
+	// deploy of job waits for its completion, so stage must not time out before job deadline
+	if pipe.Type == WorkloadTypeJob && pipe.Job != nil && pipe.Job.ActiveDeadlineSeconds != 0 {
+		deployStage.OverrideTimeout = true
+		deployStage.StageTimeoutMs = int(pipe.Job.ActiveDeadlineSeconds) * 1000
+	}
+	stages = append(stages, deployStage)
 	triggers = append(triggers, newDockerTrigger(
 		organization,
+		registry,
 		pipe.DockerImage,
+		pipe.ImageTagPolicy().TriggerRegex(),
 		pipe.Owners,
 		pipeValues["dockerTriggerEnabled"].(bool)))
 	triggers = append(triggers, newGitTrigger(
@@ -229,7 +243,7 @@ func NewDeployPipeline(pipe *Configuration, pipeValues map[string]interface{}) *
 		KeepWaitingPipelines: false,
 		LastModifiedBy:       pipe.OwnerEmail,
 		LimitConcurrent:      true,
-		Name:                 "deploy-" + pipeValues["cluster"].(string) + "-dc(" + pipeValues["stage"].(string) + ")",
+		Name:                 DeployPipelineName(pipeValues["cluster"].(string), pipeValues["stage"].(string)),
 		Roles:                []string{spinnakerRoleGroupDevops, pipe.Owners},
 		ParameterConfig:      &[]*Parameter{},
 		Notifications:        []Notification{notification},
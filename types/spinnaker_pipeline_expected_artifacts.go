@@ -27,20 +27,20 @@ type PipelineExpectedArtifact struct {
 }
 
 // newDockerPipelineExpectedArtifact return new expected docker image artifact
-func newDockerPipelineExpectedArtifact(organization, image, version string) *PipelineExpectedArtifact {
+func newDockerPipelineExpectedArtifact(registry *Registry, image, version string) *PipelineExpectedArtifact {
 	return &PipelineExpectedArtifact{
 		DefaultArtifact: &PipelineArtifact{
 			ArtifactAccount: "docker-registry",
-			Name:            dockerHubUrl + organization + "/" + image,
-			Reference:       dockerHubUrl + organization + "/" + image + ":" + version,
+			Name:            registry.ImageName(image),
+			Reference:       registry.ImageName(image) + ":" + version,
 			Type:            "docker/image",
 			Version:         version,
 		},
-		DisplayName: dockerHubUrl + organization + "/" + image,
-		ID:          organization + "/" + image,
+		DisplayName: registry.ImageName(image),
+		ID:          registry.Repository(image),
 		MatchArtifact: &PipelineArtifact{
 			ArtifactAccount: "docker-registry",
-			Name:            dockerHubUrl + organization + "/" + image,
+			Name:            registry.ImageName(image),
 			Type:            "docker/image",
 		},
 		UseDefaultArtifact: true,
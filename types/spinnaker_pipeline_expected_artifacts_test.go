@@ -21,11 +21,11 @@ func TestNewDockerPipelineExpectedArtifact(t *testing.T) {
 				if artifact.DefaultArtifact == nil {
 					t.Fatal("Expected DefaultArtifact to be set")
 				}
-				expectedName := dockerHubUrl + "myorg/myapp"
+				expectedName := dockerHubHost + "/myorg/myapp"
 				if artifact.DefaultArtifact.Name != expectedName {
 					t.Errorf("Expected DefaultArtifact.Name %q, got %q", expectedName, artifact.DefaultArtifact.Name)
 				}
-				expectedReference := dockerHubUrl + "myorg/myapp:1.2.3"
+				expectedReference := dockerHubHost + "/myorg/myapp:1.2.3"
 				if artifact.DefaultArtifact.Reference != expectedReference {
 					t.Errorf("Expected DefaultArtifact.Reference %q, got %q", expectedReference, artifact.DefaultArtifact.Reference)
 				}
@@ -61,7 +61,7 @@ func TestNewDockerPipelineExpectedArtifact(t *testing.T) {
 			image:        "myapp",
 			version:      "",
 			validate: func(t *testing.T, artifact *PipelineExpectedArtifact) {
-				expectedReference := dockerHubUrl + "myorg/myapp:"
+				expectedReference := dockerHubHost + "/myorg/myapp:"
 				if artifact.DefaultArtifact.Reference != expectedReference {
 					t.Errorf("Expected DefaultArtifact.Reference %q, got %q", expectedReference, artifact.DefaultArtifact.Reference)
 				}
@@ -76,7 +76,7 @@ func TestNewDockerPipelineExpectedArtifact(t *testing.T) {
 			image:        "myapp",
 			version:      "1.0.0",
 			validate: func(t *testing.T, artifact *PipelineExpectedArtifact) {
-				expectedName := dockerHubUrl + "/myapp"
+				expectedName := dockerHubHost + "//myapp"
 				if artifact.DefaultArtifact.Name != expectedName {
 					t.Errorf("Expected DefaultArtifact.Name %q, got %q", expectedName, artifact.DefaultArtifact.Name)
 				}
@@ -91,7 +91,7 @@ func TestNewDockerPipelineExpectedArtifact(t *testing.T) {
 			image:        "",
 			version:      "1.0.0",
 			validate: func(t *testing.T, artifact *PipelineExpectedArtifact) {
-				expectedName := dockerHubUrl + "myorg/"
+				expectedName := dockerHubHost + "/myorg/"
 				if artifact.DefaultArtifact.Name != expectedName {
 					t.Errorf("Expected DefaultArtifact.Name %q, got %q", expectedName, artifact.DefaultArtifact.Name)
 				}
@@ -104,7 +104,7 @@ func TestNewDockerPipelineExpectedArtifact(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := newDockerPipelineExpectedArtifact(tt.organization, tt.image, tt.version)
+			result := newDockerPipelineExpectedArtifact(DefaultRegistry(tt.organization), tt.image, tt.version)
 			if result == nil {
 				t.Fatal("newDockerPipelineExpectedArtifact returned nil")
 			}
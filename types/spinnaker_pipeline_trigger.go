@@ -37,17 +37,17 @@ type Trigger struct {
 	Type                string   `json:"type"`
 }
 
-// newDockerTrigger return Trigger object with default values for docker hub trigger type
-func newDockerTrigger(organization, dockerImage, owner string, enabled bool) *Trigger {
+// newDockerTrigger return Trigger object with default values for docker registry trigger type
+func newDockerTrigger(organization string, registry *Registry, dockerImage, tagRegex, owner string, enabled bool) *Trigger {
 	return &Trigger{
-		Account:             organization,
+		Account:             registry.Account,
 		Enabled:             enabled,
-		ExpectedArtifactIds: []string{organization + "/" + dockerImage},
-		Organization:        organization,
-		Registry:            "index.docker.io",
-		Repository:          organization + "/" + dockerImage,
+		ExpectedArtifactIds: []string{registry.Repository(dockerImage)},
+		Organization:        registry.Namespace,
+		Registry:            registry.Host,
+		Repository:          registry.Repository(dockerImage),
 		RunAsUser:           owner + "-service-account@" + organization + ".com",
-		Tag:                 "^\\d{2}\\.\\d{2}\\.\\d{2}\\-\\d{2}\\.\\d{2}$",
+		Tag:                 tagRegex,
 		Type:                "docker",
 	}
 }
@@ -113,7 +113,7 @@ func TestNewDockerTrigger(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := newDockerTrigger(tt.organization, tt.dockerImage, tt.owner, tt.enabled)
+			result := newDockerTrigger(tt.organization, DefaultRegistry(tt.organization), tt.dockerImage, DefaultTagFilter, tt.owner, tt.enabled)
 			if result == nil {
 				t.Fatal("newDockerTrigger returned nil")
 			}
@@ -0,0 +1,347 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/ealebed/spini/types"
+	git "github.com/ealebed/spini/utils/github"
+)
+
+// DefaultConfigurationPath is the path of applications configuration used when --config-path isn't provided
+const DefaultConfigurationPath = "configuration.json"
+
+// ResourcePolicyPath is the path of organization resource policy at the root of configuration repository,
+// applications can only tighten it with own resourcePolicy
+const ResourcePolicyPath = "resource-policy.json"
+
+// configurationExtensions lists extensions of files loaded from configuration directory
+var configurationExtensions = []string{".json", ".yaml", ".yml"}
+
+// configurationSource reads configuration files from local filesystem or GitHub repository
+type configurationSource interface {
+	// readFile returns raw content of the file
+	readFile(filePath string) ([]byte, error)
+	// listDirectory returns paths of files in the directory, isDir is false when dirPath points to the file
+	listDirectory(dirPath string) (files []string, isDir bool, err error)
+	// glob returns paths of files matching the pattern, wildcards don't match path separator
+	glob(pattern string) ([]string, error)
+}
+
+// localSource reads configuration files from local filesystem
+type localSource struct{}
+
+// repositorySource reads configuration files from GitHub repository
+type repositorySource struct {
+	client         *git.Client
+	organization   string
+	repositoryName string
+	branch         string
+}
+
+func (s *localSource) readFile(filePath string) ([]byte, error) {
+	return os.ReadFile(filePath) //nolint:gosec // path is provided by user
+}
+
+func (s *localSource) listDirectory(dirPath string) ([]string, bool, error) {
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		return nil, false, err
+	}
+	if !info.IsDir() {
+		return nil, false, nil
+	}
+
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, true, err
+	}
+
+	files := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, filepath.Join(dirPath, entry.Name()))
+		}
+	}
+
+	return files, true, nil
+}
+
+func (s *localSource) glob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Clean(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration path pattern %q: %v", pattern, err)
+	}
+
+	files := []string{}
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.IsDir() {
+			files = append(files, match)
+		}
+	}
+
+	return files, nil
+}
+
+func (s *repositorySource) readFile(filePath string) ([]byte, error) {
+	return s.client.ReadFileFromRepo(s.organization, s.repositoryName, s.branch, filePath)
+}
+
+func (s *repositorySource) listDirectory(dirPath string) ([]string, bool, error) {
+	return s.client.ListDirectoryFromRepo(s.organization, s.repositoryName, s.branch, path.Clean(dirPath))
+}
+
+func (s *repositorySource) glob(pattern string) ([]string, error) {
+	files, err := s.client.ListFilesFromRepo(s.organization, s.repositoryName, s.branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchFiles(path.Clean(pattern), files)
+}
+
+// matchFiles returns repository files which full paths match the pattern
+func matchFiles(pattern string, files []string) ([]string, error) {
+	matched := []string{}
+	for _, file := range files {
+		ok, err := path.Match(pattern, file)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration path pattern %q: %v", pattern, err)
+		}
+		if ok {
+			matched = append(matched, file)
+		}
+	}
+
+	return matched, nil
+}
+
+// newConfigurationSource returns local or GitHub repository configuration source
+func newConfigurationSource(local bool, organization, repositoryName, branch string) (configurationSource, error) {
+	if local {
+		return &localSource{}, nil
+	}
+
+	if repositoryName == "" {
+		return nil, errors.New("repository name is required to read remote configuration")
+	}
+
+	return &repositorySource{
+		client:         git.NewClient(),
+		organization:   organization,
+		repositoryName: repositoryName,
+		branch:         branch,
+	}, nil
+}
+
+// resolveConfigurationFiles returns sorted list of files for the configuration path,
+// which can be a single file, a directory or a glob pattern like 'apps/*.yaml' or 'apps/*/configuration.yaml'
+func resolveConfigurationFiles(source configurationSource, configPath string) ([]string, error) {
+	if strings.ContainsAny(configPath, "*?[") {
+		matched, err := source.glob(configPath)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("no configuration files match %q", configPath)
+		}
+		sort.Strings(matched)
+
+		return matched, nil
+	}
+
+	files, isDir, err := source.listDirectory(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		return []string{configPath}, nil
+	}
+
+	matched := []string{}
+	for _, file := range files {
+		if sliceContains(configurationExtensions, strings.ToLower(filepath.Ext(file))) {
+			matched = append(matched, file)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no configuration files (%s) found in directory %q", strings.Join(configurationExtensions, ", "), configPath)
+	}
+	sort.Strings(matched)
+
+	return matched, nil
+}
+
+// parseConfigurationFile converts JSON or YAML file content into JSON list of applications.
+// File may contain either list of applications or single application object.
+func parseConfigurationFile(filePath string, content []byte) (*types.ConfigurationFile, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		converted, err := yaml.YAMLToJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML file '%s': %v", filePath, err)
+		}
+		content = converted
+	default:
+		var target interface{}
+		if err := json.Unmarshal(content, &target); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON file '%s': %v", filePath, err)
+		}
+	}
+
+	file := &types.ConfigurationFile{Path: filePath, Content: bytes.TrimSpace(content)}
+
+	if bytes.HasPrefix(file.Content, []byte("{")) {
+		file.Content = append(append([]byte("["), file.Content...), ']')
+		file.Wrapped = true
+	}
+
+	return file, nil
+}
+
+// LoadConfigurationFiles returns content of all local or remote configuration files found by the configuration path
+func LoadConfigurationFiles(local bool, organization, repositoryName, branch, configPath string) ([]*types.ConfigurationFile, error) {
+	if configPath == "" {
+		configPath = DefaultConfigurationPath
+	}
+
+	source, err := newConfigurationSource(local, organization, repositoryName, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	filePaths, err := resolveConfigurationFiles(source, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*types.ConfigurationFile, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		content, err := source.readFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := parseConfigurationFile(filePath, content)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// MergeConfigurationFiles merges applications from all configuration files into single list
+// with defaults resolved into every datacenter
+func MergeConfigurationFiles(files []*types.ConfigurationFile) ([]*types.Configuration, error) {
+	configResponse := make([]*types.Configuration, 0)
+	definedIn := map[string]string{}
+
+	for _, file := range files {
+		configuration := make([]*types.Configuration, 0)
+		if err := json.Unmarshal(file.Content, &configuration); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal configuration file '%s' due to %s", file.Path, err)
+		}
+
+		for i, app := range configuration {
+			if app == nil {
+				return nil, fmt.Errorf("configuration file '%s' has empty application at index %d", file.Path, i)
+			}
+			if previous, ok := definedIn[app.Application]; ok {
+				return nil, fmt.Errorf("application %q is defined in both '%s' and '%s'", app.Application, previous, file.Path)
+			}
+			definedIn[app.Application] = file.Path
+			app.ResolveDefaults()
+		}
+
+		configResponse = append(configResponse, configuration...)
+	}
+
+	types.ResolveDependents(configResponse)
+
+	return configResponse, nil
+}
+
+// LoadResourcePolicy returns organization resource policy from local or remote ResourcePolicyPath,
+// nil is returned when organization doesn't have the policy
+func LoadResourcePolicy(local bool, organization, repositoryName, branch string) (*types.ResourcePolicy, error) {
+	source, err := newConfigurationSource(local, organization, repositoryName, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := source.readFile(ResourcePolicyPath)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, git.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResourcePolicy(content)
+}
+
+// parseResourcePolicy decodes organization resource policy and checks its rules
+func parseResourcePolicy(content []byte) (*types.ResourcePolicy, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	policy := &types.ResourcePolicy{}
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("failed to parse resource policy '%s': %v", ResourcePolicyPath, err)
+	}
+	if err := policy.CheckRules(); err != nil {
+		return nil, fmt.Errorf("invalid resource policy '%s': %w", ResourcePolicyPath, err)
+	}
+
+	return policy, nil
+}
+
+// LoadConfiguration returns applications config from local or remote configuration file(s)
+// with organization resource policy applied
+func LoadConfiguration(local bool, organization, repositoryName, branch, configPath string) ([]*types.Configuration, error) {
+	files, err := LoadConfigurationFiles(local, organization, repositoryName, branch, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration, err := MergeConfigurationFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := LoadResourcePolicy(local, organization, repositoryName, branch)
+	if err != nil {
+		return nil, err
+	}
+	types.ApplyResourcePolicy(configuration, policy)
+
+	return configuration, nil
+}
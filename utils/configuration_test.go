@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ealebed/spini/types"
+)
+
+func TestResolveConfigurationFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.yaml", "a.json", "c.yml", "README.md", "apps/x/configuration.yaml", "apps/y/configuration.yaml"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700); err != nil {
+			t.Fatalf("failed to create directory of %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("[]"), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		configPath string
+		expected   []string
+		wantErr    bool
+	}{
+		{name: "single file", configPath: filepath.Join(dir, "a.json"), expected: []string{"a.json"}},
+		{name: "directory", configPath: dir, expected: []string{"a.json", "b.yaml", "c.yml"}},
+		{name: "glob pattern", configPath: filepath.Join(dir, "*.y*ml"), expected: []string{"b.yaml", "c.yml"}},
+		{
+			name:       "glob pattern of nested directories",
+			configPath: filepath.Join(dir, "apps", "*", "configuration.yaml"),
+			expected:   []string{"apps/x/configuration.yaml", "apps/y/configuration.yaml"},
+		},
+		{name: "glob doesn't match directories", configPath: filepath.Join(dir, "app*"), wantErr: true},
+		{name: "glob without matches", configPath: filepath.Join(dir, "*.toml"), wantErr: true},
+		{name: "missing file", configPath: filepath.Join(dir, "missing.json"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := resolveConfigurationFiles(&localSource{}, tt.configPath)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q, got files %v", tt.configPath, files)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(files) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, files)
+			}
+			for i := range files {
+				if files[i] != filepath.Join(dir, filepath.FromSlash(tt.expected[i])) {
+					t.Errorf("Expected %s, got %s", tt.expected[i], files[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMatchFiles(t *testing.T) {
+	files := []string{"configuration.json", "apps/a/configuration.yaml", "apps/b/configuration.yaml", "apps/b/README.md", "apps/c.yaml"}
+
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{pattern: "apps/*/configuration.yaml", expected: []string{"apps/a/configuration.yaml", "apps/b/configuration.yaml"}},
+		{pattern: "apps/*.yaml", expected: []string{"apps/c.yaml"}},
+		{pattern: "*.json", expected: []string{"configuration.json"}},
+		{pattern: "*.yaml", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			matched, err := matchFiles(tt.pattern, files)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !slices.Equal(matched, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, matched)
+			}
+		})
+	}
+
+	if _, err := matchFiles("apps/[", files); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+}
+
+func TestParseConfigurationFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		expected string
+		wrapped  bool
+		wantErr  bool
+	}{
+		{
+			name:     "json list",
+			filePath: "configuration.json",
+			content:  `[{"application": "myapp"}]`,
+			expected: `[{"application": "myapp"}]`,
+		},
+		{
+			name:     "json single application",
+			filePath: "myapp.json",
+			content:  "{\"application\": \"myapp\"}\n",
+			expected: `[{"application": "myapp"}]`,
+			wrapped:  true,
+		},
+		{
+			name:     "yaml list",
+			filePath: "apps.yaml",
+			content:  "- application: myapp\n- application: other\n",
+			expected: `[{"application":"myapp"},{"application":"other"}]`,
+		},
+		{
+			name:     "yaml single application",
+			filePath: "myapp.YML",
+			content:  "application: myapp\n",
+			expected: `[{"application":"myapp"}]`,
+			wrapped:  true,
+		},
+		{name: "invalid json", filePath: "configuration.json", content: `[{`, wantErr: true},
+		{name: "invalid yaml", filePath: "apps.yaml", content: "application: [", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parseConfigurationFile(tt.filePath, []byte(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %s", file.Content)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(file.Content) != tt.expected {
+				t.Errorf("Expected content %s, got %s", tt.expected, file.Content)
+			}
+			if file.Wrapped != tt.wrapped {
+				t.Errorf("Expected wrapped %v, got %v", tt.wrapped, file.Wrapped)
+			}
+		})
+	}
+}
+
+func TestMergeConfigurationFiles(t *testing.T) {
+	files := []*types.ConfigurationFile{
+		{Path: "apps/a.yaml", Content: []byte(`[{"application": "a"}, {"application": "b"}]`)},
+		{Path: "apps/c.yaml", Content: []byte(`[{"application": "c"}]`)},
+	}
+
+	configuration, err := MergeConfigurationFiles(files)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(configuration) != 3 || configuration[2].Application != "c" {
+		t.Errorf("Expected applications a, b, c, got %d applications", len(configuration))
+	}
+
+	files = append(files, &types.ConfigurationFile{Path: "apps/d.yaml", Content: []byte(`[{"application": "b"}]`)})
+	_, err = MergeConfigurationFiles(files)
+	if err == nil || !strings.Contains(err.Error(), "'apps/a.yaml' and 'apps/d.yaml'") {
+		t.Errorf("Expected duplicate application error, got %v", err)
+	}
+
+	_, err = MergeConfigurationFiles([]*types.ConfigurationFile{{Path: "apps/e.yaml", Content: []byte(`[{"application": "e"}, null]`)}})
+	if err == nil || !strings.Contains(err.Error(), "'apps/e.yaml'") {
+		t.Errorf("Expected empty application error, got %v", err)
+	}
+}
+
+func TestLoadResourcePolicy(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	policy, err := LoadResourcePolicy(true, "ealebed", "", "")
+	if err != nil || policy != nil {
+		t.Fatalf("Expected no policy without %s, got %+v, %v", ResourcePolicyPath, policy, err)
+	}
+
+	if err := os.WriteFile(ResourcePolicyPath, []byte(`{"cpuLimit": "none", "maxPod": {"cpu": "4"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err = LoadResourcePolicy(true, "ealebed", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if policy.CPULimit != types.LimitNone || policy.MaxPod.CPU != "4" {
+		t.Errorf("Unexpected policy %+v", policy)
+	}
+
+	for _, content := range []string{`{"cpuLimits": "none"}`, `{"memoryLimit": "none"}`} {
+		if _, err := parseResourcePolicy([]byte(content)); err == nil {
+			t.Errorf("Expected error of policy %s", content)
+		}
+	}
+}
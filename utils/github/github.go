@@ -39,6 +39,9 @@ import (
 	"github.com/ealebed/spini/types"
 )
 
+// ErrNotFound is returned when requested file or directory doesn't exist in repository
+var ErrNotFound = errors.New("not found")
+
 type Client struct {
 	client *github.Client
 }
@@ -136,21 +139,24 @@ func (c *Client) readFileContent(org, name, branch, path string) (fileContent *g
 	return nil, resp, fmt.Errorf("unmarshalling failed for file content: %s", fileUnmarshalError)
 }
 
-// ReadJSONFromRepoToStruct returns struct with configuration values from selected repository
-func (c *Client) ReadJSONFromRepoToStruct(org, repoName, branch string) []*types.Configuration {
-	conf := make([]*types.Configuration, 0)
+// ReadFileFromRepo returns raw content of the file from selected repository and branch
+func (c *Client) ReadFileFromRepo(org, repoName, branch, path string) ([]byte, error) {
+	if c.client == nil {
+		return nil, errors.New("unauthorized: no GitHub token present")
+	}
 
-	fileContentToEncode, _, err := c.readFileContent(org, repoName, branch, "configuration.json")
+	fileContentToEncode, fileResp, err := c.readFileContent(org, repoName, branch, path)
+	if fileResp != nil && fileResp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("could not get file '%s' from repository %s: %w", path, repoName, ErrNotFound)
+	}
 	if err != nil {
-		fmt.Printf("Could not get file 'configuration.json' from repository %s due to %s", repoName, err)
-		return conf
+		return nil, fmt.Errorf("could not get file '%s' from repository %s due to %s", path, repoName, err)
 	}
 
 	DownloadURL := fileContentToEncode.GetDownloadURL()
 	resp, err := http.Get(DownloadURL) //nolint:gosec // URL is from GitHub API, safe
 	if err != nil {
-		fmt.Printf("Failed to download file 'configuration.json' due to %s", err)
-		return conf
+		return nil, fmt.Errorf("failed to download file '%s' due to %s", path, err)
 	}
 
 	defer func() {
@@ -161,17 +167,70 @@ func (c *Client) ReadJSONFromRepoToStruct(org, repoName, branch string) []*types
 	}()
 	buf := new(bytes.Buffer)
 	if _, err := buf.ReadFrom(resp.Body); err != nil {
-		fmt.Printf("Failed to read response body: %s", err)
-		return conf
+		return nil, fmt.Errorf("failed to read response body: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ListDirectoryFromRepo returns paths of files located in the directory of selected repository and branch.
+// When path points to the file, isDir is false and no files are returned.
+func (c *Client) ListDirectoryFromRepo(org, repoName, branch, path string) (files []string, isDir bool, err error) {
+	if c.client == nil {
+		return nil, false, errors.New("unauthorized: no GitHub token present")
+	}
+
+	_, directoryContent, resp, err := c.client.Repositories.GetContents(
+		context.Background(),
+		org,
+		repoName,
+		path,
+		&github.RepositoryContentGetOptions{Ref: branch})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, false, fmt.Errorf("could not get '%s' from repository %s: %w", path, repoName, ErrNotFound)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not get '%s' from repository %s due to %s", path, repoName, err)
+	}
+
+	if directoryContent == nil {
+		return nil, false, nil
 	}
-	respByte := buf.Bytes()
 
-	if err := json.Unmarshal(respByte, &conf); err != nil {
-		fmt.Printf("Failed to unmarshal JSON file 'configuration.json' due to %s", err)
-		return conf
+	for _, content := range directoryContent {
+		if content.GetType() == "file" {
+			files = append(files, content.GetPath())
+		}
+	}
+
+	return files, true, nil
+}
+
+// ListFilesFromRepo returns paths of all files of selected repository and branch
+func (c *Client) ListFilesFromRepo(org, repoName, branch string) ([]string, error) {
+	if c.client == nil {
+		return nil, errors.New("unauthorized: no GitHub token present")
+	}
+
+	tree, resp, err := c.client.Git.GetTree(context.Background(), org, repoName, branch, true)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("could not get branch '%s' of repository %s: %w", branch, repoName, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get files of repository %s due to %s", repoName, err)
+	}
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("repository %s has too many files to list, use directory instead of pattern", repoName)
+	}
+
+	files := []string{}
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" {
+			files = append(files, entry.GetPath())
+		}
 	}
 
-	return conf
+	return files, nil
 }
 
 // NewPullRequest prepare additional GitHub specific data for creating pr
@@ -0,0 +1,79 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/instrumenta/kubeval/kubeval"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// bundledSchemas are strict JSON schemas of generated custom resources which aren't published with kubernetes schemas
+//
+//go:embed schemas/*.json
+var bundledSchemas embed.FS
+
+// bundledSchemaKinds maps kubeval version kind of custom resources to their bundled schema files
+var bundledSchemaKinds = map[string]string{
+	"monitoring.coreos.com/v1/ServiceMonitor": "schemas/servicemonitor-monitoring-v1.json",
+	"monitoring.coreos.com/v1/PodMonitor":     "schemas/podmonitor-monitoring-v1.json",
+}
+
+// newSchemaCache returns kubeval schema cache with bundled schemas of custom resources,
+// so they are validated without looking for their schemas in kubernetes schema location
+func newSchemaCache() (map[string]*gojsonschema.Schema, error) {
+	cache := kubeval.NewSchemaCache()
+
+	for versionKind, file := range bundledSchemaKinds {
+		content, err := bundledSchemas.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bundled schema %s: %w", file, err)
+		}
+		cache[versionKind] = schema
+	}
+
+	return cache, nil
+}
+
+// bundledSchemaErrors returns error with problems of resources validated against bundled schemas,
+// nil is returned when there are no such problems
+func bundledSchemaErrors(results []kubeval.ValidationResult) error {
+	problems := []string{}
+	for _, result := range results {
+		if _, ok := bundledSchemaKinds[result.VersionKind()]; !ok {
+			continue
+		}
+
+		for _, resultError := range result.Errors {
+			problems = append(problems, fmt.Sprintf("%s %s: %s", result.Kind, result.QualifiedName(), resultError))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid custom resources: %s", strings.Join(problems, "; "))
+}
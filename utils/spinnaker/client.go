@@ -25,6 +25,7 @@ import (
 	orcaTasks "github.com/spinnaker/spin/cmd/orca-tasks"
 	gate "github.com/spinnaker/spin/gateapi"
 
+	"github.com/ealebed/spini/pkg/diff"
 	"github.com/ealebed/spini/types"
 )
 
@@ -78,36 +79,21 @@ func CreatePipeline(pipeline *types.Pipeline, gateClient *gateclient.GatewayClie
 	if err == nil && queryResp != nil && queryResp.StatusCode == http.StatusOK && len(foundPipeline) > 0 {
 		fmt.Println("Pipeline " + pipeline.Name + " exists, let's update it!")
 
-		prettyStr, err := json.MarshalIndent(foundPipeline, "", " ")
+		pipe, err = PipelineFromConfig(foundPipeline)
 		if err != nil {
-			return fmt.Errorf("failed to marshal pipeline: %w", err)
-		}
-		if err := json.Unmarshal(prettyStr, &pipe); err != nil {
-			return fmt.Errorf("failed to unmarshal pipeline: %w", err)
-		}
-
-		for _, triggerExists := range pipe.Triggers {
-			// let's use Spinnaker's known service-account in triggers
-			for _, triggerCreated := range pipeline.Triggers {
-				triggerCreated.RunAsUser = triggerExists.RunAsUser
-			}
-			// let's use Spinnaker's known dependent Pipeline ID in triggers 'pipeline' type
-			if triggerExists.Type == "pipeline" {
-				for _, triggerCreated := range pipeline.Triggers {
-					if triggerCreated.Type == "pipeline" {
-						triggerCreated.Pipeline = triggerExists.Pipeline
-					}
-				}
-			}
+			return err
 		}
 
-		// let's use Spinnaker's known Pipeline ID and index
-		pipeline.ID = pipe.ID
-		pipeline.Index = pipe.Index
+		AdoptLivePipeline(pipeline, pipe)
 	} else {
 		fmt.Println("Pipeline " + pipeline.Name + " doesn't exists, let's create a new one!")
 	}
 
+	return SavePipeline(pipeline, gateClient)
+}
+
+// SavePipeline POST pipeline config as is, creating new pipeline or replacing existing one with the same ID
+func SavePipeline(pipeline *types.Pipeline, gateClient *gateclient.GatewayClient) error {
 	saveResp, saveErr := gateClient.PipelineControllerApi.SavePipelineUsingPOST(
 		gateClient.Context,
 		pipeline,
@@ -127,3 +113,190 @@ func CreatePipeline(pipeline *types.Pipeline, gateClient *gateclient.GatewayClie
 
 	return nil
 }
+
+// AdoptLivePipeline copies Spinnaker's known pipeline ID, index and service-account in triggers
+// from live pipeline into generated one, parent pipeline IDs are resolved by GeneratePipelines
+func AdoptLivePipeline(pipeline, live *types.Pipeline) {
+	// let's use Spinnaker's known service-account in triggers
+	for _, triggerExists := range live.Triggers {
+		for _, triggerCreated := range pipeline.Triggers {
+			triggerCreated.RunAsUser = triggerExists.RunAsUser
+		}
+	}
+
+	// let's use Spinnaker's known Pipeline ID and index
+	pipeline.ID = live.ID
+	pipeline.Index = live.Index
+}
+
+// GetPipelineConfig returns live pipeline config, nil is returned when pipeline doesn't exist
+func GetPipelineConfig(application, pipelineName string, gateClient *gateclient.GatewayClient) (map[string]interface{}, error) {
+	foundPipeline, resp, err := gateClient.ApplicationControllerApi.GetPipelineConfigUsingGET(
+		gateClient.Context,
+		application,
+		pipelineName)
+
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck // acceptable to ignore close errors in defer
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("encountered an error getting pipeline %s: %v", pipelineName, err)
+	}
+	if len(foundPipeline) == 0 {
+		return nil, nil
+	}
+
+	return foundPipeline, nil
+}
+
+// GetApplicationConfig returns live application attributes, nil is returned when application doesn't exist
+func GetApplicationConfig(application string, gateClient *gateclient.GatewayClient) (map[string]interface{}, error) {
+	app, resp, err := gateClient.ApplicationControllerApi.GetApplicationUsingGET(
+		gateClient.Context,
+		application,
+		&gate.ApplicationControllerApiGetApplicationUsingGETOpts{})
+
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck // acceptable to ignore close errors in defer
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("encountered an error getting application %s: %v", application, err)
+	}
+
+	// NOTE: app GET wraps the actual app attributes in an 'attributes' field.
+	attributes, ok := app["attributes"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	return attributes, nil
+}
+
+// PipelineFromConfig converts live pipeline config into pipeline object
+func PipelineFromConfig(config map[string]interface{}) (*types.Pipeline, error) {
+	var pipe *types.Pipeline
+
+	prettyStr, err := json.MarshalIndent(config, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pipeline: %w", err)
+	}
+	if err := json.Unmarshal(prettyStr, &pipe); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pipeline: %w", err)
+	}
+
+	return pipe, nil
+}
+
+// DiffApplication compares generated application with its live state
+func DiffApplication(application *types.Application, gateClient *gateclient.GatewayClient) (*diff.Result, error) {
+	live, err := GetApplicationConfig(application.Name, gateClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.NewResult("application", application.Name, live, application)
+}
+
+// DiffPipeline compares generated pipeline with its live state.
+// Spinnaker's known pipeline ID, index and trigger settings are adopted by generated pipeline as CreatePipeline does.
+func DiffPipeline(pipeline *types.Pipeline, gateClient *gateclient.GatewayClient) (*diff.Result, error) {
+	name := pipeline.Application + "/" + pipeline.Name
+
+	live, err := GetPipelineConfig(pipeline.Application, pipeline.Name, gateClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if live == nil {
+		return diff.NewResult("pipeline", name, nil, pipeline)
+	}
+
+	livePipeline, err := PipelineFromConfig(live)
+	if err != nil {
+		return nil, err
+	}
+	AdoptLivePipeline(pipeline, livePipeline)
+
+	return diff.NewResult("pipeline", name, live, pipeline)
+}
+
+// DeletePipeline deletes pipeline from spinnaker application
+func DeletePipeline(application, pipelineName string, gateClient *gateclient.GatewayClient) error {
+	resp, err := gateClient.PipelineControllerApi.DeletePipelineUsingDELETE(
+		gateClient.Context,
+		application,
+		pipelineName)
+
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck // acceptable to ignore close errors in defer
+	}
+
+	if err != nil {
+		return fmt.Errorf("encountered an error deleting pipeline %s: %v", pipelineName, err)
+	} else if resp != nil && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("encountered an error deleting pipeline %s, status code: %d", pipelineName, resp.StatusCode)
+	}
+
+	fmt.Println("Application " + application + ":\n \u2714 Pipeline " + pipelineName + " delete succeeded")
+
+	return nil
+}
+
+// ListPipelineConfigs returns live configs of all pipelines of spinnaker application
+func ListPipelineConfigs(application string, gateClient *gateclient.GatewayClient) ([]map[string]interface{}, error) {
+	payload, resp, err := gateClient.ApplicationControllerApi.GetPipelineConfigsForApplicationUsingGET(
+		gateClient.Context,
+		application)
+
+	if resp != nil {
+		defer resp.Body.Close() //nolint:errcheck // acceptable to ignore close errors in defer
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("encountered an error listing pipelines for application %s: %v", application, err)
+	}
+
+	configs := []map[string]interface{}{}
+	for _, item := range payload {
+		if config, ok := item.(map[string]interface{}); ok {
+			configs = append(configs, config)
+		}
+	}
+
+	return configs, nil
+}
+
+// LivePipelineIDs returns IDs of live pipelines of the application by pipeline name
+func LivePipelineIDs(application string, gateClient *gateclient.GatewayClient) (map[string]string, error) {
+	configs, err := ListPipelineConfigs(application, gateClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return PipelineIDs(configs), nil
+}
+
+// PipelineIDs returns IDs of pipeline configs by pipeline name
+func PipelineIDs(configs []map[string]interface{}) map[string]string {
+	ids := map[string]string{}
+	for _, config := range configs {
+		name, _ := config["name"].(string)
+		id, _ := config["id"].(string)
+		if name != "" && id != "" {
+			ids[name] = id
+		}
+	}
+
+	return ids
+}
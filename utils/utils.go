@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
+	"slices"
 	"strings"
 
 	"github.com/google/go-github/v44/github"
@@ -32,49 +34,19 @@ import (
 	kjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+	"sigs.k8s.io/yaml"
 
+	"github.com/ealebed/spini/pkg/registry"
 	"github.com/ealebed/spini/types"
 	git "github.com/ealebed/spini/utils/github"
 )
 
 const (
 	stageProduction = "production"
-	stageNightly    = "nightly"
 )
 
-var (
-	removeLines = [][]byte{
-		// The Kubernetes standard header field `currentTimestamp` serializes weirdly, so filter it out.
-		[]byte("  creationTimestamp: null"),
-		[]byte("        resources: {}"),
-		[]byte("      resources: {}"),
-		[]byte("    resources: {}"),
-		[]byte("status: {}"),
-		[]byte("spec: {}"),
-		[]byte("status:"),
-		[]byte("  loadBalancer: {}"),
-		[]byte("targetPort: 0"),
-	}
-)
-
-// ReadJSONLocalToStruct returns struct with configuration values from selected repository
-func ReadJSONLocalToStruct() []*types.Configuration {
-	configuration := make([]*types.Configuration, 0)
-
-	var filename = "configuration.json"
-
-	file, err := os.ReadFile(filename)
-	if err != nil {
-		fmt.Print(err)
-	}
-
-	if err := json.Unmarshal(file, &configuration); err != nil {
-		fmt.Printf("Failed to unmarshal JSON file 'configuration.json' due to %s", err)
-		os.Exit(1)
-	}
-
-	return configuration
-}
+// emptyStructFields are fields of kubernetes objects which aren't pointers, so they are serialized as {} when unset
+var emptyStructFields = []string{"metadata", "resources", "spec", "status", "updateStrategy"}
 
 // GetFileContent loads the local content of a file and return the target name of the file in the target repository and its contents.
 func GetFileContent(fileArg string) (targetName string, b []byte, err error) {
@@ -116,53 +88,29 @@ func sliceContains(s []string, str string) bool {
 	return false
 }
 
-// fillPipelineConfig fills pipeline configuration for generating spinnaker pipelines
-//
-//nolint:gocyclo // complex business logic requires multiple conditionals
-func fillPipelineConfig(stage string, pList []string, pipelineIDs map[string]string) map[string]interface{} {
+// fillPipelineConfig fills pipeline configuration for generating spinnaker pipelines of the profile.
+// Profile promoted from another profile gets promote-to-<profile> pipeline triggered by the source deploy pipeline,
+// and its deploy pipelines are triggered by the promote pipeline instead of docker image push.
+func fillPipelineConfig(app *types.Configuration, profile *types.Profile, pipelineIDs map[string]string) map[string]interface{} {
 	var pipeValues = make(map[string]interface{})
 
-	pipeValues["stage"] = stage
-
-	if sliceContains(pList, "beta") || sliceContains(pList, stageNightly) {
-		if stage == stageProduction || (strings.Contains(stage, "dev")) {
-			pipeValues["dockerTriggerEnabled"] = false
-		} else if sliceContains(pList, "beta") && stage == stageNightly {
-			pipeValues["dockerTriggerEnabled"] = false
-		} else if !sliceContains(pList, "beta") && stage == stageNightly {
-			pipeValues["dockerTriggerEnabled"] = true
-		} else if !sliceContains(pList, stageNightly) && stage == "beta" {
-			pipeValues["dockerTriggerEnabled"] = true
-		}
-		pipeValues["dockerTriggerEnabled"] = true
-	} else {
-		pipeValues["dockerTriggerEnabled"] = true
-	}
-
-	if sliceContains(pList, "beta") && (stage == stageProduction || stage == stageNightly) {
-		// by default set in all promote pipelines trigger parentPipelineID from 'beta' pipeline
-		pipeValues["id"] = pipelineIDs["promote-to-"+stage]
-		pipeValues["parentPipelineId"] = pipelineIDs["beta-gke1"]
-
-		// in case we have 'nightly' stage, set in promote-to-production pipeline trigger parentPipelineID from 'nightly' pipeline
-		if stage == stageProduction && pipelineIDs["nightly-gke1"] != "" {
-			pipeValues["parentPipelineId"] = pipelineIDs["nightly-gke1"]
-		}
-
-		pipeValues["GeneratePromotePipeline"] = true
-		pipeValues["pipelineTriggerEnabled"] = true
-	} else if sliceContains(pList, stageNightly) && stage == stageProduction {
-		// in case we have 'nightly' stage, set in promote-to-production pipeline trigger parentPipelineID from 'nightly' pipeline
-		pipeValues["id"] = pipelineIDs["promote-to-"+stage]
-		pipeValues["parentPipelineId"] = pipelineIDs["nightly-gke1"]
+	pipeValues["stage"] = profile.ProfileName
 
-		pipeValues["GeneratePromotePipeline"] = true
-		pipeValues["pipelineTriggerEnabled"] = true
-	} else {
+	sourceProfile, sourceTier := app.PromotionSource(profile)
+	if sourceProfile == "" {
+		pipeValues["dockerTriggerEnabled"] = true
 		pipeValues["GeneratePromotePipeline"] = false
 		pipeValues["pipelineTriggerEnabled"] = false
+
+		return pipeValues
 	}
 
+	pipeValues["dockerTriggerEnabled"] = false
+	pipeValues["id"] = pipelineIDs["promote-to-"+profile.ProfileName]
+	pipeValues["parentPipelineId"] = pipelineIDs[types.DeployPipelineName(sourceTier, sourceProfile)]
+	pipeValues["GeneratePromotePipeline"] = true
+	pipeValues["pipelineTriggerEnabled"] = true
+
 	return pipeValues
 }
 
@@ -175,11 +123,19 @@ func formatManifest(in []byte) (*bytes.Buffer, error) {
 		Strict:               true,
 	}
 
-	// validate generated kubernetes manifest with kubeval
-	_, err := kubeval.Validate(in, kubevalConfig)
+	schemaCache, err := newSchemaCache()
+	if err != nil {
+		return nil, err
+	}
+
+	// validate generated kubernetes manifest with kubeval, custom resources are validated against bundled schemas
+	results, err := kubeval.ValidateWithCache(in, schemaCache, kubevalConfig)
 	if err != nil {
 		return nil, err
 	}
+	if err := bundledSchemaErrors(results); err != nil {
+		return nil, err
+	}
 
 	// format the field ordering in generated YAML
 	out := &bytes.Buffer{}
@@ -195,32 +151,74 @@ func formatManifest(in []byte) (*bytes.Buffer, error) {
 	return out, nil
 }
 
-// GeneratePipelines returns list generated spinnaker pipeline objects
-func GeneratePipelines(app *types.Configuration, organization, githubRepositoryName string) []*types.Pipeline {
-	var pipelineNamesList []string
+// pipelineNamespace is the UUIDv5 namespace of generated spinnaker pipeline IDs
+var pipelineNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/ealebed/spini/pipelines"))
+
+// PipelineID returns deterministic name-based (UUIDv5) ID of the pipeline generated for application, profile and tier,
+// empty profile identifies build pipeline and empty tier identifies promote-to-profile pipeline
+func PipelineID(application, profile, tier string) string {
+	return uuid.NewSHA1(pipelineNamespace, []byte(application+"/"+profile+"/"+tier)).String()
+}
+
+// GeneratedPipeline checks that live pipeline was created by spini, i.e. it has name of generated pipeline
+// and deterministic ID of that name. Pipelines created by hand or before IDs became deterministic aren't reported.
+func GeneratedPipeline(application, name, id string) bool {
+	switch {
+	case name == "build-image":
+		return id == PipelineID(application, "", "")
+	case strings.HasPrefix(name, "promote-to-"):
+		return id == PipelineID(application, strings.TrimPrefix(name, "promote-to-"), "")
+	case strings.HasPrefix(name, "deploy-") && strings.HasSuffix(name, ")"):
+		tier, profile, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(name, "deploy-"), ")"), "-dc(")
+		return ok && id == PipelineID(application, profile, tier)
+	}
+
+	return false
+}
+
+// resolvePipelineID returns ID of the live pipeline with the given name, or deterministic ID when it doesn't exist
+func resolvePipelineID(liveIDs map[string]string, name, application, profile, tier string) string {
+	if id := liveIDs[name]; id != "" {
+		return id
+	}
+
+	return PipelineID(application, profile, tier)
+}
 
+// GeneratePipelines returns list generated spinnaker pipeline objects, liveIDs maps names of existing
+// pipelines to their IDs and is used to keep IDs (and parent pipeline triggers) of already created pipelines
+func GeneratePipelines(app *types.Configuration, organization, githubRepositoryName string,
+	liveIDs map[string]string) ([]*types.Pipeline, error) {
 	var generatedPipelineList []*types.Pipeline
 	var pipelineIDs = map[string]string{}
 
+	maxmindVersion, err := resolveVersions(app, organization)
+	if err != nil {
+		return nil, err
+	}
+
 	// generate build pipeline
 	buildPipeline := types.NewBuildPipeline(app)
+	buildPipeline.ID = resolvePipelineID(liveIDs, buildPipeline.Name, app.Application, "", "")
 	generatedPipelineList = append(generatedPipelineList, buildPipeline)
 
-	// here we collect all pipeline names to list and create pipelineIDs, including IDs for promote to stage pipeline
+	// resolve IDs of all pipelines by name up front, so triggers can reference pipelines of any profile
 	for _, profile := range *app.Profiles {
-		pipelineNamesList = append(pipelineNamesList, profile.ProfileName)
-		if profile.ProfileName != "beta" {
-			pipelineIDs["promote-to-"+profile.ProfileName] = uuid.New().String()
+		if app.Promotion(profile) != nil {
+			pipelineIDs["promote-to-"+profile.ProfileName] = resolvePipelineID(liveIDs,
+				"promote-to-"+profile.ProfileName, app.Application, profile.ProfileName, "")
 		}
 		for _, tier := range *profile.Datacenters {
-			pipelineIDs[profile.ProfileName+"-"+tier.TierName] = uuid.New().String()
+			name := types.DeployPipelineName(tier.TierName, profile.ProfileName)
+			pipelineIDs[name] = resolvePipelineID(liveIDs, name, app.Application, profile.ProfileName, tier.TierName)
 		}
 	}
 
 	for _, profile := range *app.Profiles {
-		pipeValues := fillPipelineConfig(profile.ProfileName, pipelineNamesList, pipelineIDs)
+		pipeValues := fillPipelineConfig(app, profile, pipelineIDs)
 		pipeValues["organization"] = organization
 		pipeValues["githubRepositoryName"] = githubRepositoryName
+		pipeValues["maxmindVersion"] = maxmindVersion
 
 		// generate promote-to-stage pipelines
 		if pipeValues["GeneratePromotePipeline"].(bool) {
@@ -231,24 +229,134 @@ func GeneratePipelines(app *types.Configuration, organization, githubRepositoryN
 		// generate deploy pipelines
 		for _, tier := range *profile.Datacenters {
 			pipeValues["cluster"] = tier.TierName
-			pipeValues["id"] = pipelineIDs[profile.ProfileName+"-"+tier.TierName]
+			pipeValues["id"] = pipelineIDs[types.DeployPipelineName(tier.TierName, profile.ProfileName)]
 			pipeValues["parentPipelineId"] = pipelineIDs["promote-to-"+profile.ProfileName]
 
-			if tier.EnvFrom != nil {
-				app.EnvFrom = append(app.EnvFrom, tier.EnvFrom...)
-			}
+			// deploy pipeline gets envFrom of the datacenter, shared application configuration isn't changed
+			deployConfig := *app
+			deployConfig.EnvFrom = append(slices.Clone(app.EnvFrom), tier.EnvFrom...)
 
-			deployPipeline := types.NewDeployPipeline(app, pipeValues)
+			deployPipeline := types.NewDeployPipeline(&deployConfig, pipeValues)
 			generatedPipelineList = append(generatedPipelineList, deployPipeline)
 		}
 	}
 
-	return generatedPipelineList
+	return generatedPipelineList, nil
 }
 
-// GenerateManifests returns generated kubernetes manifest objects
-func GenerateManifests(app *types.Configuration, tier *types.Datacenter, stage, organization string) string {
-	var buf bytes.Buffer
+// resolveVersions sets application version to the most recent image tag selected by application tag policy when
+// it isn't configured, and returns the most recent maxmind image tag for applications depending on maxmind
+func resolveVersions(app *types.Configuration, organization string) (string, error) {
+	if app.Version != "" && !app.HasDependency("maxmind") {
+		return "", nil
+	}
+
+	client, err := registry.New(app.ImageRegistry(organization))
+	if err != nil {
+		return "", err
+	}
+
+	if app.Version == "" {
+		if app.Version, err = registry.LatestTag(client, app.DockerImage, app.ImageTagPolicy()); err != nil {
+			return "", err
+		}
+	}
+
+	if !app.HasDependency("maxmind") {
+		return "", nil
+	}
+
+	return registry.LatestTag(client, types.MaxmindImage, types.DefaultTagPolicy())
+}
+
+// ManifestPath returns path of generated kubernetes manifest inside manifests repository
+func ManifestPath(app *types.Configuration, tier *types.Datacenter, stage string) string {
+	directory := "datacenters/" + tier.TierName + "/" + app.Namespace + "/"
+
+	if stage != stageProduction {
+		return directory + app.Application + "-" + stage + ".yaml"
+	}
+
+	return directory + app.Application + ".yaml"
+}
+
+// workloadKinds are kinds of kubernetes objects which run the application
+var workloadKinds = []string{"Deployment", "StatefulSet", "CronJob", "Job"}
+
+// ManifestApplication returns name of the application which generated kubernetes manifest file,
+// empty string is returned for manifests not generated by spini
+func ManifestApplication(content []byte, organization string) string {
+	var list struct {
+		Items []struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name        string            `json:"name"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+
+	if err := yaml.Unmarshal(content, &list); err != nil {
+		return ""
+	}
+
+	for _, item := range list.Items {
+		if !slices.Contains(workloadKinds, item.Kind) || item.Metadata.Annotations["service."+organization+".dev/generated"] == "" {
+			continue
+		}
+
+		stage := item.Metadata.Annotations["moniker.spinnaker.io/stack"]
+		if stage == stageProduction {
+			return item.Metadata.Name
+		}
+
+		return strings.TrimSuffix(item.Metadata.Name, "-"+stage)
+	}
+
+	return ""
+}
+
+// RenderManifests returns validated and formatted kubernetes manifest objects
+func RenderManifests(app *types.Configuration, tier *types.Datacenter, stage, organization string) ([]byte, error) {
+	if tier.LivenessProbe == nil {
+		return nil, fmt.Errorf("livenessProbe is required for datacenter %q of application %q", tier.TierName, app.Application)
+	}
+	if err := tier.Resources.CheckQuantities(); err != nil {
+		return nil, fmt.Errorf("resources of datacenter %q of application %q: %w", tier.TierName, app.Application, err)
+	}
+	if err := tier.Autoscaling.CheckQuantities(); err != nil {
+		return nil, fmt.Errorf("autoscaling of datacenter %q of application %q: %w", tier.TierName, app.Application, err)
+	}
+	for _, volume := range app.PodVolumes() {
+		if err := volume.CheckQuantities(); err != nil {
+			return nil, fmt.Errorf("volume %q of application %q: %w", volume.Name, app.Application, err)
+		}
+	}
+	if err := app.StatefulSet.CheckQuantities(); err != nil {
+		return nil, fmt.Errorf("statefulSet of application %q: %w", app.Application, err)
+	}
+	if tier.Env != nil {
+		for _, env := range *tier.Env {
+			if err := env.CheckQuantities(); err != nil {
+				return nil, fmt.Errorf("env of datacenter %q of application %q: %w", tier.TierName, app.Application, err)
+			}
+		}
+	}
+	for _, container := range append(app.PodSidecars(tier), app.PodInitContainers(tier)...) {
+		if err := container.Resources.CheckQuantities(); err != nil {
+			return nil, fmt.Errorf("resources of container %q of application %q: %w", container.Name, app.Application, err)
+		}
+		for _, env := range container.Env {
+			if err := env.CheckQuantities(); err != nil {
+				return nil, fmt.Errorf("env of container %q of application %q: %w", container.Name, app.Application, err)
+			}
+		}
+	}
+
+	if exceeded := app.PodResourcePolicy().ExceededResources(app.PodResources(tier)); len(exceeded) != 0 {
+		return nil, fmt.Errorf("resources of datacenter %q of application %q: %s", tier.TierName, app.Application, strings.Join(exceeded, ", "))
+	}
+
 	list := &metav1.List{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "List",
@@ -259,17 +367,48 @@ func GenerateManifests(app *types.Configuration, tier *types.Datacenter, stage,
 	sa := types.NewServiceAccount(app.Application, stage, app.Namespace, "dockerhubkey")
 	list.Items = append(list.Items, runtime.RawExtension{Object: sa})
 
-	if app.Type == "service" {
-		s := types.NewService(app.Application, stage, app.Namespace, app.Ports)
+	if app.HasService() {
+		s := types.NewService(app.Application, stage, app.Namespace, app.Ports, app.Service)
 		list.Items = append(list.Items, runtime.RawExtension{Object: s})
+
+		if app.Type == types.WorkloadTypeService && app.Ingress != nil {
+			list.Items = append(list.Items, runtime.RawExtension{Object: types.NewIngressRoute(app, stage)})
+		}
+	}
+
+	list.Items = append(list.Items, runtime.RawExtension{Object: types.NewWorkload(app, tier, stage, organization)})
+
+	if hpa := types.NewHorizontalPodAutoscaler(app, tier, stage, organization); hpa != nil {
+		list.Items = append(list.Items, runtime.RawExtension{Object: hpa})
 	}
 
-	if tier.ChaosMonkey == nil {
-		tier.ChaosMonkey = app.ChaosMonkey
+	if pdb := types.NewPodDisruptionBudget(app, tier, stage, organization); pdb != nil {
+		list.Items = append(list.Items, runtime.RawExtension{Object: pdb})
 	}
 
-	d := types.NewDeployment(app, tier, stage, organization)
-	list.Items = append(list.Items, runtime.RawExtension{Object: d})
+	if policy := types.NewNetworkPolicy(app, stage); policy != nil {
+		list.Items = append(list.Items, runtime.RawExtension{Object: policy})
+	}
+
+	if monitor := types.NewMonitor(app, stage); monitor != nil {
+		list.Items = append(list.Items, runtime.RawExtension{Object: monitor})
+	}
+
+	return encodeManifests(list)
+}
+
+// encodeManifests returns list of kubernetes objects serialized to validated and formatted yaml
+func encodeManifests(list *metav1.List) ([]byte, error) {
+	pruned := &metav1.List{TypeMeta: list.TypeMeta}
+	for _, item := range list.Items {
+		raw, err := pruneManifest(item.Object)
+		if err != nil {
+			return nil, err
+		}
+		pruned.Items = append(pruned.Items, runtime.RawExtension{Raw: raw})
+	}
+
+	var buf bytes.Buffer
 
 	options := kjson.SerializerOptions{
 		Yaml:   true,
@@ -278,47 +417,127 @@ func GenerateManifests(app *types.Configuration, tier *types.Datacenter, stage,
 	}
 
 	e := kjson.NewSerializerWithOptions(kjson.DefaultMetaFactory, nil, nil, options)
-	err := e.Encode(list, &buf)
+	if err := e.Encode(pruned, &buf); err != nil {
+		return nil, err
+	}
+
+	out, err := formatManifest(buf.Bytes())
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("can't format manifest: %s", err)
 	}
 
-	cleaned := buf.Bytes()
+	return out.Bytes(), nil
+}
 
-	// We currently use bytes.Replace for ease/speed but we have to repeat extra lines due to varying whitespace.
-	// May consider doing regexp but it will probably result in it being slower/more
-	for _, removeLine := range removeLines {
-		cleaned = bytes.ReplaceAll(cleaned, removeLine, []byte(""))
+// pruneManifest returns kubernetes object serialized to json without status, which is set by the cluster,
+// null fields and empty non-pointer structs, which the serializer can't omit
+func pruneManifest(object runtime.Object) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, fmt.Errorf("can't convert %s to unstructured: %w", object.GetObjectKind().GroupVersionKind().Kind, err)
 	}
 
-	// The Kubernetes standard header field `currentTimestamp` serializes weirdly, so filter it out.
+	delete(content, "status")
+	pruneFields(content)
+
+	return json.Marshal(content)
+}
+
+// pruneFields recursively removes null fields and empty structs listed in emptyStructFields
+func pruneFields(content map[string]interface{}) {
+	for key, value := range content {
+		switch value := value.(type) {
+		case nil:
+			delete(content, key)
+		case map[string]interface{}:
+			pruneFields(value)
+			if len(value) == 0 && slices.Contains(emptyStructFields, key) {
+				delete(content, key)
+			}
+		case []interface{}:
+			for _, item := range value {
+				if item, ok := item.(map[string]interface{}); ok {
+					pruneFields(item)
+				}
+			}
+		}
+	}
+}
 
-	out, err := formatManifest(cleaned)
+// GenerateManifests writes generated kubernetes manifest objects on disk and returns path of the file
+func GenerateManifests(app *types.Configuration, tier *types.Datacenter, stage, organization string) (string, error) {
+	out, err := RenderManifests(app, tier, stage, organization)
 	if err != nil {
-		fmt.Printf("can't format manifest: %s", err)
+		return "", fmt.Errorf("failed to generate manifest of %s in %s: %w", app.Application, tier.TierName, err)
 	}
 
-	directory := "datacenters/" + tier.TierName + "/" + app.Namespace + "/"
+	filePath := ManifestPath(app, tier, stage)
+	if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil { //nolint:gosec // 0755 is appropriate for directory permissions
+		return "", fmt.Errorf("failed to create directory %s: %w", path.Dir(filePath), err)
+	}
 
-	_, cerr := os.Stat(directory)
-	if os.IsNotExist(cerr) {
-		errDir := os.MkdirAll(directory, 0755) //nolint:gosec // 0755 is appropriate for directory permissions
-		if errDir != nil {
-			panic(err)
-		}
+	if err := WriteFileOnDisk(out, filePath); err != nil {
+		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
 	}
 
-	filePath := directory + app.Application + ".yaml"
-	if stage != stageProduction {
-		filePath = directory + app.Application + "-" + stage + ".yaml"
+	return filePath, nil
+}
+
+// NamespaceManifestPath returns path of kubernetes manifest file with the namespace in the cluster
+func NamespaceManifestPath(cluster, namespace string) string {
+	return "datacenters/" + cluster + "/" + namespace + "/_namespace.yaml"
+}
+
+// RenderNamespaceManifests returns validated and formatted kubernetes manifest of the namespace with
+// resource quota and limit range, settings are merged from all applications deployed to the namespace
+func RenderNamespaceManifests(apps []*types.Configuration, namespace, organization string) ([]byte, error) {
+	settings := types.MergeNamespaceSettings(apps, namespace)
+	if err := settings.CheckQuantities(); err != nil {
+		return nil, fmt.Errorf("settings of namespace %q: %w", namespace, err)
 	}
 
-	if err := WriteFileOnDisk(out.Bytes(), filePath); err != nil {
-		fmt.Printf("Failed to write file %s: %s", filePath, err)
-		return ""
+	list := &metav1.List{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "List",
+			APIVersion: "v1",
+		},
+	}
+
+	list.Items = append(list.Items, runtime.RawExtension{Object: types.NewNamespace(namespace, organization, settings)})
+
+	if quota := types.NewResourceQuota(namespace, settings); quota != nil {
+		list.Items = append(list.Items, runtime.RawExtension{Object: quota})
+	}
+
+	if limits := types.NewLimitRange(namespace, settings); limits != nil {
+		list.Items = append(list.Items, runtime.RawExtension{Object: limits})
+	}
+
+	return encodeManifests(list)
+}
+
+// GenerateNamespaceManifests writes namespace manifest of the cluster on disk and returns path of the file,
+// empty path is returned for "default" namespace which isn't managed by spini
+func GenerateNamespaceManifests(apps []*types.Configuration, cluster, namespace, organization string) (string, error) {
+	if namespace == "" || namespace == "default" {
+		return "", nil
+	}
+
+	out, err := RenderNamespaceManifests(apps, namespace, organization)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate manifest of namespace %s in %s: %w", namespace, cluster, err)
 	}
 
-	return filePath
+	filePath := NamespaceManifestPath(cluster, namespace)
+	if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil { //nolint:gosec // 0755 is appropriate for directory permissions
+		return "", fmt.Errorf("failed to create directory %s: %w", path.Dir(filePath), err)
+	}
+
+	if err := WriteFileOnDisk(out, filePath); err != nil {
+		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+
+	return filePath, nil
 }
 
 // CreatePullRequest create pull request with generated manifests to github repository
@@ -351,20 +570,3 @@ func CreatePullRequest(sourceFiles string, prOptions *types.PullRequestOptions)
 
 	return nil
 }
-
-// LoadConfiguration returns application config from local or remote configuration.json file
-func LoadConfiguration(local bool, organization, repositoryName, branch string) []*types.Configuration {
-	var configResponse = make([]*types.Configuration, 0)
-	gc := git.NewClient()
-
-	if local {
-		configResponse = ReadJSONLocalToStruct()
-	} else if repositoryName != "" {
-		configResponse = gc.ReadJSONFromRepoToStruct(
-			organization,
-			repositoryName,
-			branch)
-	}
-
-	return configResponse
-}
@@ -1,7 +1,16 @@
 package utils
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ealebed/spini/types"
 )
 
 func TestSliceContains(t *testing.T) {
@@ -72,132 +81,701 @@ func TestSliceContains(t *testing.T) {
 }
 
 func TestFillPipelineConfig(t *testing.T) {
+	profile := func(name string, promotesFrom *types.Promotion, tiers ...string) *types.Profile {
+		datacenters := []*types.Datacenter{}
+		for _, tier := range tiers {
+			datacenters = append(datacenters, &types.Datacenter{TierName: tier})
+		}
+		return &types.Profile{ProfileName: name, PromotesFrom: promotesFrom, Datacenters: &datacenters}
+	}
+
+	// dev -> qa -> staging -> prod-eu/prod-us
+	app := &types.Configuration{
+		Application: "myapp",
+		Profiles: &[]*types.Profile{
+			profile("dev", nil, "gke1"),
+			profile("qa", &types.Promotion{ProfileName: "dev"}, "gke1"),
+			profile("staging", &types.Promotion{ProfileName: "qa"}, "gke1", "gke2"),
+			profile("prod-eu", &types.Promotion{ProfileName: "staging", TierName: "gke2"}, "gke1"),
+			profile("prod-us", &types.Promotion{ProfileName: "staging"}, "gke1"),
+			profile("canary", &types.Promotion{ProfileName: "missing"}, "gke1"),
+		},
+	}
+
+	pipelineIDs := map[string]string{
+		"deploy-gke1-dc(dev)":     "dev-id",
+		"deploy-gke1-dc(qa)":      "qa-id",
+		"deploy-gke1-dc(staging)": "staging-gke1-id",
+		"deploy-gke2-dc(staging)": "staging-gke2-id",
+		"promote-to-qa":           "promote-qa-id",
+		"promote-to-staging":      "promote-staging-id",
+		"promote-to-prod-eu":      "promote-prod-eu-id",
+		"promote-to-prod-us":      "promote-prod-us-id",
+	}
+
 	tests := []struct {
-		name        string
-		stage       string
-		pList       []string
-		pipelineIDs map[string]string
-		expected    map[string]interface{}
+		name     string
+		profile  string
+		expected map[string]interface{}
 	}{
 		{
-			name:  "production stage with beta and nightly",
-			stage: stageProduction,
-			pList: []string{"beta", stageNightly},
-			pipelineIDs: map[string]string{
-				"promote-to-production": "promote-prod-id",
-				"beta-gke1":             "beta-id",
-				"nightly-gke1":          "nightly-id",
-			},
+			name:    "root profile is triggered by docker image",
+			profile: "dev",
 			expected: map[string]interface{}{
-				"stage":                   stageProduction,
+				"stage":                   "dev",
 				"dockerTriggerEnabled":    true,
-				"id":                      "promote-prod-id",
-				"parentPipelineId":        "nightly-id",
-				"GeneratePromotePipeline": true,
-				"pipelineTriggerEnabled":  true,
+				"GeneratePromotePipeline": false,
+				"pipelineTriggerEnabled":  false,
 			},
 		},
 		{
-			name:  "nightly stage with beta",
-			stage: stageNightly,
-			pList: []string{"beta", stageNightly},
-			pipelineIDs: map[string]string{
-				"promote-to-nightly": "promote-nightly-id",
-				"beta-gke1":          "beta-id",
-			},
+			name:    "promoted from first tier of source profile",
+			profile: "qa",
 			expected: map[string]interface{}{
-				"stage":                   stageNightly,
-				"dockerTriggerEnabled":    true, // Line 137 always sets this to true
-				"id":                      "promote-nightly-id",
-				"parentPipelineId":        "beta-id",
+				"stage":                   "qa",
+				"dockerTriggerEnabled":    false,
+				"id":                      "promote-qa-id",
+				"parentPipelineId":        "dev-id",
 				"GeneratePromotePipeline": true,
 				"pipelineTriggerEnabled":  true,
 			},
 		},
 		{
-			name:        "beta stage without nightly",
-			stage:       "beta",
-			pList:       []string{"beta"},
-			pipelineIDs: map[string]string{},
+			name:    "promoted from explicit tier of source profile",
+			profile: "prod-eu",
 			expected: map[string]interface{}{
-				"stage":                   "beta",
-				"dockerTriggerEnabled":    true,
-				"GeneratePromotePipeline": false,
-				"pipelineTriggerEnabled":  false,
+				"stage":                   "prod-eu",
+				"dockerTriggerEnabled":    false,
+				"id":                      "promote-prod-eu-id",
+				"parentPipelineId":        "staging-gke2-id",
+				"GeneratePromotePipeline": true,
+				"pipelineTriggerEnabled":  true,
 			},
 		},
 		{
-			name:        "dev stage with beta and nightly",
-			stage:       "dev",
-			pList:       []string{"beta", stageNightly},
-			pipelineIDs: map[string]string{},
+			name:    "sibling promoted from the same profile",
+			profile: "prod-us",
 			expected: map[string]interface{}{
-				"stage":                   "dev",
-				"dockerTriggerEnabled":    true, // Line 137 always sets this to true
-				"GeneratePromotePipeline": false,
-				"pipelineTriggerEnabled":  false,
+				"stage":                   "prod-us",
+				"dockerTriggerEnabled":    false,
+				"id":                      "promote-prod-us-id",
+				"parentPipelineId":        "staging-gke1-id",
+				"GeneratePromotePipeline": true,
+				"pipelineTriggerEnabled":  true,
 			},
 		},
 		{
-			name:        "nightly stage without beta",
-			stage:       stageNightly,
-			pList:       []string{stageNightly},
-			pipelineIDs: map[string]string{},
+			name:    "missing source profile",
+			profile: "canary",
 			expected: map[string]interface{}{
-				"stage":                   stageNightly,
+				"stage":                   "canary",
 				"dockerTriggerEnabled":    true,
 				"GeneratePromotePipeline": false,
 				"pipelineTriggerEnabled":  false,
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := fillPipelineConfig(app, app.Profile(tt.profile), pipelineIDs)
+
+			for key, expectedValue := range tt.expected {
+				if result[key] != expectedValue {
+					t.Errorf("fillPipelineConfig(%q)[%q] = %v, want %v", tt.profile, key, result[key], expectedValue)
+				}
+			}
+			if len(result) != len(tt.expected) {
+				t.Errorf("fillPipelineConfig(%q) = %v, want %v", tt.profile, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFillPipelineConfigLegacyPromotion(t *testing.T) {
+	profile := func(name string, tiers ...string) *types.Profile {
+		datacenters := []*types.Datacenter{}
+		for _, tier := range tiers {
+			datacenters = append(datacenters, &types.Datacenter{TierName: tier})
+		}
+		return &types.Profile{ProfileName: name, Datacenters: &datacenters}
+	}
+	pipelineIDs := map[string]string{
+		"deploy-gke1-dc(beta)":    "beta-id",
+		"deploy-gke1-dc(nightly)": "nightly-id",
+		"promote-to-nightly":      "promote-nightly-id",
+		"promote-to-production":   "promote-production-id",
+	}
+
+	tests := []struct {
+		name     string
+		profiles []*types.Profile
+		parents  map[string]string
+	}{
+		{
+			name:     "beta and nightly",
+			profiles: []*types.Profile{profile("beta", "gke2", "gke1"), profile("nightly", "gke1"), profile("production", "gke1")},
+			parents:  map[string]string{"beta": "", "nightly": "beta-id", "production": "nightly-id"},
+		},
 		{
-			name:  "production stage with only nightly",
-			stage: stageProduction,
-			pList: []string{stageNightly},
-			pipelineIDs: map[string]string{
-				"promote-to-production": "promote-prod-id",
-				"nightly-gke1":          "nightly-id",
-			},
-			expected: map[string]interface{}{
-				"stage":                   stageProduction,
-				"dockerTriggerEnabled":    true,
-				"id":                      "promote-prod-id",
-				"parentPipelineId":        "nightly-id",
-				"GeneratePromotePipeline": true,
-				"pipelineTriggerEnabled":  true,
+			name:     "beta only",
+			profiles: []*types.Profile{profile("beta", "gke1"), profile("production", "gke1")},
+			parents:  map[string]string{"beta": "", "production": "beta-id"},
+		},
+		{
+			name:     "nightly only",
+			profiles: []*types.Profile{profile("nightly", "gke1"), profile("production", "gke1")},
+			parents:  map[string]string{"nightly": "", "production": "nightly-id"},
+		},
+		{
+			name: "explicit promotion disables legacy graph",
+			profiles: []*types.Profile{profile("beta", "gke1"), profile("production", "gke1"),
+				{ProfileName: "qa", PromotesFrom: &types.Promotion{ProfileName: "beta"}, Datacenters: &[]*types.Datacenter{}}},
+			parents: map[string]string{"beta": "", "production": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &types.Configuration{Application: "myapp", Profiles: &tt.profiles}
+
+			for name, parent := range tt.parents {
+				result := fillPipelineConfig(app, app.Profile(name), pipelineIDs)
+				if result["GeneratePromotePipeline"] != (parent != "") || result["dockerTriggerEnabled"] != (parent == "") {
+					t.Errorf("Unexpected triggers of %s: %v", name, result)
+				}
+				if parent != "" && result["parentPipelineId"] != parent {
+					t.Errorf("Expected %s promoted from %s, got %v", name, parent, result["parentPipelineId"])
+				}
+			}
+		})
+	}
+}
+
+func TestManifestPath(t *testing.T) {
+	app := &types.Configuration{Application: "myapp", Namespace: "default"}
+	tier := &types.Datacenter{TierName: "gke1"}
+
+	if result := ManifestPath(app, tier, "production"); result != "datacenters/gke1/default/myapp.yaml" {
+		t.Errorf("Unexpected production manifest path %s", result)
+	}
+	if result := ManifestPath(app, tier, "beta"); result != "datacenters/gke1/default/myapp-beta.yaml" {
+		t.Errorf("Unexpected beta manifest path %s", result)
+	}
+}
+
+func TestRenderNamespaceManifests(t *testing.T) {
+	apps := []*types.Configuration{
+		{Application: "first", Namespace: "team", NamespaceSettings: &types.NamespaceSettings{
+			Labels:        map[string]string{"team": "core"},
+			ResourceQuota: &types.ResourceQuota{Requests: &types.ResourceList{CPU: "10"}, Pods: 50},
+		}},
+		{Application: "second", Namespace: "team", NamespaceSettings: &types.NamespaceSettings{
+			Labels:     map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+			LimitRange: &types.LimitRange{Default: &types.ResourceList{CPU: "500m", Memory: "512Mi"}},
+		}},
+		{Application: "other", Namespace: "other", NamespaceSettings: &types.NamespaceSettings{Labels: map[string]string{"team": "other"}}},
+	}
+
+	out, err := RenderNamespaceManifests(apps, "team", "ealebed")
+	if err != nil {
+		t.Fatalf("RenderNamespaceManifests returned error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"kind: Namespace",
+		"pod-security.kubernetes.io/enforce: restricted",
+		"team: core",
+		"service.ealebed.dev/generated: spini/v1",
+		"kind: ResourceQuota",
+		"requests.cpu: \"10\"",
+		"pods: \"50\"",
+		"kind: LimitRange",
+		"cpu: 500m",
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("Expected %q in namespace manifest:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(string(out), "team: other") || strings.Contains(string(out), "status:") {
+		t.Errorf("Unexpected content in namespace manifest:\n%s", out)
+	}
+
+	if result := NamespaceManifestPath("gke1", "team"); result != "datacenters/gke1/team/_namespace.yaml" {
+		t.Errorf("Unexpected namespace manifest path %s", result)
+	}
+	if result, err := GenerateNamespaceManifests(apps, "gke1", "default", "ealebed"); result != "" || err != nil {
+		t.Errorf("Expected no manifest of default namespace, got %s, %v", result, err)
+	}
+
+	apps[0].NamespaceSettings.ResourceQuota.Limits = &types.ResourceList{Memory: "1 gig"}
+	if _, err := RenderNamespaceManifests(apps, "team", "ealebed"); err == nil || !strings.Contains(err.Error(), "resourceQuota") {
+		t.Errorf("Expected error of invalid resource quota, got %v", err)
+	}
+}
+
+func TestRenderManifestsMonitor(t *testing.T) {
+	tier := &types.Datacenter{
+		TierName:      "gke1",
+		Replicas:      1,
+		Resources:     &types.ResourceRequirements{Requests: &types.ResourceList{CPU: "100m", Memory: "128Mi"}},
+		LivenessProbe: &types.Probe{Type: "http", Port: 8080},
+		ChaosMonkey:   &types.ChaosMonkey{},
+	}
+	app := &types.Configuration{
+		Application: "myapp",
+		DockerImage: "myapp",
+		Namespace:   "default",
+		Type:        "service",
+		Ports:       []types.Port{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9113}},
+		Monitoring:  &types.Monitoring{Interval: "30s"},
+	}
+
+	out, err := RenderManifests(app, tier, "production", "ealebed")
+	if err != nil {
+		t.Fatalf("RenderManifests returned error: %v", err)
+	}
+	if !strings.Contains(string(out), "kind: ServiceMonitor") || !strings.Contains(string(out), "interval: 30s") {
+		t.Errorf("Expected ServiceMonitor in manifest:\n%s", out)
+	}
+
+	app.Monitoring.Interval = "soon"
+	if _, err := RenderManifests(app, tier, "production", "ealebed"); err == nil || !strings.Contains(err.Error(), "spec.endpoints.0.interval") {
+		t.Errorf("Expected bundled schema error of interval, got %v", err)
+	}
+}
+
+func TestRenderManifestsProbes(t *testing.T) {
+	tier := &types.Datacenter{
+		TierName:       "gke1",
+		Replicas:       1,
+		Resources:      &types.ResourceRequirements{Requests: &types.ResourceList{CPU: "100m", Memory: "128Mi"}},
+		LivenessProbe:  &types.Probe{Type: "grpc", Port: 9000},
+		ReadinessProbe: &types.Probe{Type: "tcp", Port: 9000},
+		ChaosMonkey:    &types.ChaosMonkey{},
+	}
+	app := &types.Configuration{
+		Application: "myapp",
+		DockerImage: "myapp",
+		Namespace:   "default",
+		Type:        "service",
+		Ports:       []types.Port{{Name: "grpc", ContainerPort: 9000}},
+	}
+
+	out, err := RenderManifests(app, tier, "production", "ealebed")
+	if err != nil {
+		t.Fatalf("RenderManifests returned error: %v", err)
+	}
+	if !strings.Contains(string(out), "grpc:") || !strings.Contains(string(out), "tcpSocket:") || strings.Contains(string(out), "service: null") {
+		t.Errorf("Expected grpc liveness and tcp readiness probes in manifest:\n%s", out)
+	}
+
+	tier.LivenessProbe = nil
+	if _, err := RenderManifests(app, tier, "production", "ealebed"); err == nil || !strings.Contains(err.Error(), "livenessProbe is required") {
+		t.Errorf("Expected missing livenessProbe error, got %v", err)
+	}
+}
+
+func TestRenderManifestsQuantities(t *testing.T) {
+	tier := &types.Datacenter{
+		TierName:      "gke1",
+		Replicas:      1,
+		Resources:     &types.ResourceRequirements{Requests: &types.ResourceList{CPU: "100m", Memory: "128Mi"}},
+		LivenessProbe: &types.Probe{Type: "http", Port: 8080},
+		ChaosMonkey:   &types.ChaosMonkey{},
+		Autoscaling: &types.Autoscaling{
+			MaxReplicas: 3,
+			Metrics:     []types.AutoscalingMetric{{Type: "pods", Name: "requests", TargetAverageValue: "100 rps"}},
+		},
+	}
+	app := &types.Configuration{
+		Application: "myapp",
+		DockerImage: "myapp",
+		Namespace:   "default",
+		Type:        "worker",
+	}
+
+	if _, err := RenderManifests(app, tier, "production", "ealebed"); err == nil || !strings.Contains(err.Error(), `"100 rps"`) {
+		t.Errorf("Expected invalid autoscaling target error, got %v", err)
+	}
+
+	tier.Autoscaling = nil
+	app.Volumes = []types.Volume{{Name: "cache", MountPath: "/cache", EmptyDir: &types.EmptyDirVolume{SizeLimit: "1 gig"}}}
+	if _, err := RenderManifests(app, tier, "production", "ealebed"); err == nil || !strings.Contains(err.Error(), `"1 gig"`) {
+		t.Errorf("Expected invalid emptyDir sizeLimit error, got %v", err)
+	}
+
+	app.Volumes = nil
+	app.Type = "statefulset"
+	app.StatefulSet = &types.StatefulSetSettings{VolumeClaimTemplates: []types.VolumeClaimTemplate{{Name: "data", MountPath: "/data", Size: "10 gigs"}}}
+	if _, err := RenderManifests(app, tier, "production", "ealebed"); err == nil || !strings.Contains(err.Error(), `"10 gigs"`) {
+		t.Errorf("Expected invalid volume claim size error, got %v", err)
+	}
+
+	app.StatefulSet = nil
+	tier.Env = &[]types.EnvVar{{Name: "MEMORY_MB", ValueFrom: &types.EnvVarSource{ResourceFieldRef: &types.ResourceFieldSelector{Resource: "limits.memory", Divisor: "1 MiB"}}}}
+	if _, err := RenderManifests(app, tier, "production", "ealebed"); err == nil || !strings.Contains(err.Error(), `"1 MiB"`) {
+		t.Errorf("Expected invalid divisor error, got %v", err)
+	}
+
+	tier.Env = nil
+	app.OrganizationResourcePolicy = &types.ResourcePolicy{MaxPod: &types.ResourceList{Memory: "64Mi"}}
+	if _, err := RenderManifests(app, tier, "production", "ealebed"); err == nil || !strings.Contains(err.Error(), "pod memory requests 128Mi exceed resource policy maximum 64Mi") {
+		t.Errorf("Expected exceeded maximum of organization resource policy, got %v", err)
+	}
+}
+
+func TestPruneManifest(t *testing.T) {
+	pod := &apiv1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{{Name: "app", Image: "app"}},
+			Volumes:    []apiv1.Volume{{Name: "cache", VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}}}},
+		},
+	}
+
+	raw, err := pruneManifest(pod)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"apiVersion":"v1","kind":"Pod","spec":{"containers":[{"image":"app","name":"app"}],"volumes":[{"emptyDir":{},"name":"cache"}]}}`
+	if string(raw) != expected {
+		t.Errorf("Expected %s, got %s", expected, raw)
+	}
+}
+
+func TestRenderManifestsWorkloads(t *testing.T) {
+	tests := []struct {
+		name       string
+		app        *types.Configuration
+		contains   []string
+		notContain []string
+	}{
+		{
+			name: "statefulset with headless service",
+			app: &types.Configuration{
+				Type:  types.WorkloadTypeStatefulSet,
+				Ports: []types.Port{{Name: "http", ContainerPort: 8080}},
+				StatefulSet: &types.StatefulSetSettings{
+					VolumeClaimTemplates: []types.VolumeClaimTemplate{{Name: "data", MountPath: "/data", Size: "1Gi"}},
+				},
 			},
+			contains:   []string{"kind: Service\n", "kind: StatefulSet", "serviceName: myapp", "storage: 1Gi", "kind: HorizontalPodAutoscaler"},
+			notContain: []string{"availableReplicas", "updateStrategy: {}"},
 		},
 		{
-			name:        "empty profile list",
-			stage:       "staging",
-			pList:       []string{},
-			pipelineIDs: map[string]string{},
-			expected: map[string]interface{}{
-				"stage":                   "staging",
-				"dockerTriggerEnabled":    true,
-				"GeneratePromotePipeline": false,
-				"pipelineTriggerEnabled":  false,
+			name: "cronjob without autoscaling",
+			app: &types.Configuration{
+				Type:    types.WorkloadTypeCronJob,
+				CronJob: &types.CronJobSettings{Schedule: "0 * * * *"},
 			},
+			contains:   []string{"kind: CronJob", "concurrencyPolicy: Forbid", "restartPolicy: OnFailure"},
+			notContain: []string{"kind: Service\n", "kind: HorizontalPodAutoscaler", "kind: PodDisruptionBudget", "metadata: {}"},
+		},
+		{
+			name:       "job recreated on deploy",
+			app:        &types.Configuration{Type: types.WorkloadTypeJob},
+			contains:   []string{"kind: Job", "strategy.spinnaker.io/recreate: \"true\"", "runAsNonRoot: true", "allowPrivilegeEscalation: false"},
+			notContain: []string{"kind: HorizontalPodAutoscaler", "kind: PodDisruptionBudget"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := fillPipelineConfig(tt.stage, tt.pList, tt.pipelineIDs)
+			tier := &types.Datacenter{
+				TierName:      "gke1",
+				Replicas:      2,
+				Resources:     &types.ResourceRequirements{Requests: &types.ResourceList{CPU: "100m", Memory: "128Mi"}},
+				LivenessProbe: &types.Probe{Type: "http", Port: 8080},
+				ChaosMonkey:   &types.ChaosMonkey{},
+				Autoscaling:   &types.Autoscaling{MinReplicas: 2, MaxReplicas: 4},
+			}
+			tt.app.Application = "myapp"
+			tt.app.DockerImage = "myapp"
+			tt.app.Namespace = "default"
 
-			// Check all expected keys
-			for key, expectedValue := range tt.expected {
-				if result[key] != expectedValue {
-					t.Errorf("fillPipelineConfig(%q, %v, %v)[%q] = %v, want %v",
-						tt.stage, tt.pList, tt.pipelineIDs, key, result[key], expectedValue)
+			out, err := RenderManifests(tt.app, tier, "production", "ealebed")
+			if err != nil {
+				t.Fatalf("RenderManifests returned error: %v", err)
+			}
+			for _, expected := range tt.contains {
+				if !strings.Contains(string(out), expected) {
+					t.Errorf("Expected %q in manifest:\n%s", expected, out)
 				}
 			}
+			for _, unexpected := range tt.notContain {
+				if strings.Contains(string(out), unexpected) {
+					t.Errorf("Unexpected %q in manifest:\n%s", unexpected, out)
+				}
+			}
+		})
+	}
+}
+
+func TestManifestApplication(t *testing.T) {
+	manifest := func(name, stack, generated string) []byte {
+		return []byte(`apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ServiceAccount
+  metadata:
+    name: ` + name + `
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: ` + name + `
+    annotations:
+      moniker.spinnaker.io/stack: ` + stack + `
+      ` + generated + `: spini/v1
+`)
+	}
+
+	tests := []struct {
+		name     string
+		content  []byte
+		expected string
+	}{
+		{name: "production", content: manifest("myapp", "production", "service.ealebed.dev/generated"), expected: "myapp"},
+		{name: "beta", content: manifest("myapp-beta", "beta", "service.ealebed.dev/generated"), expected: "myapp"},
+		{name: "not generated", content: manifest("myapp", "production", "service.other.dev/generated"), expected: ""},
+		{name: "statefulset", content: []byte(strings.Replace(string(manifest("myapp", "production", "service.ealebed.dev/generated")), "kind: Deployment", "kind: StatefulSet", 1)), expected: "myapp"},
+		{name: "invalid yaml", content: []byte("items: ["), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ManifestApplication(tt.content, "ealebed"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPipelineID(t *testing.T) {
+	id := PipelineID("myapp", "beta", "gke1")
+
+	if id != PipelineID("myapp", "beta", "gke1") {
+		t.Errorf("Expected stable pipeline ID, got %s and %s", id, PipelineID("myapp", "beta", "gke1"))
+	}
+	for _, other := range []string{
+		PipelineID("otherapp", "beta", "gke1"),
+		PipelineID("myapp", "production", "gke1"),
+		PipelineID("myapp", "beta", "gke2"),
+		PipelineID("myapp", "beta", ""),
+	} {
+		if other == id {
+			t.Errorf("Expected different pipeline IDs, got %s twice", id)
+		}
+	}
+}
+
+func TestGeneratedPipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		expected bool
+	}{
+		{name: "build-image", id: PipelineID("myapp", "", ""), expected: true},
+		{name: "promote-to-production", id: PipelineID("myapp", stageProduction, ""), expected: true},
+		{name: "deploy-gke-eu-1-dc(beta)", id: PipelineID("myapp", "beta", "gke-eu-1"), expected: true},
+		{name: "deploy-gke1-dc(beta)", id: PipelineID("myapp", "production", "gke1"), expected: false},
+		{name: "deploy-gke1-dc(beta)", id: "3f1c8d4e-random-id", expected: false},
+		{name: "rollback-gke1", id: PipelineID("myapp", "", "gke1"), expected: false},
+	}
 
-			// Ensure result has stage
-			if result["stage"] != tt.stage {
-				t.Errorf("fillPipelineConfig(%q, %v, %v)[\"stage\"] = %v, want %v",
-					tt.stage, tt.pList, tt.pipelineIDs, result["stage"], tt.stage)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := GeneratedPipeline("myapp", tt.name, tt.id); result != tt.expected {
+				t.Errorf("Expected %v for %s with ID %s, got %v", tt.expected, tt.name, tt.id, result)
 			}
 		})
 	}
 }
+
+func TestGeneratePipelinesIDs(t *testing.T) {
+	newApp := func() *types.Configuration {
+		return &types.Configuration{
+			Application: "myapp",
+			DockerImage: "myapp",
+			Namespace:   "default",
+			Owners:      "team",
+			Version:     "1.0.0",
+			Profiles: &[]*types.Profile{
+				{ProfileName: "beta", Datacenters: &[]*types.Datacenter{{TierName: "gke1"}}},
+				{
+					ProfileName:  stageProduction,
+					PromotesFrom: &types.Promotion{ProfileName: "beta"},
+					Datacenters:  &[]*types.Datacenter{{TierName: "gke1"}},
+				},
+			},
+		}
+	}
+
+	pipelineIDs := func(pipelines []*types.Pipeline) map[string]string {
+		ids := map[string]string{}
+		for _, pipeline := range pipelines {
+			ids[pipeline.Name] = pipeline.ID
+		}
+		return ids
+	}
+
+	parentPipelineID := func(pipelines []*types.Pipeline, name string) string {
+		for _, pipeline := range pipelines {
+			if pipeline.Name != name {
+				continue
+			}
+			for _, trigger := range pipeline.Triggers {
+				if trigger.Type == "pipeline" {
+					return trigger.Pipeline
+				}
+			}
+		}
+		return ""
+	}
+
+	generate := func(liveIDs map[string]string) []*types.Pipeline {
+		pipelines, err := GeneratePipelines(newApp(), "ealebed", "test-k8s", liveIDs)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return pipelines
+	}
+
+	first := generate(nil)
+	second := generate(nil)
+
+	ids := pipelineIDs(first)
+	for name, id := range pipelineIDs(second) {
+		if id == "" || ids[name] != id {
+			t.Errorf("Expected stable ID of pipeline %s, got %q and %q", name, ids[name], id)
+		}
+	}
+
+	if ids["promote-to-production"] != PipelineID("myapp", stageProduction, "") {
+		t.Errorf("Unexpected promote pipeline ID %s", ids["promote-to-production"])
+	}
+	if parent := parentPipelineID(first, "promote-to-production"); parent != ids["deploy-gke1-dc(beta)"] {
+		t.Errorf("Expected promote pipeline to be triggered by %s, got %s", ids["deploy-gke1-dc(beta)"], parent)
+	}
+
+	// live IDs of existing pipelines take precedence, so triggers reference pipelines which already exist
+	live := generate(map[string]string{"deploy-gke1-dc(beta)": "live-beta-id"})
+	if parent := parentPipelineID(live, "promote-to-production"); parent != "live-beta-id" {
+		t.Errorf("Expected promote pipeline to be triggered by live pipeline, got %s", parent)
+	}
+	if id := pipelineIDs(live)["deploy-gke1-dc(production)"]; id != ids["deploy-gke1-dc(production)"] {
+		t.Errorf("Expected generated ID of not existing pipeline, got %s", id)
+	}
+}
+
+func TestGeneratePipelinesEnvFrom(t *testing.T) {
+	app := &types.Configuration{
+		Application: "myapp",
+		DockerImage: "myapp",
+		Namespace:   "default",
+		Version:     "1.0.0",
+		EnvFrom:     []string{"common-configmap"},
+		Profiles: &[]*types.Profile{{ProfileName: stageProduction, Datacenters: &[]*types.Datacenter{
+			{TierName: "gke1", EnvFrom: []string{"gke1-configmap"}},
+			{TierName: "gke2"},
+		}}},
+	}
+
+	artifacts := func(pipeline *types.Pipeline) []string {
+		names := []string{}
+		for _, artifact := range pipeline.ExpectedArtifacts {
+			if strings.HasPrefix(artifact.DefaultArtifact.Name, "datacenters/_commons/") {
+				names = append(names, artifact.DefaultArtifact.Name)
+			}
+		}
+		return names
+	}
+
+	for range 2 {
+		pipelines, err := GeneratePipelines(app, "ealebed", "test-k8s", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := map[string]string{
+			"deploy-gke1-dc(production)": "datacenters/_commons/common-configmap.yaml,datacenters/_commons/gke1-configmap.yaml",
+			"deploy-gke2-dc(production)": "datacenters/_commons/common-configmap.yaml",
+		}
+		for _, pipeline := range pipelines {
+			if names, ok := expected[pipeline.Name]; ok && strings.Join(artifacts(pipeline), ",") != names {
+				t.Errorf("Expected envFrom artifacts %s of %s, got %v", names, pipeline.Name, artifacts(pipeline))
+			}
+		}
+		if strings.Join(app.EnvFrom, ",") != "common-configmap" {
+			t.Errorf("Expected application envFrom not to be changed, got %v", app.EnvFrom)
+		}
+	}
+}
+
+func TestGeneratePipelinesRegistry(t *testing.T) {
+	// stand-in of private registry implementing OCI distribution API
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/team/myapp/tags/list":
+			fmt.Fprint(w, `{"name": "team/myapp", "tags": ["24.01.01-10.00", "24.02.01-10.00"]}`)
+		case "/v2/team/maxmind-geoip/tags/list":
+			fmt.Fprint(w, `{"name": "team/maxmind-geoip", "tags": ["24.01.15-08.00"]}`)
+		case "/v2/team/empty/tags/list":
+			fmt.Fprint(w, `{"name": "team/empty", "tags": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	newApp := func(image string) *types.Configuration {
+		return &types.Configuration{
+			Application: "myapp",
+			DockerImage: image,
+			Namespace:   "default",
+			Registry:    &types.Registry{Type: types.RegistryTypeOCI, Host: host, Namespace: "team", Insecure: true},
+			DependsOn:   []types.DependsOn{{Name: "maxmind"}},
+			Profiles: &[]*types.Profile{
+				{ProfileName: stageProduction, Datacenters: &[]*types.Datacenter{{TierName: "gke1"}}},
+			},
+		}
+	}
+
+	app := newApp("myapp")
+	pipelines, err := GeneratePipelines(app, "ealebed", "test-k8s", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if app.Version != "24.02.01-10.00" {
+		t.Errorf("Expected the most recent tag as version, got %q", app.Version)
+	}
+
+	references := []string{}
+	deploy := pipelines[len(pipelines)-1]
+	for _, artifact := range deploy.ExpectedArtifacts {
+		if artifact.DefaultArtifact.Type == "docker/image" {
+			references = append(references, artifact.DefaultArtifact.Reference)
+		}
+	}
+	expected := []string{host + "/team/maxmind-geoip:24.01.15-08.00", host + "/team/myapp:24.02.01-10.00"}
+	if strings.Join(references, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected docker artifacts %v, got %v", expected, references)
+	}
+
+	for _, trigger := range deploy.Triggers {
+		if trigger.Type == "docker" && (trigger.Registry != host || trigger.Organization != "team") {
+			t.Errorf("Expected docker trigger on %s/team, got %s/%s", host, trigger.Registry, trigger.Organization)
+		}
+	}
+
+	if _, err := GeneratePipelines(newApp("empty"), "ealebed", "test-k8s", nil); err == nil {
+		t.Error("Expected error for image without tags")
+	}
+	if _, err := GeneratePipelines(newApp("missing"), "ealebed", "test-k8s", nil); err == nil {
+		t.Error("Expected error for missing image")
+	}
+}